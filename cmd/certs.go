@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"syspulse/internal/display"
+	"syspulse/internal/monitor"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	certsTargets     []string
+	certsWarnDays    int
+	certsNoListeners bool
+)
+
+var certsCmd = &cobra.Command{
+	Use:   "certs",
+	Short: "扫描 TLS 证书过期时间",
+	Long:  "对本机每个 TCP 监听端口和配置的 host:port 进行 TLS 握手，显示证书主体、SAN、颁发者和剩余天数，标记过期、即将过期和自签名的证书",
+	Run: func(cmd *cobra.Command, args []string) {
+		opts := cfg.CertScanOptions()
+		opts.Targets = append(opts.Targets, certsTargets...)
+		if cmd.Flags().Changed("warn-days") {
+			opts.WarnDays = certsWarnDays
+		}
+		if certsNoListeners {
+			opts.ScanListeners = false
+		}
+
+		display.Clear()
+		display.PrintHeader("🔐 TLS 证书")
+
+		certsInfo, ok := collect("certificates", "TLS 证书", func(ctx context.Context) monitor.CertificatesInfo {
+			return monitor.GetCertificateInfo(ctx, opts)
+		})
+		if !ok {
+			return
+		}
+		if len(certsInfo.Certificates) == 0 {
+			display.PrintWarning(fmt.Sprintf("⚠️  扫描了 %d 个地址，未发现 TLS 证书", certsInfo.ScannedCount))
+			return
+		}
+
+		display.PrintCertificates(certsInfo)
+
+		fmt.Println()
+		display.PrintFooter("数据更新时间: " + certsInfo.Timestamp.Format("2006-01-02 15:04:05"))
+	},
+}
+
+func init() {
+	certsCmd.Flags().StringSliceVarP(&certsTargets, "target", "t", nil, "额外扫描的 host:port（可重复指定）")
+	certsCmd.Flags().IntVar(&certsWarnDays, "warn-days", 30, "剩余天数低于该值时视为即将过期")
+	certsCmd.Flags().BoolVar(&certsNoListeners, "no-listeners", false, "不扫描本机监听端口，只扫描 --target 和配置的地址")
+}
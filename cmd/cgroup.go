@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"syspulse/internal/display"
+	"syspulse/internal/monitor"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	cgroupTop   int
+	cgroupDepth int
+	cgroupSort  string
+	cgroupRoot  string
+)
+
+var cgroupCmd = &cobra.Command{
+	Use:   "cgroup",
+	Short: "显示 cgroup 资源占用",
+	Long:  "直接读取 cgroup v2 (/sys/fs/cgroup)，以树形显示资源占用最高的 slice 和 service，不依赖容器运行时",
+	Run: func(cmd *cobra.Command, args []string) {
+		display.Clear()
+		display.PrintHeader("🧩 cgroup 资源占用")
+
+		cgroupInfo, ok := collect("cgroup", "cgroup", func(ctx context.Context) monitor.CgroupInfo {
+			return monitor.GetCgroupInfoFrom(ctx, cgroupRoot)
+		})
+		if !ok {
+			return
+		}
+		if !cgroupInfo.Available {
+			display.PrintError("❌ 未检测到 cgroup v2")
+			fmt.Printf("   请确认 %s 以 cgroup2 方式挂载\n", cgroupRoot)
+			return
+		}
+
+		monitor.SortCgroupTree(&cgroupInfo.Tree, cgroupSort)
+		display.PrintCgroupInfo(cgroupInfo, cgroupTop, cgroupDepth)
+
+		fmt.Println()
+		display.PrintFooter("数据更新时间: " + cgroupInfo.Timestamp.Format("2006-01-02 15:04:05"))
+	},
+}
+
+func init() {
+	cgroupCmd.Flags().IntVarP(&cgroupTop, "top", "t", 5, "每层显示 Top N 子节点")
+	cgroupCmd.Flags().IntVarP(&cgroupDepth, "depth", "d", 2, "最大显示深度（0 表示不限制）")
+	cgroupCmd.Flags().StringVarP(&cgroupSort, "sort", "s", "cpu", "排序字段: cpu, memory, io, pids")
+	cgroupCmd.Flags().StringVar(&cgroupRoot, "root", monitor.DefaultCgroupRoot, "cgroup v2 挂载点")
+}
@@ -17,7 +17,10 @@ var cpuCmd = &cobra.Command{
 		display.Clear()
 		display.PrintHeader("🔥 CPU 信息")
 
-		cpuInfo := monitor.GetCPUInfo()
+		cpuInfo, ok := collect("cpu", "CPU", monitor.GetCPUInfo)
+		if !ok {
+			return
+		}
 		display.PrintCPUInfoDetailed(cpuInfo)
 
 		fmt.Println()
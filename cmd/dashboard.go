@@ -1,10 +1,12 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"time"
 
+	"syspulse/internal/alert"
 	"syspulse/internal/display"
 	"syspulse/internal/monitor"
 
@@ -14,6 +16,11 @@ import (
 var (
 	watchMode bool
 	interval  int
+
+	// dashboardScrape 抓取采集器在刷新之间保留，用上一次的样本计算 counter 的速率
+	dashboardScrape monitor.Collector
+	// dashboardPlugins 插件采集器在刷新之间保留，未到插件的执行间隔时显示上一次的结果
+	dashboardPlugins []monitor.Collector
 )
 
 var dashboardCmd = &cobra.Command{
@@ -35,49 +42,117 @@ func init() {
 }
 
 func showDashboard() {
+	// 所有部分并发采集，各自有超时，卡住的部分（如无响应的 Docker）不会拖住其他部分
+	diskFilter, netFilter := cfg.DiskFilter(), cfg.NetworkFilter()
+	sysInfo := collectAsync("system", "系统信息", monitor.GetSystemInfo)
+	cpuInfo := collectAsync("cpu", "CPU", monitor.GetCPUInfo)
+	memInfo := collectAsync("memory", "内存", monitor.GetMemoryInfo)
+	pressureInfo := collectAsync("pressure", "资源压力", monitor.GetPressureInfo)
+	diskInfo := collectAsync("disk", "磁盘", func(ctx context.Context) monitor.DiskInfo {
+		return monitor.GetDiskInfo(ctx, diskFilter)
+	})
+	netInfo := collectAsync("network", "网络", func(ctx context.Context) monitor.NetworkInfo {
+		return monitor.GetNetworkInfo(ctx, netFilter)
+	})
+	dockerInfo := collectAsync("docker", "Docker", monitor.GetDockerInfo)
+	sensorsInfo := collectAsync("sensors", "传感器", monitor.GetSensorsInfo)
+	protocolStats := collectAsync("protocols", "协议统计", monitor.GetProtocolStats)
+	if dashboardPlugins == nil {
+		dashboardPlugins = cachePlugins(cfg.PluginCollectors())
+	}
+	plugins := startPlugins(dashboardPlugins)
+	if dashboardScrape == nil {
+		dashboardScrape = cfg.ScrapeCollector()
+	}
+	var scrapeInfo *collecting[monitor.Sample]
+	if dashboardScrape != nil {
+		scrapeInfo = collectAsync("scrape", "应用指标", dashboardScrape.Collect)
+	}
+
 	// 清屏
 	display.Clear()
 
 	// 显示标题
 	display.PrintHeader("💻 SYSTEM PULSE - 系统概览")
 
-	// 获取系统信息
-	sysInfo := monitor.GetSystemInfo()
-	display.PrintSystemInfo(sysInfo)
+	// 系统信息
+	if info, ok := sysInfo.wait(); ok {
+		display.PrintSystemInfo(info)
+	}
 
 	fmt.Println()
 
 	// CPU 信息
-	cpuInfo := monitor.GetCPUInfo()
-	display.PrintCPUInfo(cpuInfo)
+	if info, ok := cpuInfo.wait(); ok {
+		display.PrintCPUInfo(info)
+	}
 
 	fmt.Println()
 
 	// 内存信息
-	memInfo := monitor.GetMemoryInfo()
-	display.PrintMemoryInfo(memInfo)
+	if info, ok := memInfo.wait(); ok {
+		display.PrintMemoryInfo(info)
+	}
+
+	fmt.Println()
+
+	// 资源压力
+	metrics := alert.Metrics{}
+	if info, ok := pressureInfo.wait(); ok {
+		display.PrintPressureInfo(info)
+		metrics.AddPressure(info)
+	}
 
 	fmt.Println()
 
+	// 本机服务的指标，与主机资源放在一起
+	if scrapeInfo != nil {
+		if sample, ok := scrapeInfo.wait(); ok {
+			display.PrintScrapeInfo(sample.Info.(monitor.ScrapeInfo))
+		}
+		fmt.Println()
+	}
+
 	// 磁盘信息
-	diskInfo := monitor.GetDiskInfo()
-	display.PrintDiskInfo(diskInfo)
+	if info, ok := diskInfo.wait(); ok {
+		display.PrintDiskInfo(info)
+	}
 
 	fmt.Println()
 
 	// 网络信息
-	netInfo := monitor.GetNetworkInfo()
-	display.PrintNetworkInfo(netInfo)
+	if info, ok := netInfo.wait(); ok {
+		display.PrintNetworkInfo(info)
+	}
 
 	fmt.Println()
 
 	// Docker 容器信息
-	dockerInfo := monitor.GetDockerInfo()
-	if dockerInfo.Available {
-		display.PrintDockerInfo(dockerInfo)
-	} else {
-		display.PrintWarning("🐳 Docker 不可用或未运行")
+	if info, ok := dockerInfo.wait(); ok {
+		if info.Available {
+			display.PrintDockerInfo(info)
+		} else {
+			display.PrintWarning("🐳 Docker 不可用或未运行")
+			display.PrintFieldErrors(info.Errors)
+		}
+	}
+
+	fmt.Println()
+
+	// 插件输出的自定义指标
+	if len(plugins) > 0 {
+		printPlugins(plugins)
+		fmt.Println()
+	}
+
+	// 告警（超时的部分不参与求值）
+	if info, ok := sensorsInfo.wait(); ok {
+		metrics.AddSensors(info)
+	}
+	if info, ok := protocolStats.wait(); ok {
+		metrics.AddProtocolStats(info)
 	}
+	display.PrintAlerts(alert.Evaluate(alert.DefaultRules(), metrics))
 
 	fmt.Println()
 	display.PrintFooter("按 Ctrl+C 退出")
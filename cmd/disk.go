@@ -1,7 +1,9 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	"syspulse/internal/display"
 	"syspulse/internal/monitor"
@@ -9,6 +11,10 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var (
+	diskSample time.Duration
+)
+
 var diskCmd = &cobra.Command{
 	Use:   "disk",
 	Short: "显示磁盘信息 (类似 df -h)",
@@ -17,10 +23,32 @@ var diskCmd = &cobra.Command{
 		display.Clear()
 		display.PrintHeader("💿 磁盘使用情况 (df -h) - 按使用率降序")
 
-		diskInfo := monitor.GetDiskInfo()
+		filter := cfg.DiskFilter()
+		collectDisk := func() (monitor.DiskInfo, bool) {
+			return collect("disk", "磁盘", func(ctx context.Context) monitor.DiskInfo {
+				return monitor.GetDiskInfo(ctx, filter)
+			})
+		}
+
+		diskInfo, ok := collectDisk()
+		// 写满预计时间需要多次采样，在 --sample 时间内均匀采样
+		for i := 1; ok && diskSample > 0 && i < monitor.DiskGrowthMinSamples; i++ {
+			time.Sleep(diskSample / time.Duration(monitor.DiskGrowthMinSamples-1))
+			diskInfo, ok = collectDisk()
+		}
+		if !ok {
+			return
+		}
 		display.PrintDiskInfoDetailed(diskInfo)
+		if diskSample == 0 {
+			fmt.Println("💡 预计写满时间需要多次采样：使用 --sample 30s 观察一段时间，或在 web 模式中查看")
+		}
 
 		fmt.Println()
 		display.PrintFooter("数据更新时间: " + diskInfo.Timestamp.Format("2006-01-02 15:04:05"))
 	},
 }
+
+func init() {
+	diskCmd.Flags().DurationVar(&diskSample, "sample", 0, "在该时间内多次采样，计算增长速度和预计写满时间（如 30s）")
+}
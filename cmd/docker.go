@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -39,10 +40,14 @@ func showDockerInfo() {
 	display.Clear()
 	display.PrintHeader("🐳 Docker 容器监控")
 
-	dockerInfo := monitor.GetDockerInfo()
+	dockerInfo, ok := collect("docker", "Docker", monitor.GetDockerInfo)
+	if !ok {
+		return
+	}
 
 	if !dockerInfo.Available {
 		display.PrintError("❌ Docker 不可用")
+		display.PrintFieldErrors(dockerInfo.Errors)
 		fmt.Println("   请确保:")
 		fmt.Println("   1. Docker 已安装")
 		fmt.Println("   2. Docker 服务正在运行")
@@ -52,7 +57,12 @@ func showDockerInfo() {
 
 	if containerID != "" {
 		// 显示特定容器的详细信息
-		containerInfo := monitor.GetContainerDetail(containerID)
+		containerInfo, ok := collect("docker", "容器详情", func(ctx context.Context) monitor.ContainerInfo {
+			return monitor.GetContainerDetail(ctx, containerID)
+		})
+		if !ok {
+			return
+		}
 		display.PrintContainerDetail(containerInfo)
 	} else {
 		// 显示所有容器概览
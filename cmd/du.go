@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"syspulse/internal/display"
+	"syspulse/internal/monitor"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	duTop     int
+	duDepth   int
+	duTimeout int
+)
+
+var duCmd = &cobra.Command{
+	Use:   "du [path]",
+	Short: "分析目录占用 (类似 du)",
+	Long:  "并发扫描目录树（不跨越文件系统），按大小排序显示最大的目录和文件",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		path := "/"
+		if len(args) > 0 {
+			path = args[0]
+		}
+
+		display.Clear()
+		display.PrintHeader("📂 目录占用分析")
+
+		usage, err := monitor.GetDirUsage(context.Background(), path, monitor.DirUsageOptions{
+			MaxDepth: duDepth,
+			TopN:     duTop,
+			Timeout:  time.Duration(duTimeout) * time.Second,
+		})
+		if err != nil {
+			display.PrintError(fmt.Sprintf("❌ 扫描失败: %v", err))
+			return
+		}
+
+		display.PrintDirUsage(usage)
+
+		fmt.Println()
+		display.PrintFooter("数据更新时间: " + usage.Timestamp.Format("2006-01-02 15:04:05"))
+	},
+}
+
+func init() {
+	defaults := monitor.DefaultDirUsageOptions()
+	duCmd.Flags().IntVarP(&duTop, "top", "t", defaults.TopN, "每层显示 Top N 目录及最大文件数")
+	duCmd.Flags().IntVarP(&duDepth, "depth", "d", defaults.MaxDepth, "目录树显示深度")
+	duCmd.Flags().IntVar(&duTimeout, "timeout", int(defaults.Timeout.Seconds()), "扫描超时（秒）")
+
+	diskCmd.AddCommand(duCmd)
+}
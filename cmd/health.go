@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"fmt"
+
+	"syspulse/internal/display"
+	"syspulse/internal/monitor"
+
+	"github.com/spf13/cobra"
+)
+
+var healthCmd = &cobra.Command{
+	Use:   "health",
+	Short: "显示磁盘 SMART / NVMe 健康状态",
+	Long:  "调用 smartctl 读取每块磁盘的温度、寿命、重映射/待映射扇区、介质错误和通电时间（通常需要 root 权限）",
+	Run: func(cmd *cobra.Command, args []string) {
+		display.Clear()
+		display.PrintHeader("🩺 磁盘健康")
+
+		healthInfo, ok := collect("disk_health", "磁盘健康", monitor.GetDriveHealth)
+		if !ok {
+			return
+		}
+		if !healthInfo.Available {
+			display.PrintWarning("⚠️  " + healthInfo.Error)
+			return
+		}
+		if len(healthInfo.Drives) == 0 {
+			display.PrintWarning("⚠️  未检测到物理磁盘")
+			return
+		}
+
+		display.PrintDriveHealth(healthInfo)
+
+		fmt.Println()
+		display.PrintFooter("数据更新时间: " + healthInfo.Timestamp.Format("2006-01-02 15:04:05"))
+	},
+}
+
+func init() {
+	diskCmd.AddCommand(healthCmd)
+}
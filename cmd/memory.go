@@ -17,7 +17,10 @@ var memoryCmd = &cobra.Command{
 		display.Clear()
 		display.PrintHeader("💾 内存信息")
 
-		memInfo := monitor.GetMemoryInfo()
+		memInfo, ok := collect("memory", "内存", monitor.GetMemoryInfo)
+		if !ok {
+			return
+		}
 		display.PrintMemoryInfoDetailed(memInfo)
 
 		fmt.Println()
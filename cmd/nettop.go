@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"syspulse/internal/display"
+	"syspulse/internal/monitor"
+
+	"github.com/spf13/cobra"
+)
+
+var netTopCount int
+
+var netTopCmd = &cobra.Command{
+	Use:   "top",
+	Short: "按进程和容器显示网络带宽 (类似 nethogs)",
+	Long:  "通过 sock_diag 读取每个 TCP 连接的收发字节，关联到进程和容器并计算每秒带宽（非 root 运行时只能关联当前用户的进程）",
+	Run: func(cmd *cobra.Command, args []string) {
+		display.Clear()
+		display.PrintHeader("🔀 进程网络带宽")
+
+		netInfo, ok := collect("nettop", "进程网络带宽", func(ctx context.Context) monitor.ProcessNetworkInfo {
+			return monitor.GetProcessNetworkInfo(ctx, netTopCount)
+		})
+		if !ok {
+			return
+		}
+		if !netInfo.Available {
+			display.PrintError("❌ " + netInfo.Error)
+			return
+		}
+
+		display.PrintProcessNetworkInfo(netInfo)
+
+		fmt.Println()
+		display.PrintFooter("数据更新时间: " + netInfo.Timestamp.Format("2006-01-02 15:04:05"))
+	},
+}
+
+func init() {
+	netTopCmd.Flags().IntVarP(&netTopCount, "top", "t", 20, "显示 Top N 进程")
+
+	networkCmd.AddCommand(netTopCmd)
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 
 	"syspulse/internal/display"
@@ -12,14 +13,26 @@ import (
 var networkCmd = &cobra.Command{
 	Use:   "network",
 	Short: "显示网络信息",
-	Long:  "显示网络接口、流量统计和连接信息",
+	Long:  "显示网络接口、流量统计、TCP / UDP 协议计数器和 conntrack 使用率",
 	Run: func(cmd *cobra.Command, args []string) {
 		display.Clear()
 		display.PrintHeader("🌐 网络信息")
 
-		netInfo := monitor.GetNetworkInfo()
+		filter := cfg.NetworkFilter()
+		protocols := collectAsync("protocols", "协议统计", monitor.GetProtocolStats)
+		netInfo, ok := collect("network", "网络", func(ctx context.Context) monitor.NetworkInfo {
+			return monitor.GetNetworkInfo(ctx, filter)
+		})
+		if !ok {
+			return
+		}
 		display.PrintNetworkInfoDetailed(netInfo)
 
+		if protocolStats, ok := protocols.wait(); ok && protocolStats.Available {
+			fmt.Println()
+			display.PrintProtocolStats(protocolStats)
+		}
+
 		fmt.Println()
 		display.PrintFooter("数据更新时间: " + netInfo.Timestamp.Format("2006-01-02 15:04:05"))
 	},
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"syspulse/internal/display"
+	"syspulse/internal/monitor"
+
+	"github.com/spf13/cobra"
+)
+
+var pluginsCmd = &cobra.Command{
+	Use:   "plugins",
+	Short: "执行插件并显示自定义指标",
+	Long:  "执行配置文件 plugins 中的外部插件各一次，显示它们输出的指标（用于调试插件）",
+	Run: func(cmd *cobra.Command, args []string) {
+		display.Clear()
+		display.PrintHeader("🧩 自定义指标")
+
+		collectors := cfg.PluginCollectors()
+		if len(collectors) == 0 {
+			display.PrintWarning("⚠️  未配置插件，请在配置文件的 plugins 中添加")
+			return
+		}
+
+		printPlugins(startPlugins(collectors))
+
+		fmt.Println()
+		display.PrintFooter("数据更新时间: " + time.Now().Format("2006-01-02 15:04:05"))
+	},
+}
+
+// startPlugins 在后台并发执行所有插件
+func startPlugins(collectors []monitor.Collector) []*collecting[monitor.Sample] {
+	running := make([]*collecting[monitor.Sample], 0, len(collectors))
+	for _, c := range collectors {
+		c := c
+		running = append(running, collectAsync(c.Name(), c.Name(), func(ctx context.Context) monitor.Sample {
+			return c.Collect(ctx)
+		}))
+	}
+	return running
+}
+
+// cachedCollector 在采集器的执行间隔内复用上一次成功的样本，
+// 用于 dashboard --watch：刷新间隔通常比插件的执行间隔短，不必每次刷新都执行插件
+type cachedCollector struct {
+	monitor.Collector
+
+	mu   sync.Mutex // 同时也避免上一次未结束（已超时）时重复执行
+	last monitor.Sample
+	at   time.Time
+}
+
+// cachePlugins 为每个插件创建可在刷新之间复用的采集器
+func cachePlugins(collectors []monitor.Collector) []monitor.Collector {
+	cached := make([]monitor.Collector, 0, len(collectors))
+	for _, c := range collectors {
+		cached = append(cached, &cachedCollector{Collector: c})
+	}
+	return cached
+}
+
+func (c *cachedCollector) Collect(ctx context.Context) monitor.Sample {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.at.IsZero() && time.Since(c.at) < c.Interval() {
+		return c.last
+	}
+	sample := c.Collector.Collect(ctx)
+	// 超时的结果不缓存，下次刷新重新执行
+	if ctx.Err() == nil {
+		c.last, c.at = sample, time.Now()
+	}
+	return sample
+}
+
+// printPlugins 按配置顺序等待并打印插件的结果
+func printPlugins(running []*collecting[monitor.Sample]) {
+	for i, plugin := range running {
+		if i > 0 {
+			fmt.Println()
+		}
+		if sample, ok := plugin.wait(); ok {
+			display.PrintPluginInfo(sample.Info.(monitor.PluginInfo))
+		}
+	}
+}
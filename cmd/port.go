@@ -17,7 +17,10 @@ var portCmd = &cobra.Command{
 		display.Clear()
 		display.PrintHeader("🔌 端口监听信息")
 
-		portInfo := monitor.GetPortInfo()
+		portInfo, ok := collect("ports", "端口", monitor.GetPortInfo)
+		if !ok {
+			return
+		}
 		display.PrintPortInfo(portInfo)
 
 		fmt.Println()
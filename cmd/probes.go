@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"syspulse/internal/display"
+	"syspulse/internal/probe"
+
+	"github.com/spf13/cobra"
+)
+
+var probesWatch bool
+
+var probesCmd = &cobra.Command{
+	Use:   "probes",
+	Short: "执行连通性和延迟探测",
+	Long:  "按配置文件中的 probes 设置执行 TCP 连接、HTTP(S) 请求、DNS 解析和 ICMP ping 探测",
+	Run: func(cmd *cobra.Command, args []string) {
+		targets := cfg.ProbeTargets()
+		if len(targets) == 0 {
+			display.PrintWarning("⚠️  未配置探测目标，请在配置文件的 probes.targets 中添加")
+			return
+		}
+
+		showProbes(targets)
+		if !probesWatch {
+			return
+		}
+
+		interval := cfg.Probes.Interval
+		if interval <= 0 {
+			interval = probe.DefaultInterval
+		}
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			showProbes(targets)
+		}
+	},
+}
+
+func showProbes(targets []probe.Target) {
+	results := probe.RunAll(context.Background(), targets)
+	info := probe.Summarize(results, cfg.Probes.Interval)
+
+	display.Clear()
+	display.PrintHeader("📡 连通性探测")
+	display.PrintProbes(info)
+
+	fmt.Println()
+	display.PrintFooter("数据更新时间: " + info.LastRun.Format("2006-01-02 15:04:05"))
+}
+
+func init() {
+	probesCmd.Flags().BoolVarP(&probesWatch, "watch", "w", false, "按配置的间隔持续探测")
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 
 	"syspulse/internal/display"
@@ -21,7 +22,12 @@ var processCmd = &cobra.Command{
 		display.Clear()
 		display.PrintHeader("⚙️  进程信息")
 
-		processInfo := monitor.GetProcessInfo(topN)
+		processInfo, ok := collect("process", "进程", func(ctx context.Context) monitor.ProcessInfo {
+			return monitor.GetProcessInfo(ctx, topN)
+		})
+		if !ok {
+			return
+		}
 		display.PrintProcessInfo(processInfo)
 
 		fmt.Println()
@@ -1,12 +1,23 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"time"
+
+	"syspulse/internal/config"
+	"syspulse/internal/display"
+	"syspulse/internal/monitor"
 
 	"github.com/spf13/cobra"
 )
 
+var (
+	configPath string
+	cfg        = config.Default()
+)
+
 var rootCmd = &cobra.Command{
 	Use:   "syspulse",
 	Short: "🚀 SysPulse - 超级易用的 Linux 系统资源监控工具",
@@ -30,7 +41,53 @@ func Execute() {
 	}
 }
 
+// loadConfig 加载配置文件
+func loadConfig() {
+	loaded, err := config.Load(configPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "❌", err)
+		os.Exit(1)
+	}
+	cfg = loaded
+}
+
+// collecting 后台进行中的采集
+type collecting[T any] struct {
+	title   string
+	timeout time.Duration
+	done    chan struct{}
+	value   T
+	ok      bool
+}
+
+// collectAsync 在后台按子系统的采集超时执行采集，title 用于超时提示
+func collectAsync[T any](subsystem, title string, fn func(context.Context) T) *collecting[T] {
+	c := &collecting[T]{title: title, timeout: cfg.CollectTimeout(subsystem), done: make(chan struct{})}
+	go func() {
+		defer close(c.done)
+		c.value, c.ok = monitor.Collect(context.Background(), c.timeout, fn)
+	}()
+	return c
+}
+
+// wait 等待采集完成，超时时打印提示并返回 false
+func (c *collecting[T]) wait() (T, bool) {
+	<-c.done
+	if !c.ok {
+		display.PrintTimedOut(c.title, c.timeout)
+	}
+	return c.value, c.ok
+}
+
+// collect 按子系统的采集超时执行采集，超时时打印提示并返回 false
+func collect[T any](subsystem, title string, fn func(context.Context) T) (T, bool) {
+	return collectAsync(subsystem, title, fn).wait()
+}
+
 func init() {
+	cobra.OnInitialize(loadConfig)
+	rootCmd.PersistentFlags().StringVar(&configPath, "config", "", "配置文件路径（默认依次查找 ./syspulse.yaml、~/.config/syspulse/config.yaml、/etc/syspulse/config.yaml）")
+
 	rootCmd.AddCommand(dashboardCmd)
 	rootCmd.AddCommand(cpuCmd)
 	rootCmd.AddCommand(memoryCmd)
@@ -39,5 +96,12 @@ func init() {
 	rootCmd.AddCommand(portCmd)
 	rootCmd.AddCommand(processCmd)
 	rootCmd.AddCommand(dockerCmd)
+	rootCmd.AddCommand(cgroupCmd)
+	rootCmd.AddCommand(servicesCmd)
+	rootCmd.AddCommand(sensorsCmd)
+	rootCmd.AddCommand(probesCmd)
+	rootCmd.AddCommand(certsCmd)
+	rootCmd.AddCommand(pluginsCmd)
+	rootCmd.AddCommand(scrapeCmd)
 	rootCmd.AddCommand(webCmd)
 }
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"syspulse/internal/display"
+	"syspulse/internal/monitor"
+
+	"github.com/spf13/cobra"
+)
+
+var scrapeList bool
+
+var scrapeCmd = &cobra.Command{
+	Use:   "scrape",
+	Short: "抓取本机服务的 Prometheus 指标",
+	Long:  "按配置文件中的 scrape 设置抓取本机服务的 Prometheus 指标，显示固定序列的当前值（counter 换算为每秒速率）",
+	Run: func(cmd *cobra.Command, args []string) {
+		display.Clear()
+		display.PrintHeader("📈 本机服务指标")
+
+		collector := cfg.ScrapeCollector()
+		if collector == nil {
+			display.PrintWarning("⚠️  未配置抓取目标，请在配置文件的 scrape.targets 中添加")
+			return
+		}
+
+		if scrapeList {
+			listScrapeSeries()
+		} else if sample, ok := collect("scrape", "应用指标", collector.Collect); ok {
+			display.PrintScrapeInfo(sample.Info.(monitor.ScrapeInfo))
+		}
+
+		fmt.Println()
+		display.PrintFooter("数据更新时间: " + time.Now().Format("2006-01-02 15:04:05"))
+	},
+}
+
+func init() {
+	scrapeCmd.Flags().BoolVarP(&scrapeList, "list", "l", false, "列出抓取到的所有序列（用于设置 pins）")
+}
+
+// scrapeResult 一个目标的抓取结果
+type scrapeResult struct {
+	metrics []monitor.Metric
+	err     error
+}
+
+// listScrapeSeries 依次抓取每个目标并打印所有序列
+func listScrapeSeries() {
+	for i, target := range cfg.Scrape.Targets {
+		if i > 0 {
+			fmt.Println()
+		}
+		url := target.URL
+		result, ok := collect("scrape", target.Name, func(ctx context.Context) scrapeResult {
+			metrics, err := monitor.Scrape(ctx, nil, url)
+			return scrapeResult{metrics: metrics, err: err}
+		})
+		switch {
+		case !ok:
+		case result.err != nil:
+			display.PrintError(fmt.Sprintf("❌ %s: %v", target.Name, result.err))
+		default:
+			display.PrintScrapeSeries(target.Name, result.metrics)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"fmt"
+
+	"syspulse/internal/display"
+	"syspulse/internal/monitor"
+
+	"github.com/spf13/cobra"
+)
+
+var sensorsCmd = &cobra.Command{
+	Use:   "sensors",
+	Short: "显示温度、风扇和功耗传感器",
+	Long:  "读取 hwmon、thermal zone 和 RAPL，显示 CPU / NVMe 温度、风扇转速和功耗",
+	Run: func(cmd *cobra.Command, args []string) {
+		display.Clear()
+		display.PrintHeader("🌡️  传感器")
+
+		sensorsInfo, ok := collect("sensors", "传感器", monitor.GetSensorsInfo)
+		if !ok {
+			return
+		}
+		if !sensorsInfo.Available {
+			display.PrintWarning("⚠️  未检测到可用的传感器（虚拟机或容器中通常不可用）")
+			return
+		}
+
+		display.PrintSensorsInfo(sensorsInfo)
+
+		fmt.Println()
+		display.PrintFooter("数据更新时间: " + sensorsInfo.Timestamp.Format("2006-01-02 15:04:05"))
+	},
+}
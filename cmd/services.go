@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"fmt"
+
+	"syspulse/internal/display"
+	"syspulse/internal/monitor"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	servicesAll bool
+)
+
+var servicesCmd = &cobra.Command{
+	Use:   "services",
+	Short: "显示 systemd 服务状态",
+	Long:  "通过 D-Bus 查询 systemd 服务的运行状态、重启次数、主进程以及 cgroup 中的内存和 CPU 占用，标记失败和频繁重启的服务",
+	Run: func(cmd *cobra.Command, args []string) {
+		display.Clear()
+		display.PrintHeader("🧰 systemd 服务")
+
+		servicesInfo, ok := collect("services", "systemd 服务", monitor.GetServicesInfo)
+		if !ok {
+			return
+		}
+		if !servicesInfo.Available {
+			display.PrintError("❌ 无法连接 systemd")
+			fmt.Println("   请确保:")
+			fmt.Println("   1. 系统使用 systemd 作为 init")
+			fmt.Println("   2. 系统 D-Bus 正在运行")
+			return
+		}
+
+		display.PrintServicesInfo(servicesInfo, servicesAll)
+
+		fmt.Println()
+		display.PrintFooter("数据更新时间: " + servicesInfo.Timestamp.Format("2006-01-02 15:04:05"))
+	},
+}
+
+func init() {
+	servicesCmd.Flags().BoolVarP(&servicesAll, "all", "a", false, "显示包括未运行在内的所有服务")
+}
@@ -1,16 +1,27 @@
 package cmd
 
 import (
+	"bufio"
+	"context"
 	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 
+	"syspulse/internal/config"
 	"syspulse/internal/web"
 
 	"github.com/spf13/cobra"
 )
 
 var (
-	webPort int
-	webHost string
+	webPort       int
+	webHost       string
+	webTLSCert    string
+	webTLSKey     string
+	webSelfSigned bool
 )
 
 var webCmd = &cobra.Command{
@@ -18,19 +29,104 @@ var webCmd = &cobra.Command{
 	Short: "启动 Web 界面服务器",
 	Long:  "启动一个 Web 服务器，通过浏览器查看系统资源监控",
 	Run: func(cmd *cobra.Command, args []string) {
+		// 命令行参数覆盖配置文件中的同名设置
+		if webTLSCert != "" {
+			cfg.Web.TLS.CertFile = webTLSCert
+		}
+		if webTLSKey != "" {
+			cfg.Web.TLS.KeyFile = webTLSKey
+		}
+		if webSelfSigned {
+			cfg.Web.TLS.SelfSigned = true
+		}
+		if err := cfg.Web.TLS.Validate(); err != nil {
+			fmt.Printf("❌ %v（--tls-cert 和 --tls-key 需要同时指定）\n", err)
+			os.Exit(1)
+		}
+
+		server := web.NewServer(webHost, webPort, cfg)
+
 		fmt.Printf("🌐 正在启动 SysPulse Web 服务器...\n")
-		fmt.Printf("📡 地址: http://%s:%d\n", webHost, webPort)
+		fmt.Printf("📡 地址: %s\n", server.URL())
+		if !cfg.Web.Auth.Enabled() && !isLoopbackHost(webHost) {
+			role := cfg.Web.Auth.AnonymousRole
+			if role == "" {
+				role = config.RoleViewer
+			}
+			fmt.Printf("⚠️  未配置认证，任何能访问该地址的人都拥有 %s 角色的权限\n", role)
+			fmt.Printf("   请在配置文件的 web.auth 中添加用户（syspulse web passwd）或令牌（syspulse web token）\n")
+		}
 		fmt.Printf("💡 在浏览器中打开上面的地址即可查看监控面板\n")
 		fmt.Printf("⏹️  按 Ctrl+C 停止服务器\n\n")
 
-		server := web.NewServer(webHost, webPort)
-		if err := server.Start(); err != nil {
-			fmt.Printf("❌ 启动失败: %v\n", err)
+		// systemd 和 Kubernetes 用 SIGTERM 停止服务，收到后优雅关闭
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		if err := server.Start(ctx); err != nil {
+			fmt.Printf("❌ %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+var webPasswdCmd = &cobra.Command{
+	Use:   "passwd",
+	Short: "生成 Basic 认证的密码哈希",
+	Long:  "从标准输入读取密码，输出可填入配置文件 web.auth.users[].password_hash 的 bcrypt 哈希",
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Fprint(os.Stderr, "请输入密码: ")
+		password, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && password == "" {
+			fmt.Fprintf(os.Stderr, "❌ 读取密码失败: %v\n", err)
+			os.Exit(1)
+		}
+		password = strings.TrimRight(password, "\r\n")
+		if password == "" {
+			fmt.Fprintln(os.Stderr, "❌ 密码不能为空")
+			os.Exit(1)
+		}
+
+		hash, err := web.HashPassword(password)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ 生成哈希失败: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(hash)
+	},
+}
+
+var webTokenCmd = &cobra.Command{
+	Use:   "token",
+	Short: "生成 Bearer 令牌",
+	Long:  "生成随机令牌，令牌交给客户端使用，哈希填入配置文件 web.auth.tokens[].token_hash",
+	Run: func(cmd *cobra.Command, args []string) {
+		token, hash, err := web.GenerateToken()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ 生成令牌失败: %v\n", err)
+			os.Exit(1)
 		}
+		fmt.Printf("令牌: %s\n", token)
+		fmt.Printf("哈希: %s\n", hash)
 	},
 }
 
+// isLoopbackHost 是否只监听在本机回环地址
+func isLoopbackHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
 func init() {
 	webCmd.Flags().IntVarP(&webPort, "port", "p", 3000, "Web 服务器端口")
 	webCmd.Flags().StringVarP(&webHost, "host", "H", "0.0.0.0", "Web 服务器主机地址")
+	webCmd.Flags().StringVar(&webTLSCert, "tls-cert", "", "HTTPS 证书文件（PEM）")
+	webCmd.Flags().StringVar(&webTLSKey, "tls-key", "", "HTTPS 私钥文件（PEM）")
+	webCmd.Flags().BoolVar(&webSelfSigned, "self-signed", false, "使用自动生成的自签名证书启用 HTTPS")
+
+	webCmd.AddCommand(webPasswdCmd)
+	webCmd.AddCommand(webTokenCmd)
 }
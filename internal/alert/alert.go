@@ -0,0 +1,209 @@
+package alert
+
+import (
+	"fmt"
+	"sort"
+
+	"syspulse/internal/monitor"
+	"syspulse/internal/probe"
+)
+
+// Level 告警级别
+type Level string
+
+const (
+	LevelWarning  Level = "warning"
+	LevelCritical Level = "critical"
+)
+
+// Rule 告警规则：指标值达到 Warning / Critical 阈值时触发（阈值为 0 表示不启用该级别）
+// Below 为 true 时反向比较，指标值低于等于阈值时触发（如证书剩余天数）
+type Rule struct {
+	Name        string
+	Metric      string
+	Description string
+	Warning     float64
+	Critical    float64
+	Below       bool
+}
+
+// Alert 已触发的告警
+type Alert struct {
+	Rule      string
+	Metric    string
+	Value     float64
+	Threshold float64
+	Level     Level
+	Message   string
+}
+
+// Metrics 指标名到当前值的映射
+type Metrics map[string]float64
+
+// DefaultRules 默认告警规则
+func DefaultRules() []Rule {
+	return []Rule{
+		// 基于压力（PSI）而不是使用率：使用率 100% 不代表任务在等待
+		{Name: "cpu_pressure", Metric: "pressure.cpu.some.avg10", Description: "CPU 压力 (some avg10)", Warning: 20, Critical: 50},
+		{Name: "memory_pressure", Metric: "pressure.memory.full.avg10", Description: "内存压力 (full avg10)", Warning: 5, Critical: 20},
+		{Name: "io_pressure", Metric: "pressure.io.full.avg10", Description: "IO 压力 (full avg10)", Warning: 10, Critical: 30},
+		{Name: "cpu_temperature", Metric: "sensors.cpu.temperature", Description: "CPU 温度 (°C)", Warning: 85, Critical: 95},
+		{Name: "nvme_temperature", Metric: "sensors.nvme.temperature", Description: "NVMe 温度 (°C)", Warning: 70, Critical: 80},
+		{Name: "sensor_critical", Metric: "sensors.critical.count", Description: "达到硬件临界温度的传感器数", Critical: 1},
+		{Name: "tcp_retransmit", Metric: "network.tcp.retrans_percent", Description: "TCP 重传率 (%)", Warning: 2, Critical: 5},
+		{Name: "tcp_listen_overflow", Metric: "network.tcp.listen_overflows_per_sec", Description: "TCP accept 队列溢出 (次/秒)", Warning: 1, Critical: 10},
+		{Name: "udp_rcvbuf_errors", Metric: "network.udp.rcvbuf_errors_per_sec", Description: "UDP 接收缓冲区丢包 (次/秒)", Warning: 1, Critical: 100},
+		{Name: "conntrack_usage", Metric: "network.conntrack.used_percent", Description: "conntrack 表使用率 (%)", Warning: 80, Critical: 95},
+		{Name: "conntrack_drop", Metric: "network.conntrack.drops_per_sec", Description: "conntrack 表满丢包 (次/秒)", Critical: 1},
+		{Name: "probe_failed", Metric: "probes.failed.count", Description: "失败的连通性探测数", Critical: 1},
+		{Name: "probe_slow", Metric: "probes.slow.count", Description: "延迟超标的连通性探测数", Warning: 1},
+		{Name: "probe_cert_expiry", Metric: "probes.cert.min_days_left", Description: "探测目标证书剩余天数", Warning: 14, Critical: 7, Below: true},
+		{Name: "cert_expired", Metric: "certs.expired.count", Description: "已过期的 TLS 证书数", Critical: 1},
+		{Name: "cert_expiring", Metric: "certs.expiring.count", Description: "即将过期的 TLS 证书数", Warning: 1},
+		{Name: "cert_self_signed", Metric: "certs.self_signed.count", Description: "自签名的 TLS 证书数", Warning: 1},
+	}
+}
+
+// AddPressure 添加 PSI 指标
+func (m Metrics) AddPressure(info monitor.PressureInfo) {
+	if !info.Available {
+		return
+	}
+	addPressureResource(m, "pressure.cpu", info.CPU)
+	addPressureResource(m, "pressure.memory", info.Memory)
+	addPressureResource(m, "pressure.io", info.IO)
+}
+
+func addPressureResource(m Metrics, prefix string, res monitor.PressureResource) {
+	m[prefix+".some.avg10"] = res.Some.Avg10
+	m[prefix+".some.avg60"] = res.Some.Avg60
+	m[prefix+".some.avg300"] = res.Some.Avg300
+	m[prefix+".full.avg10"] = res.Full.Avg10
+	m[prefix+".full.avg60"] = res.Full.Avg60
+	m[prefix+".full.avg300"] = res.Full.Avg300
+}
+
+// AddSensors 添加温度指标
+func (m Metrics) AddSensors(info monitor.SensorsInfo) {
+	if len(info.Temperatures) == 0 {
+		return
+	}
+
+	critical := 0
+	for _, t := range info.Temperatures {
+		switch t.Kind {
+		case monitor.SensorCPUPackage, monitor.SensorCPUCore:
+			m.max("sensors.cpu.temperature", t.Celsius)
+		case monitor.SensorNVMe:
+			m.max("sensors.nvme.temperature", t.Celsius)
+		}
+		if t.Critical > 0 && t.Celsius >= t.Critical {
+			critical++
+		}
+	}
+	m["sensors.critical.count"] = float64(critical)
+}
+
+// retransMinSegsPerSec 发送速率低于该值时不计算重传率，避免少量重传造成误报
+const retransMinSegsPerSec = 100
+
+// AddProtocolStats 添加 TCP / UDP / conntrack 指标
+func (m Metrics) AddProtocolStats(info monitor.ProtocolStats) {
+	if !info.Available {
+		return
+	}
+
+	if info.TCP.OutSegs.PerSec >= retransMinSegsPerSec {
+		m["network.tcp.retrans_percent"] = info.TCP.RetransPercent
+	}
+	m["network.tcp.listen_overflows_per_sec"] = info.TCP.ListenOverflows.PerSec
+	m["network.tcp.syn_backlog_drops_per_sec"] = info.TCP.SynBacklogDrops.PerSec
+	m["network.udp.rcvbuf_errors_per_sec"] = info.UDP.RcvbufErrors.PerSec
+
+	if info.Conntrack.Available {
+		m["network.conntrack.used_percent"] = info.Conntrack.UsedPercent
+		m["network.conntrack.drops_per_sec"] = info.Conntrack.Drop.PerSec + info.Conntrack.EarlyDrop.PerSec
+	}
+}
+
+// AddProbes 添加连通性探测指标
+func (m Metrics) AddProbes(info probe.Info) {
+	if info.TotalCount == 0 {
+		return
+	}
+	m["probes.failed.count"] = float64(info.FailedCount)
+	m["probes.slow.count"] = float64(info.SlowCount)
+	if info.HasCertCheck {
+		m["probes.cert.min_days_left"] = info.MinCertDays
+	}
+}
+
+// AddCertificates 添加 TLS 证书指标（即将过期的判断使用扫描时的 WarnDays）
+func (m Metrics) AddCertificates(info monitor.CertificatesInfo) {
+	if len(info.Certificates) == 0 {
+		return
+	}
+	m["certs.expired.count"] = float64(info.ExpiredCount)
+	m["certs.expiring.count"] = float64(info.ExpiringCount)
+	m["certs.self_signed.count"] = float64(info.SelfSignedCount)
+	m["certs.min_days_left"] = info.Certificates[0].DaysLeft
+}
+
+// max 保留较大值
+func (m Metrics) max(name string, value float64) {
+	if current, ok := m[name]; !ok || value > current {
+		m[name] = value
+	}
+}
+
+// Evaluate 按规则检查指标，返回触发的告警（严重的在前）
+func Evaluate(rules []Rule, metrics Metrics) []Alert {
+	var alerts []Alert
+
+	for _, rule := range rules {
+		value, ok := metrics[rule.Metric]
+		if !ok {
+			continue
+		}
+
+		exceeds := func(threshold float64) bool {
+			if threshold == 0 {
+				return false
+			}
+			if rule.Below {
+				return value <= threshold
+			}
+			return value >= threshold
+		}
+
+		var level Level
+		var threshold float64
+		if exceeds(rule.Critical) {
+			level, threshold = LevelCritical, rule.Critical
+		} else if exceeds(rule.Warning) {
+			level, threshold = LevelWarning, rule.Warning
+		} else {
+			continue
+		}
+
+		message := fmt.Sprintf("%s 为 %.2f，超过阈值 %.2f", rule.Description, value, threshold)
+		if rule.Below {
+			message = fmt.Sprintf("%s 为 %.2f，低于阈值 %.2f", rule.Description, value, threshold)
+		}
+
+		alerts = append(alerts, Alert{
+			Rule:      rule.Name,
+			Metric:    rule.Metric,
+			Value:     value,
+			Threshold: threshold,
+			Level:     level,
+			Message:   message,
+		})
+	}
+
+	sort.SliceStable(alerts, func(i, j int) bool {
+		return alerts[i].Level == LevelCritical && alerts[j].Level != LevelCritical
+	})
+
+	return alerts
+}
@@ -0,0 +1,478 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"syspulse/internal/monitor"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config SysPulse 配置（字段与 examples/config-example.yaml 对应）
+type Config struct {
+	Disk         DiskConfig         `yaml:"disk"`
+	Network      NetworkConfig      `yaml:"network"`
+	Probes       ProbesConfig       `yaml:"probes"`
+	Certificates CertificatesConfig `yaml:"certificates"`
+	Timeouts     TimeoutsConfig     `yaml:"timeouts"`
+	Plugins      []PluginConfig     `yaml:"plugins"`
+	Scrape       ScrapeConfig       `yaml:"scrape"`
+	Web          WebConfig          `yaml:"web"`
+
+	// path 加载的配置文件路径，未找到配置文件时为空
+	path string
+}
+
+// DiskConfig 磁盘监控设置
+type DiskConfig struct {
+	// 要监控的挂载点（支持通配符，留空表示监控所有）
+	MountPoints []string `yaml:"mount_points"`
+	// 要排除的挂载点（支持通配符）
+	ExcludeMountPoints []string `yaml:"exclude_mount_points"`
+	// 要监控的文件系统类型（留空表示所有）
+	FsTypes []string `yaml:"fs_types"`
+	// 要排除的文件系统类型
+	ExcludeFsTypes []string `yaml:"exclude_fs_types"`
+	// 要监控的设备（支持通配符，留空表示所有）
+	Devices []string `yaml:"devices"`
+	// 要排除的设备（支持通配符）
+	ExcludeDevices []string `yaml:"exclude_devices"`
+	// 是否合并同一文件系统的多个挂载点（bind mount）
+	DedupBindMounts bool `yaml:"dedup_bind_mounts"`
+}
+
+// NetworkConfig 网络监控设置
+type NetworkConfig struct {
+	// 要监控的网络接口（支持通配符，留空表示监控所有）
+	Interfaces []string `yaml:"interfaces"`
+	// 要排除的网络接口（支持通配符）
+	ExcludeInterfaces []string `yaml:"exclude_interfaces"`
+	// 要监控的接口类型：physical、bridge、veth、vlan、bond、tunnel、virtual（留空表示所有）
+	Types []string `yaml:"types"`
+	// 要排除的接口类型
+	ExcludeTypes []string `yaml:"exclude_types"`
+	// 是否排除回环接口
+	ExcludeLoopback bool `yaml:"exclude_loopback"`
+}
+
+// ProbesConfig 连通性探测设置
+type ProbesConfig struct {
+	// 探测间隔，如 30s
+	Interval time.Duration `yaml:"interval"`
+	// 探测目标
+	Targets []ProbeConfig `yaml:"targets"`
+}
+
+// ProbeConfig 单个探测目标
+type ProbeConfig struct {
+	// 名称，用于显示和告警
+	Name string `yaml:"name"`
+	// 类型: tcp、http、dns、icmp
+	Type string `yaml:"type"`
+	// 目标: tcp 为 host:port，http 为 URL，dns 为域名，icmp 为主机名或 IP
+	Target string `yaml:"target"`
+	// 超时时间，默认 5s
+	Timeout time.Duration `yaml:"timeout"`
+	// 延迟超过该值时视为慢（可选）
+	MaxLatency time.Duration `yaml:"max_latency"`
+	// HTTP 期望的状态码（默认接受 2xx 和 3xx）
+	ExpectStatus int `yaml:"expect_status"`
+	// HTTPS 不校验证书（自签名的内部服务）
+	Insecure bool `yaml:"insecure"`
+	// DNS 服务器 (host:port)，默认使用系统配置
+	Server string `yaml:"server"`
+	// ICMP 发送的报文数，默认 3
+	Count int `yaml:"count"`
+}
+
+// CertificatesConfig TLS 证书扫描设置
+type CertificatesConfig struct {
+	// 是否扫描本机所有 TCP 监听端口
+	ScanListeners bool `yaml:"scan_listeners"`
+	// 额外扫描的 host:port
+	Targets []string `yaml:"targets"`
+	// 剩余天数低于该值时告警
+	WarnDays int `yaml:"warn_days"`
+	// 单个握手的超时时间
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// TimeoutsConfig 各子系统的采集超时
+// 超时的子系统在仪表盘和 Web 面板中显示为"超时"，其余部分照常显示
+type TimeoutsConfig struct {
+	// 未单独设置的子系统使用的超时，默认 5s，0 表示不限时
+	Default time.Duration `yaml:"default"`
+	// 按子系统设置的超时，键见 Subsystems
+	Subsystems map[string]time.Duration `yaml:",inline"`
+}
+
+// Subsystems 可以单独设置超时的子系统
+var Subsystems = []string{
+	"system", "cpu", "memory", "pressure", "disk", "disk_health", "network", "protocols", "nettop",
+	"ports", "process", "docker", "services", "sensors", "cgroup", "certificates", "scrape",
+}
+
+// reservedNames Web 数据中已使用、不能作为插件名的其他名称
+var reservedNames = []string{"probes", "plugins"}
+
+// validPluginName 插件名会作为 WebSocket 主题和 JSON 键，只允许简单的字符
+func validPluginName(name string) bool {
+	for _, c := range name {
+		if !(c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '_' || c == '-') {
+			return false
+		}
+	}
+	return name != ""
+}
+
+// defaultSubsystemTimeouts 需要比默认值更长时间的子系统
+var defaultSubsystemTimeouts = map[string]time.Duration{
+	// 每块盘的 smartctl 最多 10 秒
+	"disk_health": time.Minute,
+	// 并发握手所有监听端口
+	"certificates": 30 * time.Second,
+	// 首次抓取时间隔 1 秒抓取两次，每次最多 3 秒
+	"scrape": 10 * time.Second,
+}
+
+// PluginConfig 外部插件：每次采集时执行一次，在标准输出打印 JSON 或 Prometheus 文本格式的指标
+type PluginConfig struct {
+	// 名称（小写字母、数字、_ 和 -），不能与内置子系统重名；可在 timeouts 中按名称设置超时
+	Name    string   `yaml:"name"`
+	Command string   `yaml:"command"`
+	Args    []string `yaml:"args"`
+	// 输出格式：json、prometheus，留空时根据输出自动判断
+	Format string `yaml:"format"`
+	// 执行间隔，默认 15s
+	Interval time.Duration `yaml:"interval"`
+}
+
+// defaultPluginInterval 插件默认的执行间隔
+const defaultPluginInterval = 15 * time.Second
+
+// ScrapeConfig 抓取本机服务 Prometheus 指标的设置
+type ScrapeConfig struct {
+	// 未单独设置的目标使用的抓取间隔，默认 15s
+	Interval time.Duration `yaml:"interval"`
+	// 抓取目标
+	Targets []ScrapeTargetConfig `yaml:"targets"`
+}
+
+// ScrapeTargetConfig 单个抓取目标
+type ScrapeTargetConfig struct {
+	// 名称，用于显示
+	Name string `yaml:"name"`
+	// 指标地址，如 http://127.0.0.1:8080/metrics
+	URL string `yaml:"url"`
+	// 抓取间隔（可选）
+	Interval time.Duration `yaml:"interval"`
+	// 单次请求的超时，默认 3s
+	Timeout time.Duration `yaml:"timeout"`
+	// 固定显示在仪表盘和 Web 面板中的序列
+	Pins []PinConfig `yaml:"pins"`
+}
+
+// PinConfig 固定显示的序列
+type PinConfig struct {
+	// 选择器：指标名加可选的标签，如 http_requests_total{code="500"}；匹配多个序列时求和
+	// 直方图和摘要使用指标族名（如 http_request_duration_seconds），显示平均值或分位数
+	Series string `yaml:"series"`
+	// 显示名称，默认为 series（分位数加上 p95 等后缀），同一目标内不能重复
+	Title string `yaml:"title"`
+	// counter 默认换算为每秒速率，设为 true 时显示累计值
+	Raw bool `yaml:"raw"`
+	// 直方图或摘要显示的分位数（0-1 之间，如 0.95），不设置时显示平均值
+	Quantile float64 `yaml:"quantile"`
+}
+
+// 抓取目标默认的间隔和请求超时
+const (
+	defaultScrapeInterval = 15 * time.Second
+	defaultScrapeTimeout  = 3 * time.Second
+)
+
+// WebConfig Web 服务器设置
+type WebConfig struct {
+	TLS  WebTLSConfig  `yaml:"tls"`
+	Auth WebAuthConfig `yaml:"auth"`
+	// 允许跨域访问 API 和 WebSocket 的来源，如 https://grafana.example.com，留空只允许同源
+	// "*" 允许任意来源不带凭据地读取 API（匿名角色可见的数据），不适用于 WebSocket
+	CORSOrigins []string `yaml:"cors_origins"`
+	// 特权操作（重启容器、修改配置）的审计日志文件，留空时输出到标准错误
+	AuditLog string `yaml:"audit_log"`
+	// 访问日志（JSON Lines）：stdout（默认）、stderr、off 或文件路径
+	AccessLog string `yaml:"access_log"`
+}
+
+// WebTLSConfig HTTPS 设置
+type WebTLSConfig struct {
+	// 证书和私钥文件（PEM）
+	CertFile string `yaml:"cert_file"`
+	KeyFile  string `yaml:"key_file"`
+	// 未指定证书时自动生成自签名证书
+	SelfSigned bool `yaml:"self_signed"`
+}
+
+// WebAuthConfig 认证设置（未配置任何用户和令牌时不启用认证）
+type WebAuthConfig struct {
+	// Basic 认证用户，密码使用 syspulse web passwd 生成的 bcrypt 哈希
+	Users []WebUser `yaml:"users"`
+	// Bearer 令牌，使用 syspulse web token 生成的 sha256 哈希
+	Tokens []WebToken `yaml:"tokens"`
+	// 未启用认证时匿名访问者的角色，默认 viewer
+	AnonymousRole string `yaml:"anonymous_role"`
+}
+
+// 角色：viewer 只读（不含进程命令行），operator 可重启容器，admin 可修改配置
+const (
+	RoleViewer   = "viewer"
+	RoleOperator = "operator"
+	RoleAdmin    = "admin"
+)
+
+// WebUser Basic 认证用户
+type WebUser struct {
+	Username     string `yaml:"username"`
+	PasswordHash string `yaml:"password_hash"`
+	// 角色，默认 viewer
+	Role string `yaml:"role"`
+}
+
+// WebToken Bearer 令牌
+type WebToken struct {
+	Name      string `yaml:"name"`
+	TokenHash string `yaml:"token_hash"`
+	// 角色，默认 viewer
+	Role string `yaml:"role"`
+}
+
+// Enabled 是否配置了认证
+func (a WebAuthConfig) Enabled() bool {
+	return len(a.Users) > 0 || len(a.Tokens) > 0
+}
+
+// Enabled 是否启用 HTTPS
+func (t WebTLSConfig) Enabled() bool {
+	return (t.CertFile != "" && t.KeyFile != "") || t.SelfSigned
+}
+
+// Validate 证书和私钥必须同时设置，只设置其中一个时不会静默退回 HTTP
+func (t WebTLSConfig) Validate() error {
+	switch {
+	case t.CertFile != "" && t.KeyFile == "":
+		return fmt.Errorf("web.tls: 设置了 cert_file 但没有设置 key_file")
+	case t.CertFile == "" && t.KeyFile != "":
+		return fmt.Errorf("web.tls: 设置了 key_file 但没有设置 cert_file")
+	}
+	return nil
+}
+
+// Default 默认配置
+func Default() *Config {
+	return &Config{
+		Disk: DiskConfig{
+			DedupBindMounts: true,
+		},
+		Network: NetworkConfig{
+			ExcludeLoopback: true,
+		},
+		Probes: ProbesConfig{
+			Interval: 30 * time.Second,
+		},
+		Certificates: CertificatesConfig{
+			ScanListeners: true,
+			WarnDays:      30,
+			Timeout:       3 * time.Second,
+		},
+		Timeouts: TimeoutsConfig{
+			Default: 5 * time.Second,
+		},
+		Scrape: ScrapeConfig{
+			Interval: defaultScrapeInterval,
+		},
+	}
+}
+
+// searchPaths 未指定配置文件时依次查找的路径
+func searchPaths() []string {
+	paths := []string{"syspulse.yaml"}
+	if home, err := os.UserHomeDir(); err == nil {
+		paths = append(paths, filepath.Join(home, ".config", "syspulse", "config.yaml"))
+	}
+	return append(paths, "/etc/syspulse/config.yaml")
+}
+
+// Load 加载配置文件。path 为空时按默认路径查找，都不存在则使用默认配置
+func Load(path string) (*Config, error) {
+	cfg := Default()
+
+	if path == "" {
+		for _, candidate := range searchPaths() {
+			if _, err := os.Stat(candidate); err == nil {
+				path = candidate
+				break
+			}
+		}
+		if path == "" {
+			return cfg, nil
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("配置文件不存在: %s", path)
+		}
+		return nil, err
+	}
+
+	cfg, err = Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("解析配置文件 %s 失败: %w", path, err)
+	}
+	cfg.path = path
+
+	return cfg, nil
+}
+
+// Parse 解析 YAML 配置内容（未设置的字段使用默认值）并校验
+func Parse(data []byte) (*Config, error) {
+	cfg := Default()
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, err
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// validate 校验无法在解析时发现的错误
+func (c *Config) validate() error {
+	plugins := make(map[string]bool, len(c.Plugins))
+	for i, plugin := range c.Plugins {
+		switch {
+		case !validPluginName(plugin.Name):
+			return fmt.Errorf("plugins[%d].name: %q 只能包含小写字母、数字、_ 和 -", i, plugin.Name)
+		case plugins[plugin.Name]:
+			return fmt.Errorf("plugins[%d].name: 重复的插件名 %q", i, plugin.Name)
+		case slices.Contains(Subsystems, plugin.Name) || slices.Contains(reservedNames, plugin.Name):
+			return fmt.Errorf("plugins[%d].name: %q 与内置子系统重名", i, plugin.Name)
+		case plugin.Command == "":
+			return fmt.Errorf("plugins[%d].command: 插件 %s 未指定命令", i, plugin.Name)
+		case plugin.Format != "" && plugin.Format != monitor.PluginFormatJSON && plugin.Format != monitor.PluginFormatPrometheus:
+			return fmt.Errorf("plugins[%d].format: 未知格式 %q（可用: json、prometheus）", i, plugin.Format)
+		}
+		plugins[plugin.Name] = true
+	}
+
+	if err := c.Scrape.validate(); err != nil {
+		return err
+	}
+
+	for name, timeout := range c.Timeouts.Subsystems {
+		if !slices.Contains(Subsystems, name) && !plugins[name] {
+			return fmt.Errorf("timeouts.%s: 未知子系统或插件（可用: %s）", name, strings.Join(Subsystems, ", "))
+		}
+		if timeout < 0 {
+			return fmt.Errorf("timeouts.%s: 超时不能为负数", name)
+		}
+	}
+
+	if err := c.Web.TLS.Validate(); err != nil {
+		return err
+	}
+
+	auth := c.Web.Auth
+	if !ValidRole(auth.AnonymousRole) {
+		return fmt.Errorf("web.auth.anonymous_role: 未知角色 %q", auth.AnonymousRole)
+	}
+	for _, user := range auth.Users {
+		if !ValidRole(user.Role) {
+			return fmt.Errorf("用户 %s: 未知角色 %q", user.Username, user.Role)
+		}
+	}
+	for _, token := range auth.Tokens {
+		if !ValidRole(token.Role) {
+			return fmt.Errorf("令牌 %s: 未知角色 %q", token.Name, token.Role)
+		}
+	}
+	return nil
+}
+
+// ValidRole 是否为已知角色（空字符串表示默认的 viewer）
+func ValidRole(role string) bool {
+	switch role {
+	case "", RoleViewer, RoleOperator, RoleAdmin:
+		return true
+	}
+	return false
+}
+
+// validate 校验抓取目标的名称、地址和固定序列
+func (c ScrapeConfig) validate() error {
+	names := make(map[string]bool, len(c.Targets))
+	for i, target := range c.Targets {
+		switch {
+		case target.Name == "":
+			return fmt.Errorf("scrape.targets[%d].name: 未设置名称", i)
+		case names[target.Name]:
+			return fmt.Errorf("scrape.targets[%d].name: 重复的名称 %q", i, target.Name)
+		}
+		names[target.Name] = true
+
+		u, err := url.Parse(target.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("scrape.targets[%d].url: %q 不是有效的 http(s) 地址", i, target.URL)
+		}
+		if target.Interval < 0 || target.Timeout < 0 {
+			return fmt.Errorf("scrape.targets[%d]: 间隔和超时不能为负数", i)
+		}
+
+		// 显示名称是 /metrics 中 syspulse_pinned_value 的标签，同一目标内不能重复
+		titles := make(map[string]bool, len(target.Pins))
+		for j, pin := range target.Pins {
+			selector, err := monitor.ParseSelector(pin.Series)
+			if err != nil {
+				return fmt.Errorf("scrape.targets[%d].pins[%d].series: %w", i, j, err)
+			}
+			if pin.Quantile < 0 || pin.Quantile >= 1 {
+				return fmt.Errorf("scrape.targets[%d].pins[%d].quantile: 必须在 0 和 1 之间", i, j)
+			}
+			title := monitor.Pin{Selector: selector, Title: pin.Title, Quantile: pin.Quantile}.DisplayTitle()
+			if titles[title] {
+				return fmt.Errorf("scrape.targets[%d].pins[%d]: 显示名称 %q 重复，请设置不同的 title", i, j, title)
+			}
+			titles[title] = true
+		}
+	}
+	return nil
+}
+
+// CollectTimeout 返回子系统的采集超时（0 表示不限时）
+func (c *Config) CollectTimeout(subsystem string) time.Duration {
+	if timeout, ok := c.Timeouts.Subsystems[subsystem]; ok {
+		return timeout
+	}
+	if timeout, ok := defaultSubsystemTimeouts[subsystem]; ok && c.Timeouts.Default > 0 && timeout > c.Timeouts.Default {
+		return timeout
+	}
+	return c.Timeouts.Default
+}
+
+// SetPath 设置配置文件路径（保存配置后使用）
+func (c *Config) SetPath(path string) {
+	c.path = path
+}
+
+// Path 返回加载的配置文件路径，使用默认配置时为空
+func (c *Config) Path() string {
+	return c.path
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"syspulse/internal/monitor"
+)
+
+// 内置采集器的名称都要能在 timeouts 中设置，并且不能被插件占用
+func TestSubsystemsCoverBuiltinCollectors(t *testing.T) {
+	collectors := monitor.BuiltinCollectors(monitor.BuiltinOptions{
+		Certificates: func(ctx context.Context) monitor.CertificatesInfo { return monitor.CertificatesInfo{} },
+	})
+	for _, c := range collectors {
+		if !slices.Contains(Subsystems, c.Name()) {
+			t.Errorf("builtin collector %q missing from Subsystems", c.Name())
+		}
+	}
+}
+
+func TestValidateTLS(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{name: "plain http", yaml: "web:\n  port: 3000\n"},
+		{name: "cert and key", yaml: "web:\n  tls:\n    cert_file: server.crt\n    key_file: server.key\n"},
+		{name: "self signed", yaml: "web:\n  tls:\n    self_signed: true\n"},
+		{name: "cert only", yaml: "web:\n  tls:\n    cert_file: server.crt\n", wantErr: "没有设置 key_file"},
+		{name: "key only", yaml: "web:\n  tls:\n    key_file: server.key\n    self_signed: true\n", wantErr: "没有设置 cert_file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.yaml))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("err = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package config
+
+import (
+	"syspulse/internal/monitor"
+	"syspulse/internal/probe"
+)
+
+// DiskFilter 根据磁盘设置生成采集过滤器
+func (c *Config) DiskFilter() monitor.DiskFilter {
+	return monitor.DiskFilter{
+		MountPoints:        c.Disk.MountPoints,
+		ExcludeMountPoints: c.Disk.ExcludeMountPoints,
+		FsTypes:            c.Disk.FsTypes,
+		ExcludeFsTypes:     c.Disk.ExcludeFsTypes,
+		Devices:            c.Disk.Devices,
+		ExcludeDevices:     c.Disk.ExcludeDevices,
+		DedupBindMounts:    c.Disk.DedupBindMounts,
+	}
+}
+
+// NetworkFilter 根据网络设置生成采集过滤器
+func (c *Config) NetworkFilter() monitor.NetworkFilter {
+	return monitor.NetworkFilter{
+		Interfaces:        c.Network.Interfaces,
+		ExcludeInterfaces: c.Network.ExcludeInterfaces,
+		Kinds:             c.Network.Types,
+		ExcludeKinds:      c.Network.ExcludeTypes,
+		ExcludeLoopback:   c.Network.ExcludeLoopback,
+	}
+}
+
+// ProbeTargets 根据探测设置生成探测目标（未设置名称时使用目标地址）
+func (c *Config) ProbeTargets() []probe.Target {
+	targets := make([]probe.Target, 0, len(c.Probes.Targets))
+	for _, t := range c.Probes.Targets {
+		name := t.Name
+		if name == "" {
+			name = t.Target
+		}
+		targets = append(targets, probe.Target{
+			Name:         name,
+			Type:         t.Type,
+			Target:       t.Target,
+			Timeout:      t.Timeout,
+			MaxLatency:   t.MaxLatency,
+			ExpectStatus: t.ExpectStatus,
+			Insecure:     t.Insecure,
+			Server:       t.Server,
+			Count:        t.Count,
+		})
+	}
+	return targets
+}
+
+// CertScanOptions 根据证书设置生成扫描选项
+func (c *Config) CertScanOptions() monitor.CertScanOptions {
+	return monitor.CertScanOptions{
+		ScanListeners: c.Certificates.ScanListeners,
+		Targets:       c.Certificates.Targets,
+		WarnDays:      c.Certificates.WarnDays,
+		Timeout:       c.Certificates.Timeout,
+	}
+}
+
+// PluginCollectors 根据插件设置生成采集器
+func (c *Config) PluginCollectors() []monitor.Collector {
+	collectors := make([]monitor.Collector, 0, len(c.Plugins))
+	for _, p := range c.Plugins {
+		interval := p.Interval
+		if interval <= 0 {
+			interval = defaultPluginInterval
+		}
+		collectors = append(collectors, monitor.NewPluginCollector(monitor.PluginOptions{
+			Name:     p.Name,
+			Command:  p.Command,
+			Args:     p.Args,
+			Format:   p.Format,
+			Interval: interval,
+		}))
+	}
+	return collectors
+}
+
+// ScrapeCollector 根据抓取设置生成采集器，没有抓取目标时返回 nil
+func (c *Config) ScrapeCollector() monitor.Collector {
+	if len(c.Scrape.Targets) == 0 {
+		return nil
+	}
+
+	targets := make([]monitor.ScrapeTarget, 0, len(c.Scrape.Targets))
+	for _, t := range c.Scrape.Targets {
+		target := monitor.ScrapeTarget{
+			Name:     t.Name,
+			URL:      t.URL,
+			Interval: t.Interval,
+			Timeout:  t.Timeout,
+		}
+		if target.Interval == 0 {
+			target.Interval = c.Scrape.Interval
+		}
+		if target.Interval <= 0 {
+			target.Interval = defaultScrapeInterval
+		}
+		if target.Timeout == 0 {
+			target.Timeout = defaultScrapeTimeout
+		}
+		for _, p := range t.Pins {
+			// 选择器已在加载配置时校验
+			selector, _ := monitor.ParseSelector(p.Series)
+			target.Pins = append(target.Pins, monitor.Pin{
+				Selector: selector,
+				Title:    p.Title,
+				Raw:      p.Raw,
+				Quantile: p.Quantile,
+			})
+		}
+		targets = append(targets, target)
+	}
+	return monitor.NewScrapeCollector(targets)
+}
@@ -0,0 +1,27 @@
+package display
+
+import (
+	"fmt"
+
+	"syspulse/internal/alert"
+)
+
+// PrintAlerts 打印已触发的告警
+func PrintAlerts(alerts []alert.Alert) {
+	colorTitle.Println("🚨 告警")
+
+	if len(alerts) == 0 {
+		fmt.Printf("  ")
+		colorSuccess.Println("✅ 无告警")
+		return
+	}
+
+	for _, a := range alerts {
+		fmt.Printf("  ")
+		if a.Level == alert.LevelCritical {
+			colorError.Printf("❗ [严重] %s\n", a.Message)
+		} else {
+			colorWarning.Printf("⚠️  [警告] %s\n", a.Message)
+		}
+	}
+}
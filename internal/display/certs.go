@@ -0,0 +1,90 @@
+package display
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"syspulse/internal/monitor"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// PrintCertificates 打印 TLS 证书扫描结果
+func PrintCertificates(info monitor.CertificatesInfo) {
+	fmt.Printf("  ")
+	colorLabel.Print("扫描地址: ")
+	colorValue.Printf("%d 个，其中 %d 个提供 TLS 证书\n", info.ScannedCount, len(info.Certificates))
+	fmt.Println()
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"地址", "进程", "主体 / SAN", "颁发者", "过期时间", "剩余天数", "状态"})
+	table.SetBorder(true)
+	table.SetRowLine(true)
+	table.SetAutoWrapText(false)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+
+	for _, c := range info.Certificates {
+		names := c.Subject
+		if len(c.DNSNames) > 0 {
+			names += "\n" + strings.Join(c.DNSNames, ", ")
+		}
+		process := c.ProcessName
+		if c.PID > 0 {
+			process = fmt.Sprintf("%s (%d)", c.ProcessName, c.PID)
+		}
+		if process == "" {
+			process = "-"
+		}
+
+		table.Append([]string{
+			c.Address,
+			process,
+			names,
+			c.Issuer,
+			c.NotAfter.Format("2006-01-02"),
+			fmt.Sprintf("%.0f", c.DaysLeft),
+			certificateStatus(c),
+		})
+	}
+	table.Render()
+	fmt.Println()
+
+	hasWarning := false
+	for _, c := range info.Certificates {
+		if c.Expired {
+			colorError.Printf("⚠️  警告: %s 的证书已于 %s 过期！\n", c.Address, c.NotAfter.Format("2006-01-02"))
+			hasWarning = true
+		} else if c.ExpiringSoon {
+			colorWarning.Printf("⚠️  提示: %s 的证书将在 %.0f 天后过期\n", c.Address, c.DaysLeft)
+			hasWarning = true
+		}
+	}
+	if info.SelfSignedCount > 0 {
+		colorWarning.Printf("⚠️  提示: %d 个证书为自签名证书\n", info.SelfSignedCount)
+		hasWarning = true
+	}
+	if !hasWarning {
+		colorSuccess.Printf("✅ 所有证书有效期均超过 %d 天\n", info.WarnDays)
+	}
+}
+
+// certificateStatus 证书状态标签
+func certificateStatus(c monitor.CertificateInfo) string {
+	var flags []string
+	switch {
+	case c.Expired:
+		flags = append(flags, "已过期")
+	case c.ExpiringSoon:
+		flags = append(flags, "即将过期")
+	}
+	if c.SelfSigned {
+		flags = append(flags, "自签名")
+	} else if !c.Verified {
+		flags = append(flags, "不受信任")
+	}
+	if len(flags) == 0 {
+		return "✅ 正常"
+	}
+	return "⚠️ " + strings.Join(flags, "，")
+}
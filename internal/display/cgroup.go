@@ -0,0 +1,125 @@
+package display
+
+import (
+	"fmt"
+	"os"
+
+	"syspulse/internal/monitor"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// PrintCgroupInfo 以树形打印 cgroup 资源占用（每层只显示前 topN 个子节点）
+func PrintCgroupInfo(info monitor.CgroupInfo, topN, maxDepth int) {
+	fmt.Printf("  ")
+	colorLabel.Print("cgroup 根目录: ")
+	colorValue.Println(info.Root)
+	fmt.Println()
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"cgroup", "CPU%", "限流", "内存", "上限", "OOM Kill", "读", "写", "进程数", "压力 cpu/mem/io"})
+	table.SetBorder(true)
+	table.SetRowLine(false)
+	table.SetAutoWrapText(false)
+	table.SetAlignment(tablewriter.ALIGN_RIGHT)
+	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+	table.SetColumnAlignment([]int{
+		tablewriter.ALIGN_LEFT,
+		tablewriter.ALIGN_RIGHT,
+		tablewriter.ALIGN_RIGHT,
+		tablewriter.ALIGN_RIGHT,
+		tablewriter.ALIGN_RIGHT,
+		tablewriter.ALIGN_RIGHT,
+		tablewriter.ALIGN_RIGHT,
+		tablewriter.ALIGN_RIGHT,
+		tablewriter.ALIGN_RIGHT,
+		tablewriter.ALIGN_RIGHT,
+	})
+
+	appendCgroupRows(table, info.Tree, "", "", topN, maxDepth)
+	table.Render()
+
+	// OOM 提示
+	fmt.Println()
+	if !printCgroupWarnings(info.Tree) {
+		colorSuccess.Println("✅ 未发现 OOM Kill 或 CPU 限流")
+	}
+}
+
+func appendCgroupRows(table *tablewriter.Table, node monitor.CgroupNode, prefix, branch string, topN, maxDepth int) {
+	memMax := "max"
+	if node.Memory.Max > 0 {
+		memMax = formatBytes(node.Memory.Max)
+	}
+
+	throttled := "-"
+	if node.CPU.NrThrottled > 0 {
+		throttled = fmt.Sprintf("%d/%d", node.CPU.NrThrottled, node.CPU.NrPeriods)
+	}
+
+	table.Append([]string{
+		prefix + branch + node.Name,
+		fmt.Sprintf("%.1f%%", node.CPU.UsagePercent),
+		throttled,
+		formatBytes(node.Memory.Current),
+		memMax,
+		fmt.Sprintf("%d", node.Memory.Events.OOMKill),
+		formatBytes(node.IO.ReadBytes),
+		formatBytes(node.IO.WriteBytes),
+		fmt.Sprintf("%d", node.Pids.Current),
+		formatCgroupPressure(node.Pressure),
+	})
+
+	if maxDepth > 0 && node.Depth >= maxDepth {
+		return
+	}
+
+	children := node.Children
+	if topN > 0 && len(children) > topN {
+		children = children[:topN]
+	}
+
+	// 子节点的缩进
+	childPrefix := prefix
+	switch branch {
+	case "├─ ":
+		childPrefix += "│  "
+	case "└─ ":
+		childPrefix += "   "
+	}
+
+	for i, child := range children {
+		childBranch := "├─ "
+		if i == len(children)-1 {
+			childBranch = "└─ "
+		}
+		appendCgroupRows(table, child, childPrefix, childBranch, topN, maxDepth)
+	}
+}
+
+func printCgroupWarnings(node monitor.CgroupNode) bool {
+	hasWarning := false
+	if node.Memory.Events.OOMKill > 0 {
+		colorError.Printf("⚠️  警告: %s 发生过 %d 次 OOM Kill\n", node.Path, node.Memory.Events.OOMKill)
+		hasWarning = true
+	}
+	if node.CPU.NrPeriods > 0 && float64(node.CPU.NrThrottled)/float64(node.CPU.NrPeriods) >= 0.1 {
+		colorWarning.Printf("⚠️  提示: %s 在 %.0f%% 的调度周期内被 CPU 限流\n",
+			node.Path, float64(node.CPU.NrThrottled)/float64(node.CPU.NrPeriods)*100)
+		hasWarning = true
+	}
+	for _, child := range node.Children {
+		if printCgroupWarnings(child) {
+			hasWarning = true
+		}
+	}
+	return hasWarning
+}
+
+// formatCgroupPressure 格式化 cgroup 的 some avg10 压力
+func formatCgroupPressure(p monitor.CgroupPressure) string {
+	if !p.CPU.Available && !p.Memory.Available && !p.IO.Available {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f/%.1f/%.1f", p.CPU.Some.Avg10, p.Memory.Some.Avg10, p.IO.Some.Avg10)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"syspulse/internal/monitor"
 
@@ -59,11 +60,30 @@ func PrintError(text string) {
 	colorError.Println(text)
 }
 
+// PrintFieldErrors 打印采集失败的字段，便于区分"值为 0"和"没读到"
+func PrintFieldErrors(errs monitor.FieldErrors) {
+	for _, e := range errs {
+		fmt.Printf("  ")
+		colorWarning.Printf("⚠️  %s: %s", e.Field, e.Message)
+		if e.Kind == monitor.ErrorPermission {
+			colorLabel.Print(" (权限不足，可尝试用 sudo 运行)")
+		}
+		fmt.Println()
+	}
+}
+
+// PrintTimedOut 打印超过采集超时的部分，代替该部分的内容
+func PrintTimedOut(title string, timeout time.Duration) {
+	colorWarning.Printf("⏱  %s: 超时", title)
+	colorLabel.Printf(" (超过 %s 未完成，可在配置文件的 timeouts 中调整)\n", timeout)
+}
+
 // PrintSystemInfo 打印系统信息
 func PrintSystemInfo(info monitor.SystemInfo) {
 	uptime := formatUptime(info.Uptime)
 
 	colorTitle.Println("🖥️  系统信息")
+	PrintFieldErrors(info.Errors)
 	fmt.Printf("  ")
 	colorLabel.Print("主机名: ")
 	colorValue.Println(info.Hostname)
@@ -84,6 +104,7 @@ func PrintSystemInfo(info monitor.SystemInfo) {
 // PrintCPUInfo 打印 CPU 信息（简洁版）
 func PrintCPUInfo(info monitor.CPUInfo) {
 	colorTitle.Println("🔥 CPU")
+	PrintFieldErrors(info.Errors)
 
 	// 使用率和进度条
 	fmt.Printf("  ")
@@ -97,10 +118,17 @@ func PrintCPUInfo(info monitor.CPUInfo) {
 	fmt.Printf("  ")
 	colorLabel.Print("负载: ")
 	colorValue.Printf("%.2f / %.2f / %.2f\n", info.LoadAvg1, info.LoadAvg5, info.LoadAvg15)
+
+	if info.Temperature > 0 {
+		fmt.Printf("  ")
+		colorLabel.Print("温度: ")
+		printTemperature(info.Temperature, 0)
+	}
 }
 
 // PrintCPUInfoDetailed 打印 CPU 详细信息
 func PrintCPUInfoDetailed(info monitor.CPUInfo) {
+	PrintFieldErrors(info.Errors)
 	fmt.Printf("  ")
 	colorLabel.Print("型号: ")
 	colorValue.Println(info.ModelName)
@@ -110,6 +138,52 @@ func PrintCPUInfoDetailed(info monitor.CPUInfo) {
 	colorLabel.Print("总体使用率: ")
 	printPercentWithBar(info.UsagePercent, 50)
 
+	if info.Temperature > 0 {
+		fmt.Printf("  ")
+		colorLabel.Print("温度: ")
+		printTemperature(info.Temperature, 0)
+	}
+
+	fmt.Println()
+	fmt.Printf("  ")
+	colorLabel.Println("CPU 时间分布:")
+	for _, mode := range []struct {
+		label   string
+		percent float64
+	}{
+		{"user   ", info.Modes.User},
+		{"nice   ", info.Modes.Nice},
+		{"system ", info.Modes.System},
+		{"iowait ", info.Modes.IOWait},
+		{"irq    ", info.Modes.IRQ},
+		{"softirq", info.Modes.SoftIRQ},
+		{"steal  ", info.Modes.Steal},
+		{"guest  ", info.Modes.Guest},
+	} {
+		fmt.Printf("    ")
+		colorLabel.Printf("%s: ", mode.label)
+		printPercentWithBar(mode.percent, 40)
+	}
+	fmt.Printf("    ")
+	colorLabel.Print("idle   : ")
+	colorSuccess.Printf("%.1f%%\n", info.Modes.Idle)
+
+	if info.Modes.IOWait >= 20 {
+		fmt.Printf("    ")
+		colorWarning.Println("⚠️  iowait 较高，CPU 在等待磁盘 IO")
+	}
+	if info.Modes.Steal >= 10 {
+		fmt.Printf("    ")
+		colorWarning.Println("⚠️  steal 较高，虚拟机 CPU 被宿主机抢占")
+	}
+
+	fmt.Println()
+	fmt.Printf("  ")
+	colorLabel.Print("上下文切换: ")
+	colorValue.Printf("%.0f/s  ", info.ContextSwitchesPerSec)
+	colorLabel.Print("中断: ")
+	colorValue.Printf("%.0f/s\n", info.InterruptsPerSec)
+
 	fmt.Println()
 	fmt.Printf("  ")
 	colorLabel.Println("各核心使用率:")
@@ -117,6 +191,9 @@ func PrintCPUInfoDetailed(info monitor.CPUInfo) {
 	for i, usage := range info.PerCoreUsage {
 		fmt.Printf("    ")
 		colorLabel.Printf("核心 %2d: ", i)
+		if i < len(info.Frequencies) && info.Frequencies[i].CurrentMHz > 0 {
+			colorInfo.Printf("%s ", formatFrequency(info.Frequencies[i]))
+		}
 		printPercentWithBar(usage, 40)
 	}
 
@@ -132,11 +209,15 @@ func PrintCPUInfoDetailed(info monitor.CPUInfo) {
 	fmt.Printf("    ")
 	colorLabel.Print("15 分钟: ")
 	printLoadValue(info.LoadAvg15, info.CoreCount)
+
+	fmt.Println()
+	printPressureDetailed(info.Pressure)
 }
 
 // PrintMemoryInfo 打印内存信息（简洁版）
 func PrintMemoryInfo(info monitor.MemoryInfo) {
 	colorTitle.Println("💾 内存")
+	PrintFieldErrors(info.Errors)
 
 	fmt.Printf("  ")
 	colorLabel.Print("物理内存: ")
@@ -157,6 +238,7 @@ func PrintMemoryInfo(info monitor.MemoryInfo) {
 
 // PrintMemoryInfoDetailed 打印内存详细信息
 func PrintMemoryInfoDetailed(info monitor.MemoryInfo) {
+	PrintFieldErrors(info.Errors)
 	fmt.Printf("  ")
 	colorLabel.Println("物理内存:")
 	fmt.Printf("    ")
@@ -195,14 +277,97 @@ func PrintMemoryInfoDetailed(info monitor.MemoryInfo) {
 		fmt.Printf("  ")
 		colorLabel.Println("交换分区 (Swap): 未配置")
 	}
+
+	fmt.Println()
+	fmt.Printf("  ")
+	colorLabel.Println("内存去向:")
+	fmt.Printf("    ")
+	colorLabel.Print("共享 (shmem): ")
+	colorValue.Println(formatBytes(info.Shared))
+	fmt.Printf("    ")
+	colorLabel.Print("Slab: ")
+	colorValue.Printf("%s ", formatBytes(info.Slab))
+	colorLabel.Printf("(可回收 %s / 不可回收 %s)\n", formatBytes(info.SlabReclaim), formatBytes(info.SlabUnrecl))
+	fmt.Printf("    ")
+	colorLabel.Print("脏页: ")
+	colorValue.Printf("%s ", formatBytes(info.Dirty))
+	colorLabel.Printf("(回写中 %s)\n", formatBytes(info.Writeback))
+
+	fmt.Println()
+	fmt.Printf("  ")
+	colorLabel.Println("提交内存 (Committed_AS):")
+	fmt.Printf("    ")
+	colorValue.Printf("%s / %s ", formatBytes(info.CommittedAS), formatBytes(info.CommitLimit))
+	commitPercent := 0.0
+	if info.CommitLimit > 0 {
+		commitPercent = float64(info.CommittedAS) / float64(info.CommitLimit) * 100
+	}
+	printPercentWithBar(commitPercent, 30)
+
+	if info.HugePages.Total > 0 {
+		fmt.Println()
+		fmt.Printf("  ")
+		colorLabel.Println("大页 (HugePages):")
+		fmt.Printf("    ")
+		colorLabel.Print("总数: ")
+		colorValue.Printf("%d ", info.HugePages.Total)
+		colorLabel.Print("空闲: ")
+		colorValue.Printf("%d ", info.HugePages.Free)
+		colorLabel.Print("预留: ")
+		colorValue.Printf("%d ", info.HugePages.Reserved)
+		colorLabel.Printf("(每页 %s，共 %s)\n", formatBytes(info.HugePages.PageSize),
+			formatBytes(info.HugePages.Total*info.HugePages.PageSize))
+	}
+
+	fmt.Println()
+	fmt.Printf("  ")
+	colorLabel.Println("分页活动 (每秒):")
+	fmt.Printf("    ")
+	colorLabel.Print("缺页: ")
+	colorValue.Printf("%.0f  ", info.Paging.PageFaults)
+	colorLabel.Print("主缺页: ")
+	if info.Paging.MajorFaults > 0 {
+		colorWarning.Printf("%.0f\n", info.Paging.MajorFaults)
+	} else {
+		colorValue.Printf("%.0f\n", info.Paging.MajorFaults)
+	}
+	fmt.Printf("    ")
+	colorLabel.Print("换入: ")
+	colorValue.Printf("%.0f 页  ", info.Paging.SwapIn)
+	colorLabel.Print("换出: ")
+	if info.Paging.SwapOut > 0 {
+		colorWarning.Printf("%.0f 页\n", info.Paging.SwapOut)
+	} else {
+		colorValue.Printf("%.0f 页\n", info.Paging.SwapOut)
+	}
+
+	if len(info.NUMANodes) > 1 {
+		fmt.Println()
+		fmt.Printf("  ")
+		colorLabel.Println("NUMA 节点:")
+		for _, node := range info.NUMANodes {
+			fmt.Printf("    ")
+			colorLabel.Printf("节点 %d: ", node.Node)
+			colorValue.Printf("%s / %s ", formatBytes(node.Used), formatBytes(node.Total))
+			nodePercent := 0.0
+			if node.Total > 0 {
+				nodePercent = float64(node.Used) / float64(node.Total) * 100
+			}
+			printPercentWithBar(nodePercent, 30)
+		}
+	}
+
+	fmt.Println()
+	printPressureDetailed(info.Pressure)
 }
 
 // PrintDiskInfo 打印磁盘信息（简洁版，类似 df -h）
 func PrintDiskInfo(info monitor.DiskInfo) {
 	colorTitle.Println("💿 磁盘 (按使用率排序)")
+	PrintFieldErrors(info.Errors)
 
 	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader([]string{"文件系统", "容量", "已用", "可用", "已用% ▼", "挂载点"})
+	table.SetHeader([]string{"文件系统", "容量", "已用", "可用", "已用% ▼", "inode%", "挂载点"})
 	table.SetBorder(false)
 	table.SetRowLine(false)
 	table.SetAutoWrapText(false)
@@ -214,6 +379,7 @@ func PrintDiskInfo(info monitor.DiskInfo) {
 		tablewriter.ALIGN_RIGHT,
 		tablewriter.ALIGN_RIGHT,
 		tablewriter.ALIGN_RIGHT,
+		tablewriter.ALIGN_RIGHT,
 		tablewriter.ALIGN_LEFT,
 	})
 
@@ -224,7 +390,8 @@ func PrintDiskInfo(info monitor.DiskInfo) {
 			formatBytes(partition.Used),
 			formatBytes(partition.Free),
 			fmt.Sprintf("%.0f%%", partition.UsedPercent),
-			partition.Mountpoint,
+			formatInodePercent(partition),
+			partition.Mountpoint + formatMountFlags(partition),
 		})
 	}
 
@@ -235,10 +402,11 @@ func PrintDiskInfo(info monitor.DiskInfo) {
 func PrintDiskInfoDetailed(info monitor.DiskInfo) {
 	fmt.Println()
 	colorTitle.Println("文件系统磁盘使用情况 (按使用率降序排列)")
+	PrintFieldErrors(info.Errors)
 	fmt.Println()
 
 	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader([]string{"文件系统", "类型", "容量", "已用", "可用", "已用% ▼", "挂载点"})
+	table.SetHeader([]string{"文件系统", "类型", "容量", "已用", "可用", "已用% ▼", "inode%", "预计写满", "挂载点"})
 	table.SetBorder(true)
 	table.SetRowLine(false)
 	table.SetAutoWrapText(false)
@@ -251,6 +419,8 @@ func PrintDiskInfoDetailed(info monitor.DiskInfo) {
 		tablewriter.ALIGN_RIGHT,
 		tablewriter.ALIGN_RIGHT,
 		tablewriter.ALIGN_RIGHT,
+		tablewriter.ALIGN_RIGHT,
+		tablewriter.ALIGN_RIGHT,
 		tablewriter.ALIGN_LEFT,
 	})
 
@@ -265,7 +435,9 @@ func PrintDiskInfoDetailed(info monitor.DiskInfo) {
 			formatBytes(partition.Used),
 			formatBytes(partition.Free),
 			usageStr,
-			partition.Mountpoint,
+			formatInodePercent(partition),
+			formatFillETA(partition.FillETASeconds),
+			partition.Mountpoint + formatMountFlags(partition),
 		})
 	}
 
@@ -282,6 +454,25 @@ func PrintDiskInfoDetailed(info monitor.DiskInfo) {
 			colorWarning.Printf("⚠️  提示: %s 使用率已达 %.0f%%，建议清理\n", partition.Mountpoint, partition.UsedPercent)
 			hasWarning = true
 		}
+
+		if partition.InodesUsedPercent >= 90 {
+			colorError.Printf("⚠️  警告: %s inode 使用率已达 %.0f%%，即使有剩余空间也无法创建文件！\n", partition.Mountpoint, partition.InodesUsedPercent)
+			hasWarning = true
+		} else if partition.InodesUsedPercent >= 80 {
+			colorWarning.Printf("⚠️  提示: %s inode 使用率已达 %.0f%%，请检查大量小文件\n", partition.Mountpoint, partition.InodesUsedPercent)
+			hasWarning = true
+		}
+
+		if partition.ReadOnlyRemount {
+			colorError.Printf("⚠️  警告: %s (%s) 处于只读状态，可能因文件系统错误被重新挂载\n", partition.Mountpoint, partition.Fstype)
+			hasWarning = true
+		}
+
+		if partition.FillETASeconds > 0 && partition.FillETASeconds < 24*3600 {
+			colorError.Printf("⚠️  警告: %s 按当前增长速度 (%s/s) 预计 %s 后写满\n",
+				partition.Mountpoint, formatBytes(uint64(partition.GrowthBytesPerSec)), formatFillETA(partition.FillETASeconds))
+			hasWarning = true
+		}
 	}
 
 	if !hasWarning {
@@ -289,9 +480,48 @@ func PrintDiskInfoDetailed(info monitor.DiskInfo) {
 	}
 }
 
+// formatInodePercent 格式化 inode 使用率（不支持 inode 的文件系统显示 -）
+func formatInodePercent(partition monitor.PartitionInfo) string {
+	if partition.InodesTotal == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.0f%%", partition.InodesUsedPercent)
+}
+
+// formatMountFlags 格式化需要关注的挂载选项
+func formatMountFlags(partition monitor.PartitionInfo) string {
+	var flags []string
+	if partition.ReadOnly {
+		flags = append(flags, "ro")
+	}
+	if partition.NoExec {
+		flags = append(flags, "noexec")
+	}
+	if len(flags) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(flags, ",") + ")"
+}
+
+// formatFillETA 格式化预计写满时间
+func formatFillETA(seconds float64) string {
+	if seconds <= 0 {
+		return "-"
+	}
+	d := time.Duration(seconds) * time.Second
+	if d < time.Hour {
+		return fmt.Sprintf("%d 分钟", int(d.Minutes()))
+	}
+	if d < 48*time.Hour {
+		return fmt.Sprintf("%.1f 小时", d.Hours())
+	}
+	return fmt.Sprintf("%.0f 天", d.Hours()/24)
+}
+
 // PrintNetworkInfo 打印网络信息（简洁版）
 func PrintNetworkInfo(info monitor.NetworkInfo) {
 	colorTitle.Println("🌐 网络")
+	PrintFieldErrors(info.Errors)
 
 	for _, iface := range info.Interfaces {
 		if len(iface.Addrs) == 0 {
@@ -312,6 +542,7 @@ func PrintNetworkInfo(info monitor.NetworkInfo) {
 
 // PrintNetworkInfoDetailed 打印网络详细信息
 func PrintNetworkInfoDetailed(info monitor.NetworkInfo) {
+	PrintFieldErrors(info.Errors)
 	table := tablewriter.NewWriter(os.Stdout)
 	table.SetHeader([]string{"接口", "地址", "发送", "接收", "发送包", "接收包"})
 	table.SetBorder(true)
@@ -336,10 +567,69 @@ func PrintNetworkInfoDetailed(info monitor.NetworkInfo) {
 	}
 
 	table.Render()
+
+	fmt.Println()
+	colorTitle.Println("🔗 接口属性")
+
+	linkTable := tablewriter.NewWriter(os.Stdout)
+	linkTable.SetHeader([]string{"接口", "类型", "状态", "MTU", "速率", "双工", "MAC", "标志"})
+	linkTable.SetBorder(true)
+	linkTable.SetRowLine(false)
+	linkTable.SetAutoWrapText(false)
+	linkTable.SetAlignment(tablewriter.ALIGN_LEFT)
+
+	for _, iface := range info.Interfaces {
+		linkTable.Append([]string{
+			iface.Name,
+			iface.Kind,
+			valueOrDash(iface.OperState),
+			fmt.Sprintf("%d", iface.MTU),
+			formatLinkSpeed(iface.SpeedMbps),
+			valueOrDash(iface.Duplex),
+			valueOrDash(iface.MAC),
+			strings.Join(iface.Flags, ","),
+		})
+	}
+
+	linkTable.Render()
+
+	// 链路告警（只提示已启用但链路断开的接口）
+	for _, iface := range info.Interfaces {
+		adminUp := false
+		for _, flag := range iface.Flags {
+			if flag == "up" {
+				adminUp = true
+			}
+		}
+		if adminUp && (iface.OperState == "down" || iface.OperState == "lowerlayerdown") {
+			colorWarning.Printf("⚠️  提示: %s 链路状态为 %s\n", iface.Name, iface.OperState)
+		} else if iface.Duplex == "half" {
+			colorWarning.Printf("⚠️  提示: %s 工作在半双工模式\n", iface.Name)
+		}
+	}
+}
+
+// formatLinkSpeed 格式化链路速率（Mbps）
+func formatLinkSpeed(mbps int64) string {
+	if mbps <= 0 {
+		return "-"
+	}
+	if mbps >= 1000 && mbps%1000 == 0 {
+		return fmt.Sprintf("%d Gbps", mbps/1000)
+	}
+	return fmt.Sprintf("%d Mbps", mbps)
+}
+
+func valueOrDash(value string) string {
+	if value == "" || value == "unknown" {
+		return "-"
+	}
+	return value
 }
 
 // PrintProcessInfo 打印进程信息
 func PrintProcessInfo(info monitor.ProcessInfo) {
+	PrintFieldErrors(info.Errors)
 	fmt.Printf("  ")
 	colorLabel.Print("进程总数: ")
 	colorValue.Println(info.TotalProcesses)
@@ -408,6 +698,7 @@ func printProcessTable(processes []monitor.ProcessDetail, sortBy string) {
 // PrintDockerInfo 打印 Docker 信息（简洁版）
 func PrintDockerInfo(info monitor.DockerInfo) {
 	colorTitle.Printf("🐳 Docker 容器 (%d 运行中 / %d 总计)\n", info.RunningCount, info.TotalCount)
+	PrintFieldErrors(info.Errors)
 
 	if len(info.Containers) == 0 {
 		fmt.Printf("  ")
@@ -459,6 +750,7 @@ func PrintDockerInfo(info monitor.DockerInfo) {
 
 // PrintDockerInfoDetailed 打印 Docker 详细信息
 func PrintDockerInfoDetailed(info monitor.DockerInfo) {
+	PrintFieldErrors(info.Errors)
 	fmt.Printf("  ")
 	colorLabel.Print("运行中容器: ")
 	colorSuccess.Printf("%d ", info.RunningCount)
@@ -620,6 +912,14 @@ func printPercentWithBar(percent float64, width int) {
 	fmt.Println()
 }
 
+// formatFrequency 格式化核心频率，例如 "2.40 GHz (0.80-3.60)"
+func formatFrequency(freq monitor.CPUFrequency) string {
+	if freq.MaxMHz > 0 {
+		return fmt.Sprintf("%.2f GHz (%.2f-%.2f)", freq.CurrentMHz/1000, freq.MinMHz/1000, freq.MaxMHz/1000)
+	}
+	return fmt.Sprintf("%.2f GHz", freq.CurrentMHz/1000)
+}
+
 func printLoadValue(load float64, cores int) {
 	threshold := float64(cores) * 0.7
 
@@ -661,6 +961,7 @@ func formatUptime(seconds uint64) string {
 
 // PrintPortInfo 打印端口信息
 func PrintPortInfo(info monitor.PortInfo) {
+	PrintFieldErrors(info.Errors)
 	if len(info.Listening) == 0 {
 		fmt.Printf("  ")
 		colorLabel.Println("未检测到监听端口")
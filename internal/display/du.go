@@ -0,0 +1,98 @@
+package display
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"syspulse/internal/monitor"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// PrintDirUsage 打印目录占用树和最大文件
+func PrintDirUsage(info monitor.DirUsageInfo) {
+	fmt.Printf("  ")
+	colorLabel.Print("扫描目录: ")
+	colorValue.Println(info.Root)
+	fmt.Printf("  ")
+	colorLabel.Print("总占用: ")
+	colorValue.Printf("%s ", formatBytes(info.TotalBytes))
+	colorLabel.Printf("(%d 个文件, %d 个目录, 耗时 %.1fs)\n", info.FileCount, info.DirCount, info.Elapsed)
+
+	if info.TimedOut {
+		fmt.Printf("  ")
+		colorWarning.Println("⚠️  扫描超时，以下为部分结果")
+	}
+	if info.ErrorCount > 0 {
+		fmt.Printf("  ")
+		colorWarning.Printf("⚠️  %d 个目录或文件无法读取（权限不足？）\n", info.ErrorCount)
+	}
+
+	fmt.Println()
+	colorTitle.Println("📁 最大的目录")
+	printDirUsageNode(info.Tree, info.TotalBytes, "", "")
+
+	if len(info.LargestFiles) > 0 {
+		fmt.Println()
+		colorTitle.Println("📄 最大的文件")
+
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"大小", "文件"})
+		table.SetBorder(true)
+		table.SetRowLine(false)
+		table.SetAutoWrapText(false)
+		table.SetColumnAlignment([]int{tablewriter.ALIGN_RIGHT, tablewriter.ALIGN_LEFT})
+
+		for _, f := range info.LargestFiles {
+			table.Append([]string{formatBytes(f.Bytes), f.Path})
+		}
+		table.Render()
+	}
+}
+
+func printDirUsageNode(node monitor.DirUsageNode, total uint64, prefix, branch string) {
+	percent := 0.0
+	if total > 0 {
+		percent = float64(node.Bytes) / float64(total) * 100
+	}
+
+	fmt.Printf("  ")
+	colorValue.Printf("%10s ", formatBytes(node.Bytes))
+	printPercentColored(percent)
+	fmt.Printf("  %s%s%s\n", prefix, branch, node.Name)
+
+	childPrefix := prefix
+	switch branch {
+	case "├─ ":
+		childPrefix += "│  "
+	case "└─ ":
+		childPrefix += "   "
+	}
+
+	for i, child := range node.Children {
+		childBranch := "├─ "
+		if i == len(node.Children)-1 {
+			childBranch = "└─ "
+		}
+		printDirUsageNode(child, total, childPrefix, childBranch)
+	}
+}
+
+// printPercentColored 打印带颜色的百分比和迷你进度条
+func printPercentColored(percent float64) {
+	width := 10
+	filled := int(percent / 100 * float64(width))
+	if filled > width {
+		filled = width
+	}
+
+	c := colorSuccess
+	if percent >= 80 {
+		c = colorError
+	} else if percent >= 50 {
+		c = colorWarning
+	}
+	c.Printf("%5.1f%% %s", percent, strings.Repeat("█", filled))
+	colorLabel.Print(strings.Repeat("░", width-filled))
+}
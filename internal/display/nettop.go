@@ -0,0 +1,92 @@
+package display
+
+import (
+	"fmt"
+	"os"
+
+	"syspulse/internal/monitor"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// PrintProcessNetworkInfo 打印按进程和容器统计的网络带宽
+func PrintProcessNetworkInfo(info monitor.ProcessNetworkInfo) {
+	colorTitle.Println("🔀 进程带宽 (TCP)")
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"PID", "进程", "容器", "连接数", "发送", "接收"})
+	table.SetBorder(true)
+	table.SetRowLine(false)
+	table.SetAutoWrapText(false)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetColumnAlignment([]int{
+		tablewriter.ALIGN_RIGHT,
+		tablewriter.ALIGN_LEFT,
+		tablewriter.ALIGN_LEFT,
+		tablewriter.ALIGN_RIGHT,
+		tablewriter.ALIGN_RIGHT,
+		tablewriter.ALIGN_RIGHT,
+	})
+
+	for _, p := range info.Processes {
+		table.Append([]string{
+			fmt.Sprintf("%d", p.PID),
+			p.Name,
+			formatContainer(p.ContainerID, p.ContainerName),
+			fmt.Sprintf("%d", p.Connections),
+			formatByteRate(p.SendBytesPerSec),
+			formatByteRate(p.RecvBytesPerSec),
+		})
+	}
+	if info.UnattributedSendPerSec > 0 || info.UnattributedRecvPerSec > 0 {
+		table.Append([]string{"-", "(未归属)", "-", "-", formatByteRate(info.UnattributedSendPerSec), formatByteRate(info.UnattributedRecvPerSec)})
+	}
+	table.Render()
+
+	if len(info.Containers) > 0 {
+		fmt.Println()
+		colorTitle.Println("🐳 容器带宽 (TCP)")
+
+		containerTable := tablewriter.NewWriter(os.Stdout)
+		containerTable.SetHeader([]string{"容器", "进程数", "连接数", "发送", "接收"})
+		containerTable.SetBorder(true)
+		containerTable.SetRowLine(false)
+		containerTable.SetAutoWrapText(false)
+		containerTable.SetAlignment(tablewriter.ALIGN_LEFT)
+
+		for _, c := range info.Containers {
+			containerTable.Append([]string{
+				formatContainer(c.ID, c.Name),
+				fmt.Sprintf("%d", c.Processes),
+				fmt.Sprintf("%d", c.Connections),
+				formatByteRate(c.SendBytesPerSec),
+				formatByteRate(c.RecvBytesPerSec),
+			})
+		}
+		containerTable.Render()
+	}
+
+	if info.Partial {
+		fmt.Println()
+		colorWarning.Printf("⚠️  提示: %d 个进程无权读取，其流量计入 (未归属)，使用 sudo 运行可查看全部进程\n", info.UnreadableProcesses)
+	}
+}
+
+// formatContainer 显示容器名称，没有名称时显示短 ID
+func formatContainer(id, name string) string {
+	if name != "" {
+		return name
+	}
+	if len(id) > 12 {
+		return id[:12]
+	}
+	if id == "" {
+		return "-"
+	}
+	return id
+}
+
+// formatByteRate 格式化每秒字节数
+func formatByteRate(bytesPerSec float64) string {
+	return formatBytes(uint64(bytesPerSec)) + "/s"
+}
@@ -0,0 +1,38 @@
+package display
+
+import (
+	"fmt"
+	"strconv"
+
+	"syspulse/internal/monitor"
+)
+
+// PrintPluginInfo 打印插件输出的指标
+func PrintPluginInfo(info monitor.PluginInfo) {
+	colorTitle.Printf("🧩 %s\n", info.Name)
+
+	if info.Error != "" {
+		fmt.Printf("  ")
+		colorError.Printf("❌ %s\n", info.Error)
+		return
+	}
+	if len(info.Metrics) == 0 {
+		fmt.Printf("  ")
+		colorLabel.Println("插件没有输出指标")
+		return
+	}
+
+	for _, m := range info.Metrics {
+		fmt.Printf("  ")
+		colorLabel.Printf("%s: ", m.Series())
+		colorValue.Println(formatMetricValue(m.Value))
+	}
+}
+
+// formatMetricValue 整数不带小数点，其余保留有效数字
+func formatMetricValue(v float64) string {
+	if v == float64(int64(v)) {
+		return strconv.FormatInt(int64(v), 10)
+	}
+	return strconv.FormatFloat(v, 'g', 6, 64)
+}
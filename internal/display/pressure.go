@@ -0,0 +1,76 @@
+package display
+
+import (
+	"fmt"
+
+	"syspulse/internal/monitor"
+
+	"github.com/fatih/color"
+)
+
+// PrintPressureInfo 打印资源压力（简洁版，仪表盘使用）
+func PrintPressureInfo(info monitor.PressureInfo) {
+	colorTitle.Println("⏳ 资源压力 (PSI avg10)")
+
+	if !info.Available {
+		fmt.Printf("  ")
+		colorLabel.Println("内核未启用 PSI (/proc/pressure 不存在)")
+		return
+	}
+
+	printPressureLine("CPU", info.CPU)
+	printPressureLine("内存", info.Memory)
+	printPressureLine("IO", info.IO)
+}
+
+func printPressureLine(label string, res monitor.PressureResource) {
+	if !res.Available {
+		return
+	}
+	fmt.Printf("  ")
+	colorLabel.Printf("%s: ", label)
+	colorLabel.Print("some ")
+	pressureColor(res.Some.Avg10).Printf("%.2f%%  ", res.Some.Avg10)
+	colorLabel.Print("full ")
+	pressureColor(res.Full.Avg10).Printf("%.2f%%\n", res.Full.Avg10)
+}
+
+// printPressureDetailed 打印单个资源的完整 PSI 数据
+func printPressureDetailed(res monitor.PressureResource) {
+	fmt.Printf("  ")
+	colorLabel.Println("压力 (PSI):")
+
+	if !res.Available {
+		fmt.Printf("    ")
+		colorLabel.Println("内核未启用 PSI")
+		return
+	}
+
+	for _, item := range []struct {
+		name string
+		stat monitor.PressureStat
+	}{
+		{"some", res.Some},
+		{"full", res.Full},
+	} {
+		fmt.Printf("    ")
+		colorLabel.Printf("%-5s ", item.name)
+		colorLabel.Print("10s: ")
+		pressureColor(item.stat.Avg10).Printf("%6.2f%%  ", item.stat.Avg10)
+		colorLabel.Print("60s: ")
+		pressureColor(item.stat.Avg60).Printf("%6.2f%%  ", item.stat.Avg60)
+		colorLabel.Print("300s: ")
+		pressureColor(item.stat.Avg300).Printf("%6.2f%%  ", item.stat.Avg300)
+		colorLabel.Print("累计停顿: ")
+		colorValue.Printf("%.1fs\n", float64(item.stat.TotalUsec)/1e6)
+	}
+}
+
+func pressureColor(percent float64) *color.Color {
+	if percent < 5 {
+		return colorSuccess
+	} else if percent < 20 {
+		return colorWarning
+	}
+	return colorError
+}
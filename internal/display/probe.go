@@ -0,0 +1,80 @@
+package display
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"syspulse/internal/probe"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// PrintProbes 打印连通性探测结果
+func PrintProbes(info probe.Info) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"名称", "类型", "目标", "状态", "延迟", "详情"})
+	table.SetBorder(true)
+	table.SetRowLine(false)
+	table.SetAutoWrapText(false)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+
+	for _, r := range info.Results {
+		status := "✅ 正常"
+		if !r.Success {
+			status = "❌ 失败"
+		} else if r.Slow {
+			status = "🐢 慢"
+		}
+
+		table.Append([]string{
+			r.Name,
+			r.Type,
+			r.Target,
+			status,
+			fmt.Sprintf("%.2f ms", r.LatencyMs),
+			probeDetail(r),
+		})
+	}
+	table.Render()
+	fmt.Println()
+
+	hasWarning := false
+	for _, r := range info.Results {
+		if !r.Success {
+			colorError.Printf("⚠️  警告: %s (%s) 探测失败: %s\n", r.Name, r.Target, r.Error)
+			hasWarning = true
+		}
+		if !r.CertNotAfter.IsZero() && r.CertDaysLeft < 14 {
+			colorWarning.Printf("⚠️  提示: %s 的证书将在 %.0f 天后过期 (%s)\n", r.Name, r.CertDaysLeft, r.CertNotAfter.Format("2006-01-02"))
+			hasWarning = true
+		}
+	}
+	if !hasWarning {
+		colorSuccess.Println("✅ 所有探测正常")
+	}
+}
+
+// probeDetail 按探测类型显示附加信息
+func probeDetail(r probe.Result) string {
+	var parts []string
+	switch r.Type {
+	case probe.TypeHTTP:
+		if r.StatusCode > 0 {
+			parts = append(parts, fmt.Sprintf("HTTP %d", r.StatusCode))
+		}
+		if !r.CertNotAfter.IsZero() {
+			parts = append(parts, fmt.Sprintf("证书剩余 %.0f 天", r.CertDaysLeft))
+		}
+	case probe.TypeDNS:
+		parts = append(parts, strings.Join(r.Addresses, ", "))
+	case probe.TypeICMP:
+		if r.Success || r.PacketLoss > 0 {
+			parts = append(parts, fmt.Sprintf("丢包 %.0f%%", r.PacketLoss))
+		}
+	}
+	if len(parts) == 0 {
+		return "-"
+	}
+	return strings.Join(parts, "  ")
+}
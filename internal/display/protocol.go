@@ -0,0 +1,101 @@
+package display
+
+import (
+	"fmt"
+	"os"
+
+	"syspulse/internal/monitor"
+
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+)
+
+// PrintProtocolStats 打印 TCP / UDP 协议计数器和 conntrack 使用率
+func PrintProtocolStats(info monitor.ProtocolStats) {
+	colorTitle.Println("📶 协议统计")
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"计数器", "累计", "每秒"})
+	table.SetBorder(true)
+	table.SetRowLine(false)
+	table.SetAutoWrapText(false)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetColumnAlignment([]int{tablewriter.ALIGN_LEFT, tablewriter.ALIGN_RIGHT, tablewriter.ALIGN_RIGHT})
+
+	tcp, udp := info.TCP, info.UDP
+	rows := []struct {
+		name    string
+		counter monitor.ProtocolCounter
+	}{
+		{"TCP 主动连接", tcp.ActiveOpens},
+		{"TCP 被动连接", tcp.PassiveOpens},
+		{"TCP 发送段", tcp.OutSegs},
+		{"TCP 重传段", tcp.RetransSegs},
+		{"TCP 连接重置", tcp.EstabResets},
+		{"TCP 发送 RST", tcp.OutRsts},
+		{"TCP 错误段", tcp.InErrs},
+		{"TCP accept 队列溢出", tcp.ListenOverflows},
+		{"TCP 监听丢弃", tcp.ListenDrops},
+		{"TCP SYN 队列丢弃", tcp.SynBacklogDrops},
+		{"UDP 接收", udp.InDatagrams},
+		{"UDP 发送", udp.OutDatagrams},
+		{"UDP 无监听端口", udp.NoPorts},
+		{"UDP 接收错误", udp.InErrors},
+		{"UDP 接收缓冲区满", udp.RcvbufErrors},
+		{"UDP 发送缓冲区满", udp.SndbufErrors},
+	}
+	for _, row := range rows {
+		table.Append([]string{row.name, fmt.Sprintf("%d", row.counter.Total), fmt.Sprintf("%.1f", row.counter.PerSec)})
+	}
+	table.Render()
+
+	fmt.Printf("  ")
+	colorLabel.Print("TCP 已建立连接: ")
+	colorValue.Println(tcp.CurrEstab)
+
+	fmt.Printf("  ")
+	colorLabel.Print("TCP 重传率: ")
+	thresholdColor(tcp.RetransPercent, 2, 5).Printf("%.2f%%\n", tcp.RetransPercent)
+
+	if info.Conntrack.Available {
+		ct := info.Conntrack
+		fmt.Printf("  ")
+		colorLabel.Print("conntrack: ")
+		colorValue.Printf("%d / %d  ", ct.Count, ct.Max)
+		thresholdColor(ct.UsedPercent, 80, 95).Printf("(%.1f%%)\n", ct.UsedPercent)
+		if ct.Drop.Total > 0 || ct.EarlyDrop.Total > 0 || ct.InsertFailed.Total > 0 {
+			fmt.Printf("  ")
+			colorLabel.Print("conntrack 丢弃: ")
+			colorValue.Printf("drop %d  early_drop %d  insert_failed %d\n", ct.Drop.Total, ct.EarlyDrop.Total, ct.InsertFailed.Total)
+		}
+	}
+
+	fmt.Println()
+	hasWarning := false
+	if tcp.ListenOverflows.PerSec > 0 || tcp.SynBacklogDrops.PerSec > 0 {
+		colorWarning.Printf("⚠️  提示: TCP 监听队列正在丢弃连接（溢出 %.1f/s，SYN 丢弃 %.1f/s），检查 backlog 和 somaxconn\n",
+			tcp.ListenOverflows.PerSec, tcp.SynBacklogDrops.PerSec)
+		hasWarning = true
+	}
+	if udp.RcvbufErrors.PerSec > 0 {
+		colorWarning.Printf("⚠️  提示: UDP 接收缓冲区满丢包 %.1f/s，考虑增大 net.core.rmem_max\n", udp.RcvbufErrors.PerSec)
+		hasWarning = true
+	}
+	if info.Conntrack.Available && info.Conntrack.UsedPercent >= 80 {
+		colorError.Printf("⚠️  警告: conntrack 表使用率 %.1f%%，写满后新连接会被丢弃！\n", info.Conntrack.UsedPercent)
+		hasWarning = true
+	}
+	if !hasWarning {
+		colorSuccess.Println("✅ 协议计数器无异常")
+	}
+}
+
+// thresholdColor 根据告警阈值选择颜色
+func thresholdColor(value, warning, critical float64) *color.Color {
+	if value >= critical {
+		return colorError
+	} else if value >= warning {
+		return colorWarning
+	}
+	return colorSuccess
+}
@@ -0,0 +1,58 @@
+package display
+
+import (
+	"fmt"
+	"strconv"
+
+	"syspulse/internal/monitor"
+)
+
+// PrintScrapeInfo 打印抓取目标的状态和固定序列的当前值
+func PrintScrapeInfo(info monitor.ScrapeInfo) {
+	colorTitle.Println("📈 应用指标")
+
+	for _, t := range info.Targets {
+		fmt.Printf("  ")
+		if !t.Up {
+			colorError.Printf("❌ %s: %s\n", t.Name, t.Error)
+			continue
+		}
+		colorSuccess.Printf("✅ %s", t.Name)
+		colorLabel.Printf(" (%.1f ms, %d 个序列)\n", t.DurationMs, t.SampleCount)
+
+		for _, p := range t.Pins {
+			fmt.Printf("     ")
+			colorLabel.Printf("%s: ", p.Title)
+			if p.Error != "" {
+				colorLabel.Println(p.Error)
+				continue
+			}
+			colorValue.Println(formatPinnedValue(p))
+		}
+	}
+}
+
+// PrintScrapeSeries 打印抓取到的所有序列，用于选择要固定显示的序列
+func PrintScrapeSeries(target string, metrics []monitor.Metric) {
+	colorTitle.Printf("📈 %s (%d 个序列)\n", target, len(metrics))
+	for _, m := range metrics {
+		fmt.Printf("  ")
+		colorLabel.Printf("%-9s ", m.Type)
+		fmt.Printf("%s ", m.Series())
+		colorValue.Println(formatMetricValue(m.Value))
+	}
+}
+
+// formatPinnedValue 速率带 /s 后缀，平均值和分位数标明取值方式
+func formatPinnedValue(p monitor.PinnedValue) string {
+	value := formatMetricValue(p.Value)
+	switch p.Kind {
+	case monitor.PinRate:
+		return value + "/s"
+	case monitor.PinAverage:
+		return value + " (平均)"
+	case monitor.PinQuantile:
+		return value + " (p" + strconv.FormatFloat(p.Quantile*100, 'g', 4, 64) + ")"
+	}
+	return value
+}
@@ -0,0 +1,108 @@
+package display
+
+import (
+	"fmt"
+	"os"
+
+	"syspulse/internal/monitor"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// 传感器类型的显示名称
+var sensorKindNames = map[string]string{
+	monitor.SensorCPUPackage:  "CPU 封装",
+	monitor.SensorCPUCore:     "CPU 核心",
+	monitor.SensorNVMe:        "NVMe",
+	monitor.SensorThermalZone: "温区",
+	monitor.SensorOther:       "其他",
+}
+
+// PrintSensorsInfo 打印温度、风扇和功耗传感器
+func PrintSensorsInfo(info monitor.SensorsInfo) {
+	if len(info.Temperatures) > 0 {
+		colorTitle.Println("🌡️  温度")
+
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"类型", "芯片", "传感器", "温度", "高温", "临界"})
+		table.SetBorder(true)
+		table.SetRowLine(false)
+		table.SetAutoWrapText(false)
+		table.SetAlignment(tablewriter.ALIGN_LEFT)
+
+		for _, t := range info.Temperatures {
+			table.Append([]string{
+				sensorKindNames[t.Kind],
+				t.Chip,
+				t.Label,
+				fmt.Sprintf("%.1f°C", t.Celsius),
+				formatThreshold(t.High),
+				formatThreshold(t.Critical),
+			})
+		}
+		table.Render()
+		fmt.Println()
+	}
+
+	if len(info.Fans) > 0 {
+		colorTitle.Println("🌀 风扇")
+		for _, f := range info.Fans {
+			fmt.Printf("  ")
+			colorLabel.Printf("%s %s: ", f.Chip, f.Label)
+			if f.RPM == 0 {
+				colorWarning.Println("0 RPM (停转)")
+			} else {
+				colorValue.Printf("%d RPM\n", f.RPM)
+			}
+		}
+		fmt.Println()
+	}
+
+	if len(info.Power) > 0 {
+		colorTitle.Println("⚡ 功耗 (RAPL)")
+		for _, p := range info.Power {
+			fmt.Printf("  ")
+			colorLabel.Printf("%s: ", p.Domain)
+			colorValue.Printf("%.1f W\n", p.Watts)
+		}
+		fmt.Println()
+	}
+
+	// 温度告警
+	hasWarning := false
+	for _, t := range info.Temperatures {
+		if t.Critical > 0 && t.Celsius >= t.Critical {
+			colorError.Printf("⚠️  警告: %s %s 温度 %.1f°C 已达临界值 %.1f°C！\n", t.Chip, t.Label, t.Celsius, t.Critical)
+			hasWarning = true
+		} else if t.High > 0 && t.Celsius >= t.High {
+			colorWarning.Printf("⚠️  提示: %s %s 温度 %.1f°C 超过高温阈值 %.1f°C\n", t.Chip, t.Label, t.Celsius, t.High)
+			hasWarning = true
+		}
+	}
+	if !hasWarning {
+		colorSuccess.Println("✅ 所有传感器温度正常")
+	}
+}
+
+// printTemperature 按温度高低着色打印（critical 为 0 时使用默认阈值）
+func printTemperature(celsius, critical float64) {
+	warning, danger := 70.0, 85.0
+	if critical > 0 {
+		warning, danger = critical-15, critical-5
+	}
+
+	if celsius < warning {
+		colorSuccess.Printf("%.1f°C\n", celsius)
+	} else if celsius < danger {
+		colorWarning.Printf("%.1f°C\n", celsius)
+	} else {
+		colorError.Printf("%.1f°C\n", celsius)
+	}
+}
+
+func formatThreshold(celsius float64) string {
+	if celsius <= 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.0f°C", celsius)
+}
@@ -0,0 +1,100 @@
+package display
+
+import (
+	"fmt"
+	"os"
+
+	"syspulse/internal/monitor"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// PrintServicesInfo 打印 systemd 服务状态
+func PrintServicesInfo(info monitor.ServicesInfo, showAll bool) {
+	fmt.Printf("  ")
+	colorLabel.Print("运行中: ")
+	colorSuccess.Printf("%d ", info.ActiveCount)
+	colorLabel.Print("/ 失败: ")
+	if info.FailedCount > 0 {
+		colorError.Printf("%d ", info.FailedCount)
+	} else {
+		colorValue.Printf("%d ", info.FailedCount)
+	}
+	colorLabel.Print("/ 频繁重启: ")
+	if info.FlappingCount > 0 {
+		colorWarning.Printf("%d ", info.FlappingCount)
+	} else {
+		colorValue.Printf("%d ", info.FlappingCount)
+	}
+	colorLabel.Print("/ 总计: ")
+	colorValue.Println(info.TotalCount)
+	fmt.Println()
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"服务", "状态", "PID", "重启", "内存", "CPU%", "描述"})
+	table.SetBorder(true)
+	table.SetRowLine(false)
+	table.SetAutoWrapText(false)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+
+	for _, s := range info.Services {
+		// 默认隐藏未运行且正常的服务
+		if !showAll && s.ActiveState == "inactive" && !s.Failed && !s.Flapping {
+			continue
+		}
+
+		state := s.ActiveState + "/" + s.SubState
+		if s.Failed {
+			state = "❌ " + state
+		} else if s.Flapping {
+			state = "🔁 " + state
+		}
+
+		pidStr := "-"
+		if s.MainPID > 0 {
+			pidStr = fmt.Sprintf("%d", s.MainPID)
+		}
+
+		memStr := "-"
+		if s.MemoryBytes > 0 {
+			memStr = formatBytes(s.MemoryBytes)
+		}
+
+		description := s.Description
+		if len(description) > 40 {
+			description = description[:37] + "..."
+		}
+
+		restartStr := fmt.Sprintf("%d", s.Restarts)
+		if s.RecentRestarts > 0 {
+			restartStr = fmt.Sprintf("%d (+%d)", s.Restarts, s.RecentRestarts)
+		}
+
+		table.Append([]string{
+			s.Name,
+			state,
+			pidStr,
+			restartStr,
+			memStr,
+			fmt.Sprintf("%.1f%%", s.CPUPercent),
+			description,
+		})
+	}
+
+	table.Render()
+
+	fmt.Println()
+	if info.FailedCount == 0 && info.FlappingCount == 0 {
+		colorSuccess.Println("✅ 所有服务运行正常")
+		return
+	}
+	for _, s := range info.Services {
+		if s.Failed {
+			colorError.Printf("⚠️  警告: %s 处于失败状态\n", s.Name)
+		} else if s.Flapping && s.RecentRestarts > 0 {
+			colorWarning.Printf("⚠️  提示: %s 频繁重启 (最近 10 分钟重启 %d 次)\n", s.Name, s.RecentRestarts)
+		} else if s.Flapping {
+			colorWarning.Printf("⚠️  提示: %s 正在等待自动重启\n", s.Name)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package display
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"syspulse/internal/monitor"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// PrintDriveHealth 打印磁盘 SMART / NVMe 健康状态
+func PrintDriveHealth(info monitor.DriveHealthInfo) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"设备", "型号", "协议", "状态", "温度", "寿命已用", "重映射", "待映射", "介质错误", "通电时间"})
+	table.SetBorder(true)
+	table.SetRowLine(false)
+	table.SetAutoWrapText(false)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+
+	for _, d := range info.Drives {
+		if !d.Supported {
+			table.Append([]string{d.Device, d.Model, d.Protocol, "不可用", "-", "-", "-", "-", "-", "-"})
+			continue
+		}
+
+		status := "PASSED"
+		if !d.Passed {
+			status = "FAILED"
+		}
+		temperature := "-"
+		if d.Temperature > 0 {
+			temperature = fmt.Sprintf("%.0f°C", d.Temperature)
+		}
+		wear := "-"
+		if d.WearKnown {
+			wear = fmt.Sprintf("%.0f%%", d.PercentUsed)
+		}
+
+		table.Append([]string{
+			d.Device,
+			d.Model,
+			d.Protocol,
+			status,
+			temperature,
+			wear,
+			fmt.Sprintf("%d", d.ReallocatedSectors),
+			fmt.Sprintf("%d", d.PendingSectors),
+			fmt.Sprintf("%d", d.MediaErrors),
+			fmt.Sprintf("%dh", d.PowerOnHours),
+		})
+	}
+	table.Render()
+	fmt.Println()
+
+	// 健康告警
+	hasWarning := false
+	for _, d := range info.Drives {
+		if d.Error != "" {
+			colorWarning.Printf("⚠️  提示: %s 无法读取 SMART: %s\n", d.Device, d.Error)
+		}
+		if len(d.Issues) > 0 {
+			colorError.Printf("⚠️  警告: %s %s\n", d.Device, strings.Join(d.Issues, "，"))
+			hasWarning = true
+		}
+	}
+	if !hasWarning {
+		colorSuccess.Println("✅ 所有磁盘健康状态正常")
+	}
+}
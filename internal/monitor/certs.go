@@ -0,0 +1,215 @@
+package monitor
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// certScanWorkers 并发握手的连接数
+const certScanWorkers = 16
+
+// CertScanOptions 证书扫描选项
+type CertScanOptions struct {
+	// ScanListeners 是否扫描本机所有 TCP 监听端口
+	ScanListeners bool
+	// Targets 额外扫描的 host:port
+	Targets []string
+	// WarnDays 剩余天数低于该值时视为即将过期
+	WarnDays int
+	// Timeout 单个握手的超时时间
+	Timeout time.Duration
+}
+
+// DefaultCertScanOptions 默认扫描选项
+func DefaultCertScanOptions() CertScanOptions {
+	return CertScanOptions{
+		ScanListeners: true,
+		WarnDays:      30,
+		Timeout:       3 * time.Second,
+	}
+}
+
+// certTarget 待扫描的地址
+type certTarget struct {
+	address     string
+	serverName  string
+	source      string
+	pid         int32
+	processName string
+}
+
+// GetCertificateInfo 对本机 TCP 监听端口和配置的地址进行 TLS 握手，记录服务端证书
+// 非 TLS 端口握手失败时直接跳过
+func GetCertificateInfo(ctx context.Context, opts CertScanOptions) CertificatesInfo {
+	var targets []certTarget
+	seen := make(map[string]bool)
+
+	for _, target := range opts.Targets {
+		host, _, err := net.SplitHostPort(target)
+		if err != nil || seen[target] {
+			continue
+		}
+		seen[target] = true
+		targets = append(targets, certTarget{address: target, serverName: host, source: "config"})
+	}
+
+	if opts.ScanListeners {
+		for _, port := range GetPortInfo(ctx).Listening {
+			if port.Protocol != "tcp" && port.Protocol != "tcp6" {
+				continue
+			}
+			address := net.JoinHostPort(listenerDialHost(port.Address), strconv.Itoa(int(port.Port)))
+			if seen[address] {
+				continue
+			}
+			seen[address] = true
+			targets = append(targets, certTarget{
+				address:     address,
+				source:      "listener",
+				pid:         port.PID,
+				processName: port.ProcessName,
+			})
+		}
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var certs []CertificateInfo
+	sem := make(chan struct{}, certScanWorkers)
+
+	for _, target := range targets {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(target certTarget) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			cert, ok := scanCertificate(ctx, target, opts)
+			if !ok {
+				return
+			}
+			mu.Lock()
+			certs = append(certs, cert)
+			mu.Unlock()
+		}(target)
+	}
+	wg.Wait()
+
+	// 剩余天数少的在前
+	sort.Slice(certs, func(i, j int) bool {
+		return certs[i].DaysLeft < certs[j].DaysLeft
+	})
+
+	info := CertificatesInfo{
+		Certificates: certs,
+		ScannedCount: len(targets),
+		WarnDays:     opts.WarnDays,
+		Timestamp:    time.Now(),
+	}
+	for _, cert := range certs {
+		if cert.Expired {
+			info.ExpiredCount++
+		} else if cert.ExpiringSoon {
+			info.ExpiringCount++
+		}
+		if cert.SelfSigned {
+			info.SelfSignedCount++
+		}
+	}
+
+	return info
+}
+
+// listenerDialHost 监听在通配地址上的端口通过回环地址连接
+func listenerDialHost(address string) string {
+	switch address {
+	case "", "0.0.0.0", "*":
+		return "127.0.0.1"
+	case "::":
+		return "::1"
+	}
+	return address
+}
+
+// scanCertificate 握手并解析证书链，握手失败（非 TLS 端口）时返回 false
+func scanCertificate(ctx context.Context, target certTarget, opts CertScanOptions) (CertificateInfo, bool) {
+	dialer := &tls.Dialer{
+		NetDialer: &net.Dialer{Timeout: opts.Timeout},
+		Config: &tls.Config{
+			// 只读取证书，校验在下面单独进行，以便记录自签名和过期的证书
+			InsecureSkipVerify: true,
+			ServerName:         target.serverName,
+		},
+	}
+	netConn, err := dialer.DialContext(ctx, "tcp", target.address)
+	if err != nil {
+		return CertificateInfo{}, false
+	}
+	conn := netConn.(*tls.Conn)
+	state := conn.ConnectionState()
+	conn.Close()
+
+	if len(state.PeerCertificates) == 0 {
+		return CertificateInfo{}, false
+	}
+	leaf := state.PeerCertificates[0]
+
+	info := CertificateInfo{
+		Address:     target.address,
+		Source:      target.source,
+		PID:         target.pid,
+		ProcessName: target.processName,
+		Subject:     leaf.Subject.CommonName,
+		DNSNames:    leaf.DNSNames,
+		Issuer:      leaf.Issuer.CommonName,
+		NotBefore:   leaf.NotBefore,
+		NotAfter:    leaf.NotAfter,
+		DaysLeft:    time.Until(leaf.NotAfter).Hours() / 24,
+		SelfSigned:  isSelfSigned(leaf),
+	}
+	for _, ip := range leaf.IPAddresses {
+		info.DNSNames = append(info.DNSNames, ip.String())
+	}
+	for _, cert := range state.PeerCertificates {
+		info.Chain = append(info.Chain, CertificateSummary{
+			Subject:  cert.Subject.CommonName,
+			Issuer:   cert.Issuer.CommonName,
+			NotAfter: cert.NotAfter,
+		})
+	}
+
+	info.Expired = info.DaysLeft <= 0
+	info.ExpiringSoon = !info.Expired && info.DaysLeft < float64(opts.WarnDays)
+
+	// 使用系统根证书校验证书链（监听端口按 IP 连接，不校验主机名）
+	intermediates := x509.NewCertPool()
+	for _, cert := range state.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+	verifyOpts := x509.VerifyOptions{Intermediates: intermediates}
+	if target.serverName != "" && net.ParseIP(target.serverName) == nil {
+		verifyOpts.DNSName = target.serverName
+	}
+	if _, err := leaf.Verify(verifyOpts); err != nil {
+		info.VerifyError = err.Error()
+	} else {
+		info.Verified = true
+	}
+
+	return info, true
+}
+
+// isSelfSigned 颁发者与主体相同且能用自身公钥验证签名
+func isSelfSigned(cert *x509.Certificate) bool {
+	if !strings.EqualFold(cert.Issuer.String(), cert.Subject.String()) {
+		return false
+	}
+	return cert.CheckSignatureFrom(cert) == nil
+}
@@ -0,0 +1,232 @@
+package monitor
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultCgroupRoot cgroup v2 默认挂载点
+const DefaultCgroupRoot = "/sys/fs/cgroup"
+
+// cgroupSampleInterval 计算 CPU 使用率的采样间隔
+const cgroupSampleInterval = time.Second
+
+// GetCgroupInfo 获取 cgroup v2 资源统计（直接读取 /sys/fs/cgroup，不依赖容器运行时）
+func GetCgroupInfo(ctx context.Context) CgroupInfo {
+	return GetCgroupInfoFrom(ctx, DefaultCgroupRoot)
+}
+
+// GetCgroupInfoFrom 从指定目录读取 cgroup v2 统计，便于针对伪造的 cgroupfs 目录运行
+func GetCgroupInfoFrom(ctx context.Context, root string) CgroupInfo {
+	unified, ok := findCgroupV2Root(root)
+	if !ok {
+		return CgroupInfo{Available: false, Root: root, Timestamp: time.Now()}
+	}
+
+	// 两次采样 cpu.stat 计算 CPU 使用率
+	// 采样被打断（超时）时返回没有 CPU 使用率的统计
+	before := readCgroupTree(unified, "/", 0)
+	start := time.Now()
+	if err := sleepContext(ctx, cgroupSampleInterval); err != nil {
+		return CgroupInfo{Available: true, Root: unified, Tree: before, Timestamp: time.Now()}
+	}
+	after := readCgroupTree(unified, "/", 0)
+	elapsed := time.Since(start)
+
+	applyCgroupCPUPercent(&after, indexCgroupUsage(before), elapsed)
+
+	return CgroupInfo{
+		Available: true,
+		Root:      unified,
+		Tree:      after,
+		Timestamp: time.Now(),
+	}
+}
+
+// findCgroupV2Root 查找 cgroup v2 层级（兼容 hybrid 模式下的 unified 目录）
+func findCgroupV2Root(root string) (string, bool) {
+	candidates := []string{root, filepath.Join(root, "unified")}
+	for _, dir := range candidates {
+		if _, err := os.Stat(filepath.Join(dir, "cgroup.controllers")); err == nil {
+			return dir, true
+		}
+	}
+	return "", false
+}
+
+// readCgroupTree 递归读取 cgroup 目录
+func readCgroupTree(root, rel string, depth int) CgroupNode {
+	dir := filepath.Join(root, rel)
+
+	node := CgroupNode{
+		Path:  rel,
+		Name:  filepath.Base(rel),
+		Depth: depth,
+	}
+	if rel == "/" {
+		node.Name = "/"
+	}
+
+	// CPU
+	cpuStat := readKeyValueFile(filepath.Join(dir, "cpu.stat"))
+	node.CPU = CgroupCPUStat{
+		UsageUsec:     cpuStat["usage_usec"],
+		UserUsec:      cpuStat["user_usec"],
+		SystemUsec:    cpuStat["system_usec"],
+		NrPeriods:     cpuStat["nr_periods"],
+		NrThrottled:   cpuStat["nr_throttled"],
+		ThrottledUsec: cpuStat["throttled_usec"],
+	}
+
+	// 内存
+	node.Memory.Current, _ = readCgroupUint(filepath.Join(dir, "memory.current"))
+	node.Memory.Max, _ = readCgroupUint(filepath.Join(dir, "memory.max"))
+	events := readKeyValueFile(filepath.Join(dir, "memory.events"))
+	node.Memory.Events = CgroupMemoryEvents{
+		Low:     events["low"],
+		High:    events["high"],
+		Max:     events["max"],
+		OOM:     events["oom"],
+		OOMKill: events["oom_kill"],
+	}
+
+	// IO
+	node.IO = readCgroupIOStat(filepath.Join(dir, "io.stat"))
+
+	// 进程数
+	node.Pids.Current, _ = readCgroupUint(filepath.Join(dir, "pids.current"))
+	node.Pids.Max, _ = readCgroupUint(filepath.Join(dir, "pids.max"))
+
+	// 压力
+	node.Pressure.CPU = readPressureFile(filepath.Join(dir, "cpu.pressure"))
+	node.Pressure.Memory = readPressureFile(filepath.Join(dir, "memory.pressure"))
+	node.Pressure.IO = readPressureFile(filepath.Join(dir, "io.pressure"))
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return node
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		child := readCgroupTree(root, filepath.Join(rel, entry.Name()), depth+1)
+		node.Children = append(node.Children, child)
+	}
+
+	return node
+}
+
+// indexCgroupUsage 按路径索引 CPU 累计使用时间
+func indexCgroupUsage(node CgroupNode) map[string]uint64 {
+	usage := make(map[string]uint64)
+	var walk func(n CgroupNode)
+	walk = func(n CgroupNode) {
+		usage[n.Path] = n.CPU.UsageUsec
+		for _, child := range n.Children {
+			walk(child)
+		}
+	}
+	walk(node)
+	return usage
+}
+
+// applyCgroupCPUPercent 根据两次采样差值计算 CPU 使用率（100% 表示占满一个核心）
+func applyCgroupCPUPercent(node *CgroupNode, before map[string]uint64, elapsed time.Duration) {
+	if prev, ok := before[node.Path]; ok && node.CPU.UsageUsec >= prev && elapsed > 0 {
+		delta := float64(node.CPU.UsageUsec - prev)
+		node.CPU.UsagePercent = delta / float64(elapsed.Microseconds()) * 100
+	}
+	for i := range node.Children {
+		applyCgroupCPUPercent(&node.Children[i], before, elapsed)
+	}
+}
+
+// SortCgroupTree 按指定字段对每一层子节点降序排序（cpu、memory、io、pids）
+func SortCgroupTree(node *CgroupNode, by string) {
+	sort.SliceStable(node.Children, func(i, j int) bool {
+		return cgroupSortKey(node.Children[i], by) > cgroupSortKey(node.Children[j], by)
+	})
+	for i := range node.Children {
+		SortCgroupTree(&node.Children[i], by)
+	}
+}
+
+func cgroupSortKey(node CgroupNode, by string) float64 {
+	switch by {
+	case "memory":
+		return float64(node.Memory.Current)
+	case "io":
+		return float64(node.IO.ReadBytes + node.IO.WriteBytes)
+	case "pids":
+		return float64(node.Pids.Current)
+	default:
+		return node.CPU.UsagePercent
+	}
+}
+
+// readCgroupUint 读取单值文件，"max" 视为无限制返回 0
+func readCgroupUint(path string) (uint64, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, false
+	}
+	value := strings.TrimSpace(string(data))
+	if value == "max" {
+		return 0, true
+	}
+	n, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+// readCgroupIOStat 读取 io.stat 并汇总所有设备
+func readCgroupIOStat(path string) CgroupIOStat {
+	var stat CgroupIOStat
+
+	file, err := os.Open(path)
+	if err != nil {
+		return stat
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		// 格式: 8:0 rbytes=1 wbytes=2 rios=3 wios=4 dbytes=0 dios=0
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		for _, field := range fields[1:] {
+			key, value, found := strings.Cut(field, "=")
+			if !found {
+				continue
+			}
+			n, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				continue
+			}
+			switch key {
+			case "rbytes":
+				stat.ReadBytes += n
+			case "wbytes":
+				stat.WriteBytes += n
+			case "rios":
+				stat.ReadIOs += n
+			case "wios":
+				stat.WriteIOs += n
+			}
+		}
+	}
+
+	return stat
+}
@@ -0,0 +1,191 @@
+package monitor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeTestFiles 在 root 下按相对路径写入文件（模拟 cgroupfs、sysfs）
+func writeTestFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for rel, content := range files {
+		path := filepath.Join(root, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// findCgroupNode 按路径查找节点
+func findCgroupNode(node CgroupNode, path string) (CgroupNode, bool) {
+	if node.Path == path {
+		return node, true
+	}
+	for _, child := range node.Children {
+		if found, ok := findCgroupNode(child, path); ok {
+			return found, true
+		}
+	}
+	return CgroupNode{}, false
+}
+
+// serviceFiles 一个服务 cgroup 的统计文件
+var serviceFiles = map[string]string{
+	"cgroup.controllers":                        "cpu io memory pids\n",
+	"system.slice/ssh.service/cpu.stat":         "usage_usec 5000\nuser_usec 3000\nsystem_usec 2000\nnr_periods 10\nnr_throttled 2\nthrottled_usec 700\n",
+	"system.slice/ssh.service/memory.current":   "1048576\n",
+	"system.slice/ssh.service/memory.max":       "max\n",
+	"system.slice/ssh.service/memory.events":    "low 0\nhigh 1\nmax 2\noom 3\noom_kill 1\n",
+	"system.slice/ssh.service/io.stat":          "8:0 rbytes=100 wbytes=200 rios=1 wios=2 dbytes=0 dios=0\n8:16 rbytes=10 wbytes=20 rios=3 wios=4\n",
+	"system.slice/ssh.service/pids.current":     "4\n",
+	"system.slice/ssh.service/pids.max":         "512\n",
+	"system.slice/ssh.service/memory.pressure":  "some avg10=1.50 avg60=0.50 avg300=0.10 total=1234\nfull avg10=0.25 avg60=0.00 avg300=0.00 total=99\n",
+	"system.slice/ssh.service/cgroup.procs":     "1\n",
+	"user.slice/user-1000.slice/memory.current": "2048\n",
+	"user.slice/user-1000.slice/memory.max":     "4096\n",
+	"user.slice/user-1000.slice/cgroup.procs":   "",
+}
+
+func TestGetCgroupInfoFrom(t *testing.T) {
+	tests := []struct {
+		name      string
+		prefix    string // 统计文件所在的子目录（hybrid 模式为 unified）
+		available bool
+	}{
+		{name: "unified", prefix: "", available: true},
+		{name: "hybrid", prefix: "unified", available: true},
+		{name: "v1 only", prefix: "cpu", available: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			files := make(map[string]string, len(serviceFiles))
+			for rel, content := range serviceFiles {
+				if tt.prefix == "cpu" && rel == "cgroup.controllers" {
+					continue // v1 层级没有 cgroup.controllers
+				}
+				files[filepath.Join(tt.prefix, rel)] = content
+			}
+			writeTestFiles(t, root, files)
+
+			// 已取消的 ctx 跳过 CPU 使用率采样，直接返回第一次读取的树
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			info := GetCgroupInfoFrom(ctx, root)
+
+			if info.Available != tt.available {
+				t.Fatalf("Available = %v, want %v", info.Available, tt.available)
+			}
+			if !tt.available {
+				return
+			}
+			if want := filepath.Join(root, tt.prefix); info.Root != want {
+				t.Errorf("Root = %q, want %q", info.Root, want)
+			}
+
+			ssh, ok := findCgroupNode(info.Tree, "/system.slice/ssh.service")
+			if !ok {
+				t.Fatal("ssh.service not found in tree")
+			}
+			if ssh.Name != "ssh.service" || ssh.Depth != 2 {
+				t.Errorf("Name, Depth = %q, %d, want ssh.service, 2", ssh.Name, ssh.Depth)
+			}
+			wantCPU := CgroupCPUStat{UsageUsec: 5000, UserUsec: 3000, SystemUsec: 2000, NrPeriods: 10, NrThrottled: 2, ThrottledUsec: 700}
+			if ssh.CPU != wantCPU {
+				t.Errorf("CPU = %+v, want %+v", ssh.CPU, wantCPU)
+			}
+			wantMemory := CgroupMemoryStat{Current: 1 << 20, Max: 0, Events: CgroupMemoryEvents{High: 1, Max: 2, OOM: 3, OOMKill: 1}}
+			if ssh.Memory != wantMemory {
+				t.Errorf("Memory = %+v, want %+v", ssh.Memory, wantMemory)
+			}
+			wantIO := CgroupIOStat{ReadBytes: 110, WriteBytes: 220, ReadIOs: 4, WriteIOs: 6}
+			if ssh.IO != wantIO {
+				t.Errorf("IO = %+v, want %+v", ssh.IO, wantIO)
+			}
+			if ssh.Pids != (CgroupPidsStat{Current: 4, Max: 512}) {
+				t.Errorf("Pids = %+v, want {4 512}", ssh.Pids)
+			}
+			if p := ssh.Pressure.Memory; !p.Available || p.Some.Avg10 != 1.5 || p.Full.TotalUsec != 99 {
+				t.Errorf("Pressure.Memory = %+v", p)
+			}
+			if ssh.Pressure.CPU.Available {
+				t.Error("Pressure.CPU.Available = true without cpu.pressure")
+			}
+
+			user, ok := findCgroupNode(info.Tree, "/user.slice/user-1000.slice")
+			if !ok {
+				t.Fatal("user-1000.slice not found in tree")
+			}
+			if user.Memory.Current != 2048 || user.Memory.Max != 4096 {
+				t.Errorf("user Memory = %+v, want current 2048 max 4096", user.Memory)
+			}
+		})
+	}
+}
+
+func TestApplyCgroupCPUPercent(t *testing.T) {
+	tests := []struct {
+		name    string
+		before  map[string]uint64
+		usage   uint64
+		elapsed time.Duration
+		want    float64
+	}{
+		{name: "one core busy", before: map[string]uint64{"/a": 1000}, usage: 1001000, elapsed: time.Second, want: 100},
+		{name: "half core", before: map[string]uint64{"/a": 0}, usage: 250000, elapsed: 500 * time.Millisecond, want: 50},
+		{name: "new cgroup", before: map[string]uint64{}, usage: 5000, elapsed: time.Second, want: 0},
+		{name: "counter went backwards", before: map[string]uint64{"/a": 9000}, usage: 10, elapsed: time.Second, want: 0},
+		{name: "zero elapsed", before: map[string]uint64{"/a": 0}, usage: 10, elapsed: 0, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := CgroupNode{Path: "/", Children: []CgroupNode{{Path: "/a", CPU: CgroupCPUStat{UsageUsec: tt.usage}}}}
+			applyCgroupCPUPercent(&root, tt.before, tt.elapsed)
+			if got := root.Children[0].CPU.UsagePercent; got != tt.want {
+				t.Errorf("UsagePercent = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortCgroupTree(t *testing.T) {
+	tree := CgroupNode{Path: "/", Children: []CgroupNode{
+		{Name: "a", CPU: CgroupCPUStat{UsagePercent: 10}, Memory: CgroupMemoryStat{Current: 300}, Pids: CgroupPidsStat{Current: 1}},
+		{Name: "b", CPU: CgroupCPUStat{UsagePercent: 30}, Memory: CgroupMemoryStat{Current: 100}, IO: CgroupIOStat{ReadBytes: 5}, Pids: CgroupPidsStat{Current: 3}},
+		{Name: "c", CPU: CgroupCPUStat{UsagePercent: 20}, Memory: CgroupMemoryStat{Current: 200}, IO: CgroupIOStat{WriteBytes: 50}, Pids: CgroupPidsStat{Current: 2}},
+	}}
+
+	tests := []struct {
+		by   string
+		want string
+	}{
+		{by: "cpu", want: "bca"},
+		{by: "memory", want: "acb"},
+		{by: "io", want: "cba"},
+		{by: "pids", want: "bca"},
+		{by: "unknown", want: "bca"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.by, func(t *testing.T) {
+			node := tree
+			node.Children = append([]CgroupNode(nil), tree.Children...)
+			SortCgroupTree(&node, tt.by)
+			got := ""
+			for _, child := range node.Children {
+				got += child.Name
+			}
+			if got != tt.want {
+				t.Errorf("order = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
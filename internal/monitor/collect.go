@@ -0,0 +1,43 @@
+package monitor
+
+import (
+	"context"
+	"time"
+)
+
+// Collect 在 timeout 内运行采集函数，返回结果和是否按时完成（timeout 为 0 表示不限时）
+// 采集函数收到带超时的 ctx，取消后应尽快返回；卡在无法中断的调用上时（如挂起的 NFS、无响应的 Docker），
+// Collect 到时即返回零值和 false，采集函数在后台结束后结果被丢弃
+func Collect[T any](ctx context.Context, timeout time.Duration, collect func(context.Context) T) (T, bool) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	result := make(chan T, 1)
+	go func() {
+		result <- collect(ctx)
+	}()
+
+	select {
+	case value := <-result:
+		return value, true
+	case <-ctx.Done():
+		var zero T
+		return zero, false
+	}
+}
+
+// sleepContext 等待 d 或 ctx 取消，取消时返回 ctx 的错误
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
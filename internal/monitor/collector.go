@@ -0,0 +1,449 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// MetricType 指标类型，与 Prometheus 的类型对应
+type MetricType string
+
+const (
+	// MetricGauge 可增可减的当前值（如队列长度）
+	MetricGauge MetricType = "gauge"
+	// MetricCounter 只增不减的累计值（如请求总数）
+	MetricCounter MetricType = "counter"
+	// MetricHistogram 直方图的一个样本（_bucket、_sum 或 _count）
+	MetricHistogram MetricType = "histogram"
+	// MetricSummary 摘要的一个样本（分位数、_sum 或 _count）
+	MetricSummary MetricType = "summary"
+	// MetricUntyped 未声明类型
+	MetricUntyped MetricType = "untyped"
+)
+
+// Metric 一个指标样本
+type Metric struct {
+	// 样本名，如 syspulse_cpu_usage_percent、queue_depth；直方图和摘要带有 _bucket、_sum、_count 后缀
+	Name   string
+	Type   MetricType
+	Help   string
+	Labels map[string]string
+	Value  float64
+}
+
+// Sample 采集器一次采集的结果
+type Sample struct {
+	// Info 用于展示的完整数据：内置采集器为 CPUInfo 等结构体，插件为 PluginInfo
+	Info interface{}
+	// Metrics 可导出的数值指标
+	Metrics []Metric
+}
+
+// Collector 定期采集一组指标的采集器，内置子系统和外部插件都通过它注册
+type Collector interface {
+	// Name 唯一名称，同时是 Web 数据中的键、WebSocket 主题和 timeouts 配置中的子系统名
+	Name() string
+	// Interval 采集间隔，0 表示跟随使用方的刷新间隔
+	Interval() time.Duration
+	// Collect 采集一次，ctx 取消后应尽快返回
+	Collect(ctx context.Context) Sample
+}
+
+// funcCollector 用函数实现的 Collector
+type funcCollector[T any] struct {
+	name     string
+	interval time.Duration
+	collect  func(context.Context) T
+	metrics  func(T) []Metric
+}
+
+// NewCollector 把 GetXxx 形式的采集函数包装成 Collector，metrics 从结果中提取指标（可为 nil）
+func NewCollector[T any](name string, interval time.Duration, collect func(context.Context) T, metrics func(T) []Metric) Collector {
+	return &funcCollector[T]{name: name, interval: interval, collect: collect, metrics: metrics}
+}
+
+func (c *funcCollector[T]) Name() string            { return c.name }
+func (c *funcCollector[T]) Interval() time.Duration { return c.interval }
+
+func (c *funcCollector[T]) Collect(ctx context.Context) Sample {
+	info := c.collect(ctx)
+	sample := Sample{Info: info}
+	if c.metrics != nil {
+		sample.Metrics = c.metrics(info)
+	}
+	return sample
+}
+
+// Registry 按注册顺序保存的采集器
+type Registry struct {
+	mu         sync.RWMutex
+	collectors []Collector
+	byName     map[string]Collector
+}
+
+// NewRegistry 创建采集器注册表，可同时传入要注册的采集器
+func NewRegistry(collectors ...Collector) *Registry {
+	r := &Registry{byName: make(map[string]Collector)}
+	for _, c := range collectors {
+		r.Register(c)
+	}
+	return r
+}
+
+// Register 注册采集器，名称重复时 panic（与 http.Handle 相同，重名属于编程或配置校验错误）
+func (r *Registry) Register(c Collector) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.byName[c.Name()]; ok {
+		panic(fmt.Sprintf("monitor: 采集器 %q 重复注册", c.Name()))
+	}
+	r.byName[c.Name()] = c
+	r.collectors = append(r.collectors, c)
+}
+
+// Collectors 按注册顺序返回所有采集器
+func (r *Registry) Collectors() []Collector {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]Collector(nil), r.collectors...)
+}
+
+// Get 按名称查找采集器
+func (r *Registry) Get(name string) (Collector, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	c, ok := r.byName[name]
+	return c, ok
+}
+
+// 采集较慢或数据很少变化的内置子系统的采集间隔
+const (
+	// 服务、cgroup 和进程带宽每次采集都要采样 1 秒
+	slowCollectInterval = 10 * time.Second
+	// smartctl 会查询每块磁盘，数据以小时为单位变化
+	driveHealthInterval = 30 * time.Minute
+	// 证书采集函数自带缓存，按分钟读取即可及时拿到重新扫描的结果
+	certificatesInterval = time.Minute
+)
+
+// BuiltinOptions 内置采集器的设置，过滤条件在每次采集时读取（配置可在运行时修改）
+type BuiltinOptions struct {
+	DiskFilter    func() DiskFilter
+	NetworkFilter func() NetworkFilter
+	// 进程采集器保留的进程数
+	ProcessTop int
+	// 进程带宽采集器保留的进程数
+	NetTopCount int
+	// Certificates 返回证书扫描结果，应自带缓存（扫描需要与每个监听端口握手）；nil 时不注册 certificates 采集器
+	Certificates func(context.Context) CertificatesInfo
+}
+
+// BuiltinCollectors 返回内置子系统的采集器
+func BuiltinCollectors(opts BuiltinOptions) []Collector {
+	collectors := []Collector{
+		NewCollector("system", 0, GetSystemInfo, systemMetrics),
+		NewCollector("cpu", 0, GetCPUInfo, cpuMetrics),
+		NewCollector("memory", 0, GetMemoryInfo, memoryMetrics),
+		NewCollector("pressure", 0, GetPressureInfo, pressureMetrics),
+		NewCollector("disk", 0, func(ctx context.Context) DiskInfo {
+			return GetDiskInfo(ctx, opts.DiskFilter())
+		}, diskMetrics),
+		NewCollector("network", 0, func(ctx context.Context) NetworkInfo {
+			return GetNetworkInfo(ctx, opts.NetworkFilter())
+		}, networkMetrics),
+		NewCollector("ports", 0, GetPortInfo, portMetrics),
+		NewCollector("docker", 0, GetDockerInfo, dockerMetrics),
+		NewCollector("process", 0, func(ctx context.Context) ProcessInfo {
+			return GetProcessInfo(ctx, opts.ProcessTop)
+		}, processMetrics),
+		NewCollector("sensors", 0, GetSensorsInfo, sensorsMetrics),
+		NewCollector("protocols", 0, GetProtocolStats, protocolMetrics),
+		NewCollector("cgroup", slowCollectInterval, GetCgroupInfo, cgroupMetrics),
+		NewCollector("services", slowCollectInterval, GetServicesInfo, servicesMetrics),
+		NewCollector("nettop", slowCollectInterval, func(ctx context.Context) ProcessNetworkInfo {
+			return GetProcessNetworkInfo(ctx, opts.NetTopCount)
+		}, netTopMetrics),
+		NewCollector("disk_health", driveHealthInterval, GetDriveHealth, driveHealthMetrics),
+	}
+	if opts.Certificates != nil {
+		collectors = append(collectors, NewCollector("certificates", certificatesInterval, opts.Certificates, certificateMetrics))
+	}
+	return collectors
+}
+
+// gauge 创建 gauge 指标，labels 为键值对
+func gauge(name, help string, value float64, labels ...string) Metric {
+	return Metric{Name: name, Type: MetricGauge, Help: help, Labels: labelMap(labels), Value: value}
+}
+
+// counter 创建 counter 指标，labels 为键值对
+func counter(name, help string, value float64, labels ...string) Metric {
+	return Metric{Name: name, Type: MetricCounter, Help: help, Labels: labelMap(labels), Value: value}
+}
+
+func labelMap(pairs []string) map[string]string {
+	if len(pairs) == 0 {
+		return nil
+	}
+	labels := make(map[string]string, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		labels[pairs[i]] = pairs[i+1]
+	}
+	return labels
+}
+
+func systemMetrics(info SystemInfo) []Metric {
+	return []Metric{
+		gauge("syspulse_uptime_seconds", "系统运行时长", float64(info.Uptime)),
+	}
+}
+
+func cpuMetrics(info CPUInfo) []Metric {
+	return []Metric{
+		gauge("syspulse_cpu_usage_percent", "CPU 使用率", info.UsagePercent),
+		gauge("syspulse_cpu_cores", "逻辑核心数", float64(info.CoreCount)),
+		gauge("syspulse_load1", "1 分钟平均负载", info.LoadAvg1),
+		gauge("syspulse_load5", "5 分钟平均负载", info.LoadAvg5),
+		gauge("syspulse_load15", "15 分钟平均负载", info.LoadAvg15),
+	}
+}
+
+func memoryMetrics(info MemoryInfo) []Metric {
+	return []Metric{
+		gauge("syspulse_memory_total_bytes", "物理内存总量", float64(info.Total)),
+		gauge("syspulse_memory_used_bytes", "已用内存", float64(info.Used)),
+		gauge("syspulse_memory_available_bytes", "可用内存", float64(info.Available)),
+		gauge("syspulse_swap_total_bytes", "交换空间总量", float64(info.SwapTotal)),
+		gauge("syspulse_swap_used_bytes", "已用交换空间", float64(info.SwapUsed)),
+	}
+}
+
+func pressureMetrics(info PressureInfo) []Metric {
+	if !info.Available {
+		return nil
+	}
+	var metrics []Metric
+	for _, r := range []struct {
+		name string
+		res  PressureResource
+	}{{"cpu", info.CPU}, {"memory", info.Memory}, {"io", info.IO}} {
+		if !r.res.Available {
+			continue
+		}
+		metrics = append(metrics,
+			gauge("syspulse_pressure_some_avg10", "部分任务停顿的时间占比（10 秒平均）", r.res.Some.Avg10, "resource", r.name),
+			gauge("syspulse_pressure_full_avg10", "所有任务停顿的时间占比（10 秒平均）", r.res.Full.Avg10, "resource", r.name),
+		)
+	}
+	return metrics
+}
+
+func diskMetrics(info DiskInfo) []Metric {
+	var metrics []Metric
+	for _, p := range info.Partitions {
+		labels := []string{"device", p.Device, "mountpoint", p.Mountpoint, "fstype", p.Fstype}
+		metrics = append(metrics,
+			gauge("syspulse_filesystem_size_bytes", "文件系统容量", float64(p.Total), labels...),
+			gauge("syspulse_filesystem_used_bytes", "文件系统已用空间", float64(p.Used), labels...),
+			gauge("syspulse_filesystem_free_bytes", "文件系统可用空间", float64(p.Free), labels...),
+		)
+	}
+	return metrics
+}
+
+func networkMetrics(info NetworkInfo) []Metric {
+	var metrics []Metric
+	for _, iface := range info.Interfaces {
+		metrics = append(metrics,
+			counter("syspulse_network_receive_bytes_total", "接收的字节数", float64(iface.BytesRecv), "interface", iface.Name),
+			counter("syspulse_network_transmit_bytes_total", "发送的字节数", float64(iface.BytesSent), "interface", iface.Name),
+		)
+	}
+	return metrics
+}
+
+func portMetrics(info PortInfo) []Metric {
+	return []Metric{
+		gauge("syspulse_listening_ports", "监听的端口数", float64(len(info.Listening))),
+	}
+}
+
+func processMetrics(info ProcessInfo) []Metric {
+	return []Metric{
+		gauge("syspulse_processes", "进程总数", float64(info.TotalProcesses)),
+	}
+}
+
+func dockerMetrics(info DockerInfo) []Metric {
+	if !info.Available {
+		return nil
+	}
+	metrics := []Metric{
+		gauge("syspulse_containers_running", "运行中的容器数", float64(info.RunningCount)),
+		gauge("syspulse_containers", "容器总数", float64(info.TotalCount)),
+	}
+	for _, c := range info.Containers {
+		if c.State != "running" {
+			continue
+		}
+		metrics = append(metrics,
+			gauge("syspulse_container_cpu_percent", "容器 CPU 使用率", c.CPUPercent, "name", c.Name),
+			gauge("syspulse_container_memory_bytes", "容器内存使用量", c.MemoryUsageMB*1024*1024, "name", c.Name),
+		)
+	}
+	return metrics
+}
+
+func sensorsMetrics(info SensorsInfo) []Metric {
+	// 同型号的设备（如两块 NVMe 磁盘）芯片名和标签相同，第二个起芯片名加上序号
+	seen := make(map[string]int)
+	chip := func(kind, chip, label string) string {
+		key := kind + "\x00" + chip + "\x00" + label
+		seen[key]++
+		if n := seen[key]; n > 1 {
+			return fmt.Sprintf("%s#%d", chip, n)
+		}
+		return chip
+	}
+
+	var metrics []Metric
+	for _, t := range info.Temperatures {
+		metrics = append(metrics, gauge("syspulse_temperature_celsius", "温度", t.Celsius, "chip", chip("temp", t.Chip, t.Label), "sensor", t.Label))
+	}
+	for _, f := range info.Fans {
+		metrics = append(metrics, gauge("syspulse_fan_rpm", "风扇转速", float64(f.RPM), "chip", chip("fan", f.Chip, f.Label), "fan", f.Label))
+	}
+	for _, p := range info.Power {
+		metrics = append(metrics, gauge("syspulse_power_watts", "RAPL 功耗", p.Watts, "domain", p.Domain))
+	}
+	return metrics
+}
+
+func protocolMetrics(info ProtocolStats) []Metric {
+	if !info.Available {
+		return nil
+	}
+	tcp, udp := info.TCP, info.UDP
+	metrics := []Metric{
+		gauge("syspulse_tcp_established", "已建立的 TCP 连接数", float64(tcp.CurrEstab)),
+		counter("syspulse_tcp_retransmitted_segments_total", "TCP 重传的段数", float64(tcp.RetransSegs.Total)),
+		counter("syspulse_tcp_sent_segments_total", "TCP 发送的段数", float64(tcp.OutSegs.Total)),
+		counter("syspulse_tcp_listen_overflows_total", "accept 队列溢出次数", float64(tcp.ListenOverflows.Total)),
+		counter("syspulse_tcp_syn_backlog_drops_total", "SYN 队列满丢弃的连接数", float64(tcp.SynBacklogDrops.Total)),
+		counter("syspulse_udp_receive_buffer_errors_total", "UDP 接收缓冲区满丢包数", float64(udp.RcvbufErrors.Total)),
+		counter("syspulse_udp_no_ports_total", "发往未监听端口的 UDP 报文数", float64(udp.NoPorts.Total)),
+	}
+	if ct := info.Conntrack; ct.Available {
+		metrics = append(metrics,
+			gauge("syspulse_conntrack_entries", "连接跟踪表条目数", float64(ct.Count)),
+			gauge("syspulse_conntrack_entries_limit", "连接跟踪表上限", float64(ct.Max)),
+			counter("syspulse_conntrack_drops_total", "连接跟踪表满丢弃的连接数", float64(ct.Drop.Total)),
+		)
+	}
+	return metrics
+}
+
+// cgroupMetrics 只导出 slice 和其下一层（通常是服务），避免会话 scope 等产生过多序列
+func cgroupMetrics(info CgroupInfo) []Metric {
+	if !info.Available {
+		return nil
+	}
+	var metrics []Metric
+	var walk func(node CgroupNode)
+	walk = func(node CgroupNode) {
+		if node.Depth > 2 {
+			return
+		}
+		if node.Depth > 0 {
+			metrics = append(metrics,
+				gauge("syspulse_cgroup_cpu_percent", "cgroup CPU 使用率（100 为一个核心）", node.CPU.UsagePercent, "path", node.Path),
+				gauge("syspulse_cgroup_memory_bytes", "cgroup 内存使用量", float64(node.Memory.Current), "path", node.Path),
+				counter("syspulse_cgroup_oom_kills_total", "cgroup 内被 OOM killer 终止的进程数", float64(node.Memory.Events.OOMKill), "path", node.Path),
+			)
+		}
+		for _, child := range node.Children {
+			walk(child)
+		}
+	}
+	walk(info.Tree)
+	return metrics
+}
+
+func servicesMetrics(info ServicesInfo) []Metric {
+	if !info.Available {
+		return nil
+	}
+	metrics := []Metric{
+		gauge("syspulse_services", "systemd 服务数", float64(info.TotalCount), "state", "total"),
+		gauge("syspulse_services", "systemd 服务数", float64(info.ActiveCount), "state", "active"),
+		gauge("syspulse_services", "systemd 服务数", float64(info.FailedCount), "state", "failed"),
+		gauge("syspulse_services", "systemd 服务数", float64(info.FlappingCount), "state", "flapping"),
+	}
+	// 只导出有问题的服务，正常的服务数量可能很多
+	for _, s := range info.Services {
+		if s.Failed || s.Flapping {
+			metrics = append(metrics,
+				gauge("syspulse_service_failed", "服务处于失败状态", boolValue(s.Failed), "name", s.Name),
+				gauge("syspulse_service_recent_restarts", "服务最近 10 分钟内的重启次数", float64(s.RecentRestarts), "name", s.Name),
+			)
+		}
+	}
+	return metrics
+}
+
+func netTopMetrics(info ProcessNetworkInfo) []Metric {
+	if !info.Available {
+		return nil
+	}
+	metrics := []Metric{
+		gauge("syspulse_unattributed_network_send_bytes_per_second", "无法归属到进程的 TCP 发送带宽", info.UnattributedSendPerSec),
+		gauge("syspulse_unattributed_network_receive_bytes_per_second", "无法归属到进程的 TCP 接收带宽", info.UnattributedRecvPerSec),
+	}
+	// 进程的 PID 会变化，按容器导出
+	for _, c := range info.Containers {
+		metrics = append(metrics,
+			gauge("syspulse_container_network_send_bytes_per_second", "容器的 TCP 发送带宽", c.SendBytesPerSec, "name", c.Name),
+			gauge("syspulse_container_network_receive_bytes_per_second", "容器的 TCP 接收带宽", c.RecvBytesPerSec, "name", c.Name),
+		)
+	}
+	return metrics
+}
+
+func driveHealthMetrics(info DriveHealthInfo) []Metric {
+	var metrics []Metric
+	for _, d := range info.Drives {
+		if !d.Supported {
+			continue
+		}
+		labels := []string{"device", d.Device, "model", d.Model}
+		metrics = append(metrics,
+			gauge("syspulse_drive_smart_passed", "SMART 整体自检是否通过", boolValue(d.Passed), labels...),
+			gauge("syspulse_drive_temperature_celsius", "磁盘温度", d.Temperature, labels...),
+			gauge("syspulse_drive_media_errors", "介质错误数", float64(d.MediaErrors), labels...),
+			gauge("syspulse_drive_reallocated_sectors", "重映射扇区数", float64(d.ReallocatedSectors), labels...),
+		)
+		if d.WearKnown {
+			metrics = append(metrics, gauge("syspulse_drive_percent_used", "已使用寿命百分比", d.PercentUsed, labels...))
+		}
+	}
+	return metrics
+}
+
+func certificateMetrics(info CertificatesInfo) []Metric {
+	var metrics []Metric
+	for _, c := range info.Certificates {
+		metrics = append(metrics, gauge("syspulse_certificate_expiry_days", "证书剩余有效天数", c.DaysLeft,
+			"address", c.Address, "subject", c.Subject))
+	}
+	return metrics
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
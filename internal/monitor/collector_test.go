@@ -0,0 +1,103 @@
+package monitor
+
+import (
+	"context"
+	"testing"
+)
+
+func TestBuiltinCollectorsCertificates(t *testing.T) {
+	names := func(collectors []Collector) map[string]bool {
+		set := make(map[string]bool)
+		for _, c := range collectors {
+			if set[c.Name()] {
+				t.Errorf("duplicate collector %q", c.Name())
+			}
+			set[c.Name()] = true
+		}
+		return set
+	}
+
+	without := names(BuiltinCollectors(BuiltinOptions{}))
+	if without["certificates"] {
+		t.Error("certificates registered without a scan function")
+	}
+
+	scan := func(ctx context.Context) CertificatesInfo { return CertificatesInfo{} }
+	with := names(BuiltinCollectors(BuiltinOptions{Certificates: scan}))
+	for _, name := range []string{"sensors", "protocols", "cgroup", "services", "nettop", "disk_health", "certificates"} {
+		if !with[name] {
+			t.Errorf("collector %q not registered", name)
+		}
+	}
+}
+
+func TestBuiltinMetricsUniqueSeries(t *testing.T) {
+	tests := []struct {
+		name    string
+		metrics []Metric
+		want    int
+	}{
+		{
+			name: "sensors with identical chips",
+			metrics: sensorsMetrics(SensorsInfo{
+				Temperatures: []TemperatureSensor{
+					{Chip: "nvme", Label: "Composite", Celsius: 40},
+					{Chip: "nvme", Label: "Composite", Celsius: 45},
+					{Chip: "coretemp", Label: "Package id 0", Celsius: 55},
+				},
+				Fans:  []FanSensor{{Chip: "nct6775", Label: "fan1", RPM: 900}},
+				Power: []PowerSensor{{Domain: "package-0", Watts: 12.5}},
+			}),
+			want: 5,
+		},
+		{
+			name: "cgroup slices and services",
+			metrics: cgroupMetrics(CgroupInfo{Available: true, Tree: CgroupNode{Path: "/", Children: []CgroupNode{
+				{Path: "/system.slice", Depth: 1, Children: []CgroupNode{
+					{Path: "/system.slice/ssh.service", Depth: 2, Children: []CgroupNode{
+						{Path: "/system.slice/ssh.service/child", Depth: 3},
+					}},
+				}},
+			}}}),
+			want: 6, // 深度 3 的节点不导出
+		},
+		{
+			name: "services",
+			metrics: servicesMetrics(ServicesInfo{Available: true, TotalCount: 3, Services: []ServiceInfo{
+				{Name: "db.service", Failed: true},
+				{Name: "app.service", Flapping: true, RecentRestarts: 4},
+				{Name: "ok.service"},
+			}}),
+			want: 8,
+		},
+		{
+			name: "drive health",
+			metrics: driveHealthMetrics(DriveHealthInfo{Available: true, Drives: []DriveHealth{
+				{Device: "/dev/nvme0n1", Supported: true, Passed: true, WearKnown: true},
+				{Device: "/dev/sda", Supported: true},
+				{Device: "/dev/sdb"},
+			}}),
+			want: 9,
+		},
+		{
+			name:    "unavailable subsystems",
+			metrics: append(append(protocolMetrics(ProtocolStats{}), netTopMetrics(ProcessNetworkInfo{})...), servicesMetrics(ServicesInfo{})...),
+			want:    0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seen := make(map[string]bool)
+			for _, m := range tt.metrics {
+				if seen[m.Series()] {
+					t.Errorf("duplicate series %s", m.Series())
+				}
+				seen[m.Series()] = true
+			}
+			if len(tt.metrics) != tt.want {
+				t.Errorf("got %d series, want %d", len(tt.metrics), tt.want)
+			}
+		})
+	}
+}
@@ -1,45 +1,203 @@
 package monitor
 
 import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/shirou/gopsutil/v3/cpu"
 	"github.com/shirou/gopsutil/v3/load"
 )
 
+// cpuSampleInterval CPU 时间采样间隔
+const cpuSampleInterval = time.Second
+
 // GetCPUInfo 获取 CPU 信息
-func GetCPUInfo() CPUInfo {
-	// CPU 使用率
-	percent, _ := cpu.Percent(time.Second, false)
+func GetCPUInfo(ctx context.Context) CPUInfo {
+	var errs FieldErrors
+
+	// 两次采样 CPU 时间和 /proc/stat 计数器
+	totalBefore, totalErr := cpu.TimesWithContext(ctx, false)
+	perCoreBefore, perCoreErr := cpu.TimesWithContext(ctx, true)
+	statBefore := readProcStatCounters(filepath.Join(DefaultProcRoot, "stat"))
+	start := time.Now()
+
+	// 采样被打断（超时）时不计算使用率和速率，其余字段照常读取
+	var totalAfter, perCoreAfter []cpu.TimesStat
+	statAfter := statBefore
+	if err := sleepContext(ctx, cpuSampleInterval); err != nil {
+		totalErr = err
+	} else {
+		var err error
+		totalAfter, err = cpu.TimesWithContext(ctx, false)
+		if totalErr == nil {
+			totalErr = err
+		}
+		perCoreAfter, err = cpu.TimesWithContext(ctx, true)
+		if perCoreErr == nil {
+			perCoreErr = err
+		}
+		statAfter = readProcStatCounters(filepath.Join(DefaultProcRoot, "stat"))
+	}
+	errs.add("UsagePercent", totalErr)
+	errs.add("PerCoreUsage", perCoreErr)
+	elapsed := time.Since(start).Seconds()
+
+	// 各模式占比和总体使用率
+	var modes CPUModePercent
 	cpuPercent := 0.0
-	if len(percent) > 0 {
-		cpuPercent = percent[0]
+	if len(totalBefore) > 0 && len(totalAfter) > 0 {
+		modes = cpuModeDelta(totalBefore[0], totalAfter[0])
+		cpuPercent = 100 - modes.Idle - modes.IOWait
 	}
 
 	// 每个核心的使用率
-	perCorePercent, _ := cpu.Percent(time.Second, true)
+	var perCorePercent []float64
+	for i := 0; i < len(perCoreBefore) && i < len(perCoreAfter); i++ {
+		coreModes := cpuModeDelta(perCoreBefore[i], perCoreAfter[i])
+		perCorePercent = append(perCorePercent, 100-coreModes.Idle-coreModes.IOWait)
+	}
+
+	// 上下文切换和中断速率
+	var ctxtRate, intrRate float64
+	if elapsed > 0 {
+		if statAfter["ctxt"] >= statBefore["ctxt"] {
+			ctxtRate = float64(statAfter["ctxt"]-statBefore["ctxt"]) / elapsed
+		}
+		if statAfter["intr"] >= statBefore["intr"] {
+			intrRate = float64(statAfter["intr"]-statBefore["intr"]) / elapsed
+		}
+	}
 
 	// CPU 核心数
-	coreCount, _ := cpu.Counts(true)
+	coreCount, err := cpu.CountsWithContext(ctx, true)
+	errs.add("CoreCount", err)
 
 	// CPU 信息
-	cpuInfos, _ := cpu.Info()
+	cpuInfos, err := cpu.InfoWithContext(ctx)
+	errs.add("ModelName", err)
 	modelName := "Unknown"
 	if len(cpuInfos) > 0 {
 		modelName = cpuInfos[0].ModelName
 	}
 
 	// 负载平均值
-	loadAvg, _ := load.Avg()
+	loadAvg, err := load.AvgWithContext(ctx)
+	if err != nil || loadAvg == nil {
+		errs.add("LoadAvg1", err)
+		loadAvg = &load.AvgStat{}
+	}
+
+	// CPU 温度（不可用时为 0）
+	temperature := readCPUTemperature(DefaultSysRoot)
+
+	// CPU 压力
+	pressure := readPressureFile(filepath.Join(DefaultPressureDir, "cpu"))
 
 	return CPUInfo{
-		UsagePercent: cpuPercent,
-		CoreCount:    coreCount,
-		ModelName:    modelName,
-		LoadAvg1:     loadAvg.Load1,
-		LoadAvg5:     loadAvg.Load5,
-		LoadAvg15:    loadAvg.Load15,
-		PerCoreUsage: perCorePercent,
-		Timestamp:    time.Now(),
+		UsagePercent:          cpuPercent,
+		CoreCount:             coreCount,
+		ModelName:             modelName,
+		LoadAvg1:              loadAvg.Load1,
+		LoadAvg5:              loadAvg.Load5,
+		LoadAvg15:             loadAvg.Load15,
+		PerCoreUsage:          perCorePercent,
+		Modes:                 modes,
+		Frequencies:           readCPUFrequencies(DefaultSysRoot, cpuInfos),
+		ContextSwitchesPerSec: ctxtRate,
+		InterruptsPerSec:      intrRate,
+		Temperature:           temperature,
+		Pressure:              pressure,
+		Errors:                errs,
+		Timestamp:             time.Now(),
+	}
+}
+
+// cpuModeDelta 根据两次 CPU 时间采样计算各模式占比
+// guest 时间已计入 user，因此不参与总时间的计算
+func cpuModeDelta(before, after cpu.TimesStat) CPUModePercent {
+	total := cpuTimesTotal(after) - cpuTimesTotal(before)
+	if total <= 0 {
+		return CPUModePercent{}
+	}
+
+	percent := func(a, b float64) float64 {
+		delta := a - b
+		if delta < 0 {
+			return 0
+		}
+		return delta / total * 100
+	}
+
+	return CPUModePercent{
+		User:    percent(after.User, before.User),
+		Nice:    percent(after.Nice, before.Nice),
+		System:  percent(after.System, before.System),
+		Idle:    percent(after.Idle, before.Idle),
+		IOWait:  percent(after.Iowait, before.Iowait),
+		IRQ:     percent(after.Irq, before.Irq),
+		SoftIRQ: percent(after.Softirq, before.Softirq),
+		Steal:   percent(after.Steal, before.Steal),
+		Guest:   percent(after.Guest+after.GuestNice, before.Guest+before.GuestNice),
+	}
+}
+
+func cpuTimesTotal(t cpu.TimesStat) float64 {
+	return t.User + t.Nice + t.System + t.Idle + t.Iowait + t.Irq + t.Softirq + t.Steal
+}
+
+// readProcStatCounters 读取 /proc/stat 中的 ctxt 和 intr 总数
+func readProcStatCounters(path string) map[string]uint64 {
+	counters := make(map[string]uint64)
+
+	file, err := os.Open(path)
+	if err != nil {
+		return counters
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	// intr 行可能很长
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || (fields[0] != "ctxt" && fields[0] != "intr") {
+			continue
+		}
+		if n, err := strconv.ParseUint(fields[1], 10, 64); err == nil {
+			counters[fields[0]] = n
+		}
 	}
+
+	return counters
+}
+
+// readCPUFrequencies 读取每个核心的当前、最小、最大频率
+// 优先使用 cpufreq，缺失时（如虚拟机）回退到 /proc/cpuinfo 中的 MHz
+func readCPUFrequencies(sysRoot string, cpuInfos []cpu.InfoStat) []CPUFrequency {
+	var freqs []CPUFrequency
+
+	for i, info := range cpuInfos {
+		dir := filepath.Join(sysRoot, "devices", "system", "cpu", fmt.Sprintf("cpu%d", i), "cpufreq")
+
+		freq := CPUFrequency{Core: i, CurrentMHz: info.Mhz}
+		if v, ok := readSysInt(filepath.Join(dir, "scaling_cur_freq")); ok {
+			freq.CurrentMHz = float64(v) / 1000
+		}
+		if v, ok := readSysInt(filepath.Join(dir, "cpuinfo_min_freq")); ok {
+			freq.MinMHz = float64(v) / 1000
+		}
+		if v, ok := readSysInt(filepath.Join(dir, "cpuinfo_max_freq")); ok {
+			freq.MaxMHz = float64(v) / 1000
+		}
+
+		freqs = append(freqs, freq)
+	}
+
+	return freqs
 }
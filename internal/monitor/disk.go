@@ -1,21 +1,65 @@
 package monitor
 
 import (
+	"context"
+	"path/filepath"
+	"sync"
+	"syscall"
 	"time"
 
 	"github.com/shirou/gopsutil/v3/disk"
 )
 
-// GetDiskInfo 获取磁盘信息（显示所有挂载点，类似 df -h）
-func GetDiskInfo() DiskInfo {
+// 磁盘增长率计算窗口
+const (
+	diskGrowthWindow = 30 * time.Minute
+	// DiskGrowthMinSamples 计算增长率和写满预计时间所需的最少采样次数
+	DiskGrowthMinSamples = 3
+)
+
+// diskSample 某个挂载点在某一时刻的已用空间
+type diskSample struct {
+	at   time.Time
+	used uint64
+}
+
+// diskHistory 记录每个挂载点最近的使用量和读写状态
+var diskHistory = struct {
+	sync.Mutex
+	samples  map[string][]diskSample
+	readOnly map[string]bool
+}{
+	samples:  make(map[string][]diskSample),
+	readOnly: make(map[string]bool),
+}
+
+// GetDiskInfo 获取磁盘信息（显示满足过滤条件的挂载点，类似 df -h）
+func GetDiskInfo(ctx context.Context, filter DiskFilter) DiskInfo {
+	var errs FieldErrors
+
 	// true 表示包括所有文件系统，包括 tmpfs、devtmpfs、overlay 等
-	partitions, _ := disk.Partitions(true)
+	partitions, err := disk.PartitionsWithContext(ctx, true)
+	errs.add("Partitions", err)
 
 	var partitionInfos []PartitionInfo
+	seen := make(map[uint64]int) // 文件系统设备号 -> partitionInfos 下标
 
 	for _, partition := range partitions {
-		usage, err := disk.Usage(partition.Mountpoint)
+		if !filter.Match(partition.Device, partition.Mountpoint, partition.Fstype) {
+			continue
+		}
+		// 超时后返回已读取的分区
+		if err := ctx.Err(); err != nil {
+			errs.add("Partitions", err)
+			break
+		}
+
+		usage, err := disk.UsageWithContext(ctx, partition.Mountpoint)
 		if err != nil {
+			// 没有权限访问的挂载点需要提示，其他错误（挂载点已卸载等）直接跳过
+			if errorKind(err) == ErrorPermission {
+				errs.addf("Partitions", ErrorPermission, "%s: %v", partition.Mountpoint, err)
+			}
 			continue
 		}
 
@@ -25,15 +69,47 @@ func GetDiskInfo() DiskInfo {
 			continue
 		}
 
-		partitionInfos = append(partitionInfos, PartitionInfo{
-			Device:      partition.Device,
-			Mountpoint:  partition.Mountpoint,
-			Fstype:      partition.Fstype,
-			Total:       usage.Total,
-			Used:        usage.Used,
-			Free:        usage.Free,
-			UsedPercent: usage.UsedPercent,
-		})
+		info := PartitionInfo{
+			Device:            partition.Device,
+			Mountpoint:        partition.Mountpoint,
+			Fstype:            partition.Fstype,
+			Options:           partition.Opts,
+			Total:             usage.Total,
+			Used:              usage.Used,
+			Free:              usage.Free,
+			UsedPercent:       usage.UsedPercent,
+			InodesTotal:       usage.InodesTotal,
+			InodesUsed:        usage.InodesUsed,
+			InodesFree:        usage.InodesFree,
+			InodesUsedPercent: usage.InodesUsedPercent,
+			ReadOnly:          hasMountOption(partition.Opts, "ro"),
+			NoExec:            hasMountOption(partition.Opts, "noexec"),
+		}
+
+		// 同一文件系统（相同设备号）的 bind mount 只保留路径最短的挂载点
+		if filter.DedupBindMounts {
+			var stat syscall.Stat_t
+			if err := syscall.Stat(partition.Mountpoint, &stat); err == nil {
+				dev := uint64(stat.Dev)
+				if i, ok := seen[dev]; ok {
+					if len(info.Mountpoint) < len(partitionInfos[i].Mountpoint) {
+						applyDiskHistory(&info)
+						partitionInfos[i] = info
+					}
+					continue
+				}
+				seen[dev] = len(partitionInfos)
+			}
+		}
+
+		applyDiskHistory(&info)
+
+		partitionInfos = append(partitionInfos, info)
+	}
+
+	// 完整读取了挂载表时，清理已卸载的挂载点（容器的 overlay 等挂载会不断变化）
+	if err == nil && ctx.Err() == nil {
+		pruneDiskHistory(partitionInfos)
 	}
 
 	// 按使用率降序排序（使用率高的在前）
@@ -41,10 +117,98 @@ func GetDiskInfo() DiskInfo {
 
 	return DiskInfo{
 		Partitions: partitionInfos,
+		Errors:     errs,
 		Timestamp:  time.Now(),
 	}
 }
 
+// applyDiskHistory 记录本次采样，并计算增长率、写满预计时间和只读重挂载状态
+func applyDiskHistory(info *PartitionInfo) {
+	diskHistory.Lock()
+	defer diskHistory.Unlock()
+
+	now := time.Now()
+	key := diskHistoryKey(*info)
+
+	// 之前是读写，现在变成只读：通常是文件系统出错后被内核重新挂载
+	// 首次采样时通过 ext4 的错误计数判断
+	wasReadOnly, seen := diskHistory.readOnly[key]
+	diskHistory.readOnly[key] = info.ReadOnly
+	if info.ReadOnly && ((seen && !wasReadOnly) || hasFilesystemErrors(DefaultSysRoot, info.Device, info.Fstype)) {
+		info.ReadOnlyRemount = true
+	}
+
+	// 只保留窗口内的样本
+	samples := append(diskHistory.samples[key], diskSample{at: now, used: info.Used})
+	for len(samples) > 0 && now.Sub(samples[0].at) > diskGrowthWindow {
+		samples = samples[1:]
+	}
+	diskHistory.samples[key] = samples
+
+	if len(samples) < DiskGrowthMinSamples {
+		return
+	}
+
+	oldest := samples[0]
+	elapsed := now.Sub(oldest.at).Seconds()
+	if elapsed <= 0 {
+		return
+	}
+
+	info.GrowthBytesPerSec = (float64(info.Used) - float64(oldest.used)) / elapsed
+	if info.GrowthBytesPerSec > 0 {
+		info.FillETASeconds = float64(info.Free) / info.GrowthBytesPerSec
+	}
+}
+
+// pruneDiskHistory 删除本次采集中没有出现的挂载点的记录
+func pruneDiskHistory(partitions []PartitionInfo) {
+	current := make(map[string]bool, len(partitions))
+	for _, p := range partitions {
+		current[diskHistoryKey(p)] = true
+	}
+
+	diskHistory.Lock()
+	defer diskHistory.Unlock()
+	for key := range diskHistory.samples {
+		if !current[key] {
+			delete(diskHistory.samples, key)
+		}
+	}
+	for key := range diskHistory.readOnly {
+		if !current[key] {
+			delete(diskHistory.readOnly, key)
+		}
+	}
+}
+
+// diskHistoryKey 返回挂载点在 diskHistory 中的键
+func diskHistoryKey(info PartitionInfo) string {
+	return info.Device + " " + info.Mountpoint
+}
+
+// hasFilesystemErrors 检查 ext4 在 sysfs 中记录的错误次数
+// sysfs 使用内核设备名，/dev/mapper/vg-root 等符号链接需要先解析为 dm-N
+func hasFilesystemErrors(sysRoot, device, fstype string) bool {
+	if fstype != "ext4" {
+		return false
+	}
+	if resolved, err := filepath.EvalSymlinks(device); err == nil {
+		device = resolved
+	}
+	count, ok := readSysInt(filepath.Join(sysRoot, "fs", "ext4", filepath.Base(device), "errors_count"))
+	return ok && count > 0
+}
+
+func hasMountOption(opts []string, option string) bool {
+	for _, opt := range opts {
+		if opt == option {
+			return true
+		}
+	}
+	return false
+}
+
 // sortDiskByUsage 按磁盘使用率降序排序
 func sortDiskByUsage(partitions []PartitionInfo) {
 	// 使用冒泡排序（简单实现）
@@ -0,0 +1,76 @@
+package monitor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestHasFilesystemErrors(t *testing.T) {
+	root := t.TempDir()
+	sysRoot := filepath.Join(root, "sys")
+	writeTestFiles(t, sysRoot, map[string]string{
+		"fs/ext4/sda1/errors_count": "0\n",
+		"fs/ext4/sdb1/errors_count": "3\n",
+		"fs/ext4/dm-2/errors_count": "1\n",
+	})
+
+	// /dev/mapper/vg-data -> ../dm-2，与 udev 创建的链接相同
+	dev := filepath.Join(root, "dev")
+	if err := os.MkdirAll(filepath.Join(dev, "mapper"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dev, "dm-2"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("../dm-2", filepath.Join(dev, "mapper", "vg-data")); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		device string
+		fstype string
+		want   bool
+	}{
+		{name: "clean", device: "/dev/sda1", fstype: "ext4", want: false},
+		{name: "errors", device: "/dev/sdb1", fstype: "ext4", want: true},
+		{name: "device mapper symlink", device: filepath.Join(dev, "mapper", "vg-data"), fstype: "ext4", want: true},
+		{name: "dm device", device: filepath.Join(dev, "dm-2"), fstype: "ext4", want: true},
+		{name: "unknown device", device: "/dev/sdz1", fstype: "ext4", want: false},
+		{name: "not ext4", device: "/dev/sdb1", fstype: "xfs", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hasFilesystemErrors(sysRoot, tt.device, tt.fstype); got != tt.want {
+				t.Errorf("hasFilesystemErrors(%s) = %v, want %v", tt.device, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPruneDiskHistory(t *testing.T) {
+	diskHistory.Lock()
+	diskHistory.samples = make(map[string][]diskSample)
+	diskHistory.readOnly = make(map[string]bool)
+	diskHistory.Unlock()
+
+	root := PartitionInfo{Device: "/dev/sda1", Mountpoint: "/", Fstype: "xfs", Used: 100}
+	overlay := PartitionInfo{Device: "overlay", Mountpoint: "/var/lib/docker/overlay2/abc/merged", Fstype: "overlay", Used: 10}
+	for _, p := range []PartitionInfo{root, overlay} {
+		applyDiskHistory(&p)
+	}
+
+	// 容器退出后 overlay 挂载消失
+	pruneDiskHistory([]PartitionInfo{root})
+
+	diskHistory.Lock()
+	defer diskHistory.Unlock()
+	if len(diskHistory.samples) != 1 || len(diskHistory.readOnly) != 1 {
+		t.Fatalf("history has %d sample and %d read-only entries, want 1 each", len(diskHistory.samples), len(diskHistory.readOnly))
+	}
+	if _, ok := diskHistory.samples[diskHistoryKey(root)]; !ok {
+		t.Error("history of mounted filesystem removed")
+	}
+}
@@ -3,40 +3,44 @@ package monitor
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/client"
+	"github.com/docker/docker/errdefs"
 )
 
 // GetDockerInfo 获取 Docker 信息
-func GetDockerInfo() DockerInfo {
-	ctx := context.Background()
-
+func GetDockerInfo(ctx context.Context) DockerInfo {
 	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
 	if err != nil {
-		return DockerInfo{Available: false, Timestamp: time.Now()}
+		return dockerUnavailable(err)
 	}
 	defer cli.Close()
 
 	// 测试连接
 	_, err = cli.Ping(ctx)
 	if err != nil {
-		return DockerInfo{Available: false, Timestamp: time.Now()}
+		return dockerUnavailable(err)
 	}
 
 	// 获取所有容器
 	containers, err := cli.ContainerList(ctx, types.ContainerListOptions{All: true})
 	if err != nil {
-		return DockerInfo{Available: false, Timestamp: time.Now()}
+		return dockerUnavailable(err)
 	}
 
 	var containerInfos []ContainerInfo
 	runningCount := 0
+	failures := newErrorCounter()
 
 	for _, ctr := range containers {
-		info := getContainerInfo(ctx, cli, ctr)
+		info, err := getContainerInfo(ctx, cli, ctr)
+		failures.add("Containers.CPUPercent", err)
 		containerInfos = append(containerInfos, info)
 
 		if info.State == "running" {
@@ -49,40 +53,107 @@ func GetDockerInfo() DockerInfo {
 		Containers:   containerInfos,
 		RunningCount: runningCount,
 		TotalCount:   len(containers),
+		Errors:       failures.errors("容器的统计信息"),
 		Timestamp:    time.Now(),
 	}
 }
 
-// GetContainerDetail 获取特定容器的详细信息
-func GetContainerDetail(containerID string) ContainerInfo {
-	ctx := context.Background()
+// dockerUnavailable 连接 Docker 失败时的结果，错误中说明原因（如没有访问 docker.sock 的权限）
+func dockerUnavailable(err error) DockerInfo {
+	info := DockerInfo{Available: false, Timestamp: time.Now()}
+	kind := errorKind(err)
+	if kind == ErrorOther {
+		kind = ErrorUnavailable
+	}
+	info.Errors.addf("Containers", kind, "%v", err)
+	return info
+}
+
+// Docker 相关的错误
+var (
+	ErrDockerUnavailable = errors.New("Docker 不可用或未运行")
+	ErrContainerNotFound = errors.New("容器不存在")
+)
 
+// GetContainerDetail 获取特定容器的详细信息（找不到时返回空的 ContainerInfo）
+func GetContainerDetail(ctx context.Context, containerID string) ContainerInfo {
+	info, _ := FindContainer(ctx, containerID)
+	return info
+}
+
+// FindContainer 按完整 ID、短 ID 或名称查找容器
+// Docker 不可用时返回 ErrDockerUnavailable，找不到时返回 ErrContainerNotFound
+// ctx 超时时返回 ctx 的错误
+func FindContainer(ctx context.Context, containerID string) (ContainerInfo, error) {
 	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
 	if err != nil {
-		return ContainerInfo{}
+		return ContainerInfo{}, ErrDockerUnavailable
 	}
 	defer cli.Close()
 
 	// 获取容器列表
 	containers, err := cli.ContainerList(ctx, types.ContainerListOptions{All: true})
 	if err != nil {
-		return ContainerInfo{}
+		if ctx.Err() != nil {
+			return ContainerInfo{}, ctx.Err()
+		}
+		return ContainerInfo{}, ErrDockerUnavailable
 	}
 
 	for _, ctr := range containers {
-		if ctr.ID == containerID || ctr.ID[:12] == containerID {
-			return getContainerInfo(ctx, cli, ctr)
+		if ctr.ID == containerID || (len(containerID) >= 12 && strings.HasPrefix(ctr.ID, containerID)) {
+			return containerDetail(ctx, cli, ctr), nil
 		}
+		for _, name := range ctr.Names {
+			if strings.TrimPrefix(name, "/") == containerID {
+				return containerDetail(ctx, cli, ctr), nil
+			}
+		}
+	}
+
+	return ContainerInfo{}, ErrContainerNotFound
+}
+
+// containerStopTimeout 重启容器时等待其退出的秒数，超时后强制终止
+const containerStopTimeout = 10
+
+// RestartContainer 重启指定容器（ID 或名称）
+func RestartContainer(ctx context.Context, containerID string) error {
+	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	if err != nil {
+		return ErrDockerUnavailable
 	}
+	defer cli.Close()
 
-	return ContainerInfo{}
+	timeout := containerStopTimeout
+	ctx, cancel := context.WithTimeout(ctx, (containerStopTimeout+20)*time.Second)
+	defer cancel()
+	err = cli.ContainerRestart(ctx, containerID, container.StopOptions{Timeout: &timeout})
+	switch {
+	case errdefs.IsNotFound(err):
+		return ErrContainerNotFound
+	case client.IsErrConnectionFailed(err):
+		return ErrDockerUnavailable
+	}
+	return err
 }
 
-func getContainerInfo(ctx context.Context, cli *client.Client, ctr types.Container) ContainerInfo {
+// containerDetail 容器详情（统计信息读取失败时对应字段为 0）
+func containerDetail(ctx context.Context, cli *client.Client, ctr types.Container) ContainerInfo {
+	info, _ := getContainerInfo(ctx, cli, ctr)
+	return info
+}
+
+// getContainerInfo 转换容器信息，返回的错误为读取运行中容器统计信息的错误
+func getContainerInfo(ctx context.Context, cli *client.Client, ctr types.Container) (ContainerInfo, error) {
 	// 获取容器名称（去掉前导 /）
-	name := ctr.Names[0]
-	if len(name) > 0 && name[0] == '/' {
-		name = name[1:]
+	name := ""
+	if len(ctr.Names) > 0 {
+		name = strings.TrimPrefix(ctr.Names[0], "/")
+	}
+	id := ctr.ID
+	if len(id) > 12 {
+		id = id[:12]
 	}
 
 	// 计算运行时间
@@ -102,7 +173,7 @@ func getContainerInfo(ctx context.Context, cli *client.Client, ctr types.Contain
 	}
 
 	info := ContainerInfo{
-		ID:      ctr.ID[:12],
+		ID:      id,
 		Name:    name,
 		Image:   ctr.Image,
 		Status:  ctr.Status,
@@ -113,13 +184,17 @@ func getContainerInfo(ctx context.Context, cli *client.Client, ctr types.Contain
 	}
 
 	// 如果容器正在运行，获取统计信息
+	var statsErr error
 	if ctr.State == "running" {
 		stats, err := cli.ContainerStats(ctx, ctr.ID, false)
+		statsErr = err
 		if err == nil {
 			defer stats.Body.Close()
 
 			var v types.StatsJSON
-			if err := json.NewDecoder(stats.Body).Decode(&v); err == nil {
+			if err := json.NewDecoder(stats.Body).Decode(&v); err != nil {
+				statsErr = fmt.Errorf("解析 %s 的统计信息失败: %w", name, err)
+			} else {
 				// CPU 使用率
 				cpuDelta := float64(v.CPUStats.CPUUsage.TotalUsage - v.PreCPUStats.CPUUsage.TotalUsage)
 				systemDelta := float64(v.CPUStats.SystemUsage - v.PreCPUStats.SystemUsage)
@@ -154,7 +229,7 @@ func getContainerInfo(ctx context.Context, cli *client.Client, ctr types.Contain
 		}
 	}
 
-	return info
+	return info, statsErr
 }
 
 func formatUptime(d time.Duration) string {
@@ -170,3 +245,27 @@ func formatUptime(d time.Duration) string {
 		return fmt.Sprintf("%dm", minutes)
 	}
 }
+
+// dockerContainerNames 返回容器完整 ID 到名称的映射（Docker 不可用时返回空）
+func dockerContainerNames(ctx context.Context) map[string]string {
+	names := make(map[string]string)
+
+	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	if err != nil {
+		return names
+	}
+	defer cli.Close()
+
+	containers, err := cli.ContainerList(ctx, types.ContainerListOptions{})
+	if err != nil {
+		return names
+	}
+
+	for _, ctr := range containers {
+		if len(ctr.Names) > 0 {
+			names[ctr.ID] = strings.TrimPrefix(ctr.Names[0], "/")
+		}
+	}
+
+	return names
+}
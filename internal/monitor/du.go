@@ -0,0 +1,248 @@
+package monitor
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// DirUsageOptions 目录占用扫描选项
+type DirUsageOptions struct {
+	// MaxDepth 结果树保留的最大深度（更深的目录大小计入上级）
+	MaxDepth int
+	// TopN 每层保留的子目录数量，以及最大文件列表的长度
+	TopN int
+	// Timeout 扫描超时时间，超时后返回已扫描的部分结果
+	Timeout time.Duration
+	// Workers 并发扫描的目录数，0 表示使用 CPU 核心数的 4 倍
+	Workers int
+}
+
+// DefaultDirUsageOptions 默认扫描选项
+func DefaultDirUsageOptions() DirUsageOptions {
+	return DirUsageOptions{
+		MaxDepth: 3,
+		TopN:     10,
+		Timeout:  30 * time.Second,
+	}
+}
+
+// GetDirUsage 并发扫描目录树（不跨越文件系统），返回最大的目录和文件
+func GetDirUsage(ctx context.Context, root string, opts DirUsageOptions) (DirUsageInfo, error) {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return DirUsageInfo{}, err
+	}
+
+	rootStat, err := os.Lstat(root)
+	if err != nil {
+		return DirUsageInfo{}, err
+	}
+	if !rootStat.IsDir() {
+		return DirUsageInfo{}, fmt.Errorf("%s 不是目录", root)
+	}
+	sys, ok := rootStat.Sys().(*syscall.Stat_t)
+	if !ok {
+		return DirUsageInfo{}, fmt.Errorf("无法获取 %s 所在的文件系统", root)
+	}
+
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU() * 4
+	}
+
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	scanner := &dirScanner{
+		ctx:      ctx,
+		opts:     opts,
+		device:   uint64(sys.Dev),
+		sem:      make(chan struct{}, workers),
+		inodes:   make(map[uint64]bool),
+		topFiles: &fileHeap{},
+	}
+
+	start := time.Now()
+	tree := scanner.scan(root, 0)
+	tree.Name = root
+
+	files := make([]FileUsage, scanner.topFiles.Len())
+	copy(files, *scanner.topFiles)
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Bytes > files[j].Bytes
+	})
+
+	return DirUsageInfo{
+		Root:         root,
+		Tree:         tree,
+		LargestFiles: files,
+		TotalBytes:   tree.Bytes,
+		FileCount:    atomic.LoadUint64(&scanner.files),
+		DirCount:     atomic.LoadUint64(&scanner.dirs),
+		ErrorCount:   atomic.LoadUint64(&scanner.errors),
+		TimedOut:     ctx.Err() != nil,
+		Elapsed:      time.Since(start).Seconds(),
+		Timestamp:    time.Now(),
+	}, nil
+}
+
+// dirScanner 目录扫描状态
+type dirScanner struct {
+	ctx    context.Context
+	opts   DirUsageOptions
+	device uint64
+	sem    chan struct{}
+
+	files  uint64
+	dirs   uint64
+	errors uint64
+
+	mu       sync.Mutex
+	inodes   map[uint64]bool // 已统计的硬链接 inode
+	topFiles *fileHeap
+}
+
+// scan 扫描单个目录，返回其大小（含所有子目录）
+func (s *dirScanner) scan(path string, depth int) DirUsageNode {
+	node := DirUsageNode{Path: path, Name: filepath.Base(path)}
+	atomic.AddUint64(&s.dirs, 1)
+
+	if s.ctx.Err() != nil {
+		return node
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		atomic.AddUint64(&s.errors, 1)
+		return node
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var children []DirUsageNode
+
+	for _, entry := range entries {
+		childPath := filepath.Join(path, entry.Name())
+
+		info, err := entry.Info()
+		if err != nil {
+			atomic.AddUint64(&s.errors, 1)
+			continue
+		}
+		stat, ok := info.Sys().(*syscall.Stat_t)
+		if !ok {
+			continue
+		}
+
+		if entry.IsDir() {
+			// 不跨越文件系统（类似 du -x）
+			if uint64(stat.Dev) != s.device {
+				continue
+			}
+
+			// 有空闲 worker 时并发扫描，否则在当前 goroutine 中扫描，避免死锁
+			select {
+			case s.sem <- struct{}{}:
+				wg.Add(1)
+				go func(p string) {
+					defer wg.Done()
+					defer func() { <-s.sem }()
+					child := s.scan(p, depth+1)
+					mu.Lock()
+					children = append(children, child)
+					mu.Unlock()
+				}(childPath)
+			default:
+				child := s.scan(childPath, depth+1)
+				mu.Lock()
+				children = append(children, child)
+				mu.Unlock()
+			}
+			continue
+		}
+
+		// 按实际占用的块计算（与 du 一致），硬链接只统计一次
+		size := uint64(stat.Blocks) * 512
+		if stat.Nlink > 1 {
+			s.mu.Lock()
+			seen := s.inodes[stat.Ino]
+			s.inodes[stat.Ino] = true
+			s.mu.Unlock()
+			if seen {
+				continue
+			}
+		}
+
+		node.Bytes += size
+		node.Files++
+		atomic.AddUint64(&s.files, 1)
+
+		if entry.Type().IsRegular() {
+			s.addFile(FileUsage{Path: childPath, Bytes: size})
+		}
+	}
+
+	wg.Wait()
+
+	for _, child := range children {
+		node.Bytes += child.Bytes
+		node.Files += child.Files
+	}
+
+	// 只保留限定深度内的最大子目录
+	if depth < s.opts.MaxDepth {
+		sort.Slice(children, func(i, j int) bool {
+			return children[i].Bytes > children[j].Bytes
+		})
+		if s.opts.TopN > 0 && len(children) > s.opts.TopN {
+			children = children[:s.opts.TopN]
+		}
+		node.Children = children
+	}
+
+	return node
+}
+
+// addFile 维护最大的 TopN 个文件
+func (s *dirScanner) addFile(file FileUsage) {
+	if s.opts.TopN <= 0 {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.topFiles.Len() < s.opts.TopN {
+		heap.Push(s.topFiles, file)
+	} else if (*s.topFiles)[0].Bytes < file.Bytes {
+		(*s.topFiles)[0] = file
+		heap.Fix(s.topFiles, 0)
+	}
+}
+
+// fileHeap 按大小排序的最小堆
+type fileHeap []FileUsage
+
+func (h fileHeap) Len() int            { return len(h) }
+func (h fileHeap) Less(i, j int) bool  { return h[i].Bytes < h[j].Bytes }
+func (h fileHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *fileHeap) Push(x interface{}) { *h = append(*h, x.(FileUsage)) }
+func (h *fileHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	*h = old[:n-1]
+	return item
+}
@@ -0,0 +1,111 @@
+package monitor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+)
+
+// ErrorKind 采集错误的类型
+type ErrorKind string
+
+const (
+	// ErrorPermission 权限不足（通常需要 root 或加入对应的用户组）
+	ErrorPermission ErrorKind = "permission"
+	// ErrorNotFound 数据源不存在（内核未启用、容器中未挂载 /proc 或 /sys 等）
+	ErrorNotFound ErrorKind = "not_found"
+	// ErrorUnavailable 依赖的服务不可用（Docker、systemd 等）
+	ErrorUnavailable ErrorKind = "unavailable"
+	// ErrorTimeout 超过子系统的采集超时，只返回了部分结果
+	ErrorTimeout ErrorKind = "timeout"
+	// ErrorOther 其他错误
+	ErrorOther ErrorKind = "other"
+)
+
+// FieldError 某个字段采集失败的原因，对应字段保留零值（或部分结果）
+type FieldError struct {
+	// Go 字段名，如 LoadAvg1、Partitions；列表元素的字段用 . 连接，如 Interfaces.BytesSent
+	Field   string
+	Kind    ErrorKind
+	Message string
+}
+
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// FieldErrors 一次采集中的所有字段错误，采集完全成功时为空
+type FieldErrors []FieldError
+
+// add 记录字段的采集错误（err 为 nil 时忽略）
+func (e *FieldErrors) add(field string, err error) {
+	if err == nil {
+		return
+	}
+	*e = append(*e, FieldError{Field: field, Kind: errorKind(err), Message: err.Error()})
+}
+
+// addf 记录指定类型的字段错误
+func (e *FieldErrors) addf(field string, kind ErrorKind, format string, args ...interface{}) {
+	*e = append(*e, FieldError{Field: field, Kind: kind, Message: fmt.Sprintf(format, args...)})
+}
+
+// errorKind 根据错误判断类型
+func errorKind(err error) ErrorKind {
+	switch {
+	case errors.Is(err, fs.ErrPermission):
+		return ErrorPermission
+	case errors.Is(err, fs.ErrNotExist):
+		return ErrorNotFound
+	case errors.Is(err, ErrDockerUnavailable):
+		return ErrorUnavailable
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, context.Canceled):
+		return ErrorTimeout
+	}
+	return ErrorOther
+}
+
+// errorCounter 统计大量同类对象（如进程）中各字段的失败次数，汇总成每个字段一条错误
+type errorCounter struct {
+	counts map[string]int
+	kinds  map[string]ErrorKind
+	first  map[string]string
+}
+
+func newErrorCounter() *errorCounter {
+	return &errorCounter{
+		counts: make(map[string]int),
+		kinds:  make(map[string]ErrorKind),
+		first:  make(map[string]string),
+	}
+}
+
+// add 记录一次失败（err 为 nil 时忽略），返回是否有错误
+func (c *errorCounter) add(field string, err error) bool {
+	if err == nil {
+		return false
+	}
+	if c.counts[field] == 0 {
+		c.kinds[field] = errorKind(err)
+		c.first[field] = err.Error()
+	}
+	c.counts[field]++
+	return true
+}
+
+// errors 按字段名排序输出汇总后的错误，what 为对象的名称（如 "进程"）
+func (c *errorCounter) errors(what string) FieldErrors {
+	fields := make([]string, 0, len(c.counts))
+	for field := range c.counts {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	var errs FieldErrors
+	for _, field := range fields {
+		errs.addf(field, c.kinds[field], "%d 个%s读取失败，例如: %s", c.counts[field], what, c.first[field])
+	}
+	return errs
+}
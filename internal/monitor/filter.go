@@ -0,0 +1,66 @@
+package monitor
+
+import "path/filepath"
+
+// DiskFilter 磁盘分区过滤条件（列表为空表示不限制）
+type DiskFilter struct {
+	MountPoints        []string
+	ExcludeMountPoints []string
+	FsTypes            []string
+	ExcludeFsTypes     []string
+	Devices            []string
+	ExcludeDevices     []string
+	// DedupBindMounts 同一文件系统的多个挂载点只保留路径最短的一个
+	DedupBindMounts bool
+}
+
+// Match 判断分区是否满足过滤条件
+func (f DiskFilter) Match(device, mountpoint, fstype string) bool {
+	return matchFilter(f.MountPoints, f.ExcludeMountPoints, mountpoint) &&
+		matchFilter(f.FsTypes, f.ExcludeFsTypes, fstype) &&
+		matchFilter(f.Devices, f.ExcludeDevices, device)
+}
+
+// matchFilter include 为空或命中 include，且未命中 exclude（均支持通配符）
+func matchFilter(include, exclude []string, value string) bool {
+	if len(include) > 0 && !matchAnyGlob(include, value) {
+		return false
+	}
+	return !matchAnyGlob(exclude, value)
+}
+
+func matchAnyGlob(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if pattern == value {
+			return true
+		}
+		if ok, err := filepath.Match(pattern, value); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+// NetworkFilter 网络接口过滤条件（列表为空表示不限制）
+type NetworkFilter struct {
+	Interfaces        []string
+	ExcludeInterfaces []string
+	// Kinds / ExcludeKinds 按接口类型过滤，如 physical、bridge、veth
+	Kinds           []string
+	ExcludeKinds    []string
+	ExcludeLoopback bool
+}
+
+// DefaultNetworkFilter 默认只排除回环接口
+func DefaultNetworkFilter() NetworkFilter {
+	return NetworkFilter{ExcludeLoopback: true}
+}
+
+// Match 判断接口是否满足过滤条件
+func (f NetworkFilter) Match(name, kind string) bool {
+	if f.ExcludeLoopback && kind == InterfaceLoopback {
+		return false
+	}
+	return matchFilter(f.Interfaces, f.ExcludeInterfaces, name) &&
+		matchFilter(f.Kinds, f.ExcludeKinds, kind)
+}
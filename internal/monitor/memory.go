@@ -1,15 +1,41 @@
 package monitor
 
 import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/shirou/gopsutil/v3/mem"
 )
 
 // GetMemoryInfo 获取内存信息
-func GetMemoryInfo() MemoryInfo {
-	vmem, _ := mem.VirtualMemory()
-	swap, _ := mem.SwapMemory()
+func GetMemoryInfo(ctx context.Context) MemoryInfo {
+	var errs FieldErrors
+
+	// 读取失败时使用零值，避免空指针
+	vmem, err := mem.VirtualMemoryWithContext(ctx)
+	if err != nil || vmem == nil {
+		errs.add("Total", err)
+		vmem = &mem.VirtualMemoryStat{}
+	}
+	swap, err := mem.SwapMemoryWithContext(ctx)
+	if err != nil || swap == nil {
+		errs.add("SwapTotal", err)
+		swap = &mem.SwapMemoryStat{}
+	}
+
+	// 内存压力
+	pressure := readPressureFile(filepath.Join(DefaultPressureDir, "memory"))
+
+	// 缺页和换入换出速率
+	vmstat := vmstatSampler.rates(ctx, func() map[string]uint64 {
+		return readKeyValueFile(filepath.Join(DefaultProcRoot, "vmstat"))
+	})
 
 	return MemoryInfo{
 		Total:       vmem.Total,
@@ -21,6 +47,73 @@ func GetMemoryInfo() MemoryInfo {
 		SwapPercent: swap.UsedPercent,
 		Cached:      vmem.Cached,
 		Buffers:     vmem.Buffers,
-		Timestamp:   time.Now(),
+		Shared:      vmem.Shared,
+		Slab:        vmem.Slab,
+		SlabReclaim: vmem.Sreclaimable,
+		SlabUnrecl:  vmem.Sunreclaim,
+		Dirty:       vmem.Dirty,
+		Writeback:   vmem.WriteBack,
+		CommittedAS: vmem.CommittedAS,
+		CommitLimit: vmem.CommitLimit,
+		HugePages: HugePagesInfo{
+			Total:    vmem.HugePagesTotal,
+			Free:     vmem.HugePagesFree,
+			Reserved: vmem.HugePagesRsvd,
+			PageSize: vmem.HugePageSize,
+		},
+		Paging: PagingRates{
+			PageFaults:  vmstat["pgfault"],
+			MajorFaults: vmstat["pgmajfault"],
+			SwapIn:      vmstat["pswpin"],
+			SwapOut:     vmstat["pswpout"],
+		},
+		NUMANodes: readNUMANodes(DefaultSysRoot),
+		Pressure:  pressure,
+		Errors:    errs,
+		Timestamp: time.Now(),
 	}
 }
+
+// vmstatSampler /proc/vmstat 计数器采样
+var vmstatSampler counterSampler
+
+// readNUMANodes 读取 /sys/devices/system/node/node*/meminfo
+func readNUMANodes(sysRoot string) []NUMANodeMemory {
+	dirs, _ := filepath.Glob(filepath.Join(sysRoot, "devices", "system", "node", "node*"))
+	sort.Strings(dirs)
+
+	var nodes []NUMANodeMemory
+	for _, dir := range dirs {
+		id, err := strconv.Atoi(strings.TrimPrefix(filepath.Base(dir), "node"))
+		if err != nil {
+			continue
+		}
+
+		// 格式: Node 0 MemTotal:       16384 kB
+		values := make(map[string]uint64)
+		file, err := os.Open(filepath.Join(dir, "meminfo"))
+		if err != nil {
+			continue
+		}
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			fields := strings.Fields(scanner.Text())
+			if len(fields) < 4 {
+				continue
+			}
+			if n, err := strconv.ParseUint(fields[3], 10, 64); err == nil {
+				values[strings.TrimSuffix(fields[2], ":")] = n * 1024
+			}
+		}
+		file.Close()
+
+		nodes = append(nodes, NUMANodeMemory{
+			Node:  id,
+			Total: values["MemTotal"],
+			Free:  values["MemFree"],
+			Used:  values["MemUsed"],
+		})
+	}
+
+	return nodes
+}
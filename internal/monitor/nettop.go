@@ -0,0 +1,320 @@
+package monitor
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// netTopSampleInterval 两次采样套接字计数器的间隔
+const netTopSampleInterval = time.Second
+
+// sock_diag 相关常量（linux/sock_diag.h、linux/inet_diag.h）
+const (
+	netlinkInetDiag   = 4
+	sockDiagByFamily  = 20
+	inetDiagInfo      = 2
+	inetDiagReqV2Size = 56
+	inetDiagMsgSize   = 72
+
+	// struct tcp_info 中 tcpi_bytes_acked 和 tcpi_bytes_received 的偏移
+	tcpInfoBytesAckedOffset    = 120
+	tcpInfoBytesReceivedOffset = 128
+)
+
+// cgroup 路径中的容器 ID（docker、containerd、cri-o）
+var containerIDPattern = regexp.MustCompile(`([0-9a-f]{64})`)
+
+// tcpSocketBytes 单个 TCP 套接字的累计收发字节
+type tcpSocketBytes struct {
+	sent uint64
+	recv uint64
+}
+
+// GetProcessNetworkInfo 按进程和容器统计 TCP 带宽（类似 nethogs）
+// 通过 sock_diag 读取每个套接字的 tcp_info 字节计数，再用 /proc/<pid>/fd 中的套接字 inode 关联到进程；
+// UDP 套接字没有字节计数，不参与统计。非 root 运行时只能关联当前用户的进程，其余流量计入未归属
+func GetProcessNetworkInfo(ctx context.Context, topN int) ProcessNetworkInfo {
+	before, err := dumpTCPSockets()
+	if err != nil {
+		return ProcessNetworkInfo{
+			Available: false,
+			Error:     fmt.Sprintf("无法读取套接字统计 (sock_diag): %v", err),
+			Timestamp: time.Now(),
+		}
+	}
+	start := time.Now()
+	if err := sleepContext(ctx, netTopSampleInterval); err != nil {
+		return ProcessNetworkInfo{
+			Available: false,
+			Error:     fmt.Sprintf("采样被中断: %v", err),
+			Timestamp: time.Now(),
+		}
+	}
+	after, err := dumpTCPSockets()
+	if err != nil {
+		return ProcessNetworkInfo{
+			Available: false,
+			Error:     fmt.Sprintf("无法读取套接字统计 (sock_diag): %v", err),
+			Timestamp: time.Now(),
+		}
+	}
+	elapsed := time.Since(start).Seconds()
+
+	owners, unreadable := socketOwners(DefaultProcRoot, after)
+
+	info := ProcessNetworkInfo{
+		Available:           true,
+		Partial:             unreadable > 0,
+		UnreadableProcesses: unreadable,
+		Timestamp:           time.Now(),
+	}
+
+	processes := make(map[int32]*ProcessNetUsage)
+	for inode, current := range after {
+		// 采样期间新建的套接字，全部字节都发生在采样期间
+		prev := before[inode]
+		if current.sent < prev.sent || current.recv < prev.recv {
+			continue
+		}
+		sent := float64(current.sent-prev.sent) / elapsed
+		recv := float64(current.recv-prev.recv) / elapsed
+
+		pid, ok := owners[inode]
+		if !ok {
+			info.UnattributedSendPerSec += sent
+			info.UnattributedRecvPerSec += recv
+			continue
+		}
+
+		usage, ok := processes[pid]
+		if !ok {
+			usage = &ProcessNetUsage{PID: pid}
+			processes[pid] = usage
+		}
+		usage.Connections++
+		usage.SendBytesPerSec += sent
+		usage.RecvBytesPerSec += recv
+	}
+
+	// 补充进程名和所属容器，并按容器汇总
+	var containerNames map[string]string
+	containers := make(map[string]*ContainerNetUsage)
+	for pid, usage := range processes {
+		procDir := filepath.Join(DefaultProcRoot, strconv.Itoa(int(pid)))
+		usage.Name = readSysString(filepath.Join(procDir, "comm"))
+		usage.ContainerID = processContainerID(filepath.Join(procDir, "cgroup"))
+
+		if usage.ContainerID == "" {
+			continue
+		}
+		if containerNames == nil {
+			containerNames = dockerContainerNames(ctx)
+		}
+		usage.ContainerName = containerNames[usage.ContainerID]
+
+		container, ok := containers[usage.ContainerID]
+		if !ok {
+			container = &ContainerNetUsage{ID: usage.ContainerID, Name: usage.ContainerName}
+			containers[usage.ContainerID] = container
+		}
+		container.Processes++
+		container.Connections += usage.Connections
+		container.SendBytesPerSec += usage.SendBytesPerSec
+		container.RecvBytesPerSec += usage.RecvBytesPerSec
+	}
+
+	for _, usage := range processes {
+		info.Processes = append(info.Processes, *usage)
+	}
+	sort.Slice(info.Processes, func(i, j int) bool {
+		a, b := info.Processes[i], info.Processes[j]
+		return a.SendBytesPerSec+a.RecvBytesPerSec > b.SendBytesPerSec+b.RecvBytesPerSec
+	})
+	if topN > 0 && len(info.Processes) > topN {
+		info.Processes = info.Processes[:topN]
+	}
+
+	for _, container := range containers {
+		info.Containers = append(info.Containers, *container)
+	}
+	sort.Slice(info.Containers, func(i, j int) bool {
+		a, b := info.Containers[i], info.Containers[j]
+		return a.SendBytesPerSec+a.RecvBytesPerSec > b.SendBytesPerSec+b.RecvBytesPerSec
+	})
+
+	return info
+}
+
+// dumpTCPSockets 通过 NETLINK_INET_DIAG 获取所有 TCP 套接字的 inode 和收发字节
+func dumpTCPSockets() (map[uint64]tcpSocketBytes, error) {
+	fd, err := syscall.Socket(syscall.AF_NETLINK, syscall.SOCK_RAW|syscall.SOCK_CLOEXEC, netlinkInetDiag)
+	if err != nil {
+		return nil, err
+	}
+	defer syscall.Close(fd)
+
+	sockets := make(map[uint64]tcpSocketBytes)
+	for seq, family := range []uint8{syscall.AF_INET, syscall.AF_INET6} {
+		if err := sendInetDiagRequest(fd, family, uint32(seq+1)); err != nil {
+			return nil, err
+		}
+		if err := receiveInetDiag(fd, sockets); err != nil {
+			return nil, err
+		}
+	}
+
+	return sockets, nil
+}
+
+// sendInetDiagRequest 发送 inet_diag_req_v2 请求（所有状态，附带 tcp_info）
+func sendInetDiagRequest(fd int, family uint8, seq uint32) error {
+	buf := make([]byte, syscall.NLMSG_HDRLEN+inetDiagReqV2Size)
+
+	// struct nlmsghdr
+	binary.NativeEndian.PutUint32(buf[0:4], uint32(len(buf)))
+	binary.NativeEndian.PutUint16(buf[4:6], sockDiagByFamily)
+	binary.NativeEndian.PutUint16(buf[6:8], syscall.NLM_F_REQUEST|syscall.NLM_F_DUMP)
+	binary.NativeEndian.PutUint32(buf[8:12], seq)
+
+	// struct inet_diag_req_v2，其后的 inet_diag_sockid 全为 0 表示不过滤
+	req := buf[syscall.NLMSG_HDRLEN:]
+	req[0] = family
+	req[1] = syscall.IPPROTO_TCP
+	req[2] = 1 << (inetDiagInfo - 1)
+	binary.NativeEndian.PutUint32(req[4:8], 0xffffffff)
+
+	return syscall.Sendto(fd, buf, 0, &syscall.SockaddrNetlink{Family: syscall.AF_NETLINK})
+}
+
+// receiveInetDiag 读取 dump 结果直到 NLMSG_DONE
+func receiveInetDiag(fd int, sockets map[uint64]tcpSocketBytes) error {
+	buf := make([]byte, 64*1024)
+	for {
+		n, _, err := syscall.Recvfrom(fd, buf, 0)
+		if err != nil {
+			return err
+		}
+		msgs, err := syscall.ParseNetlinkMessage(buf[:n])
+		if err != nil {
+			return err
+		}
+
+		for _, msg := range msgs {
+			switch msg.Header.Type {
+			case syscall.NLMSG_DONE:
+				return nil
+			case syscall.NLMSG_ERROR:
+				if len(msg.Data) >= 4 {
+					if errno := int32(binary.NativeEndian.Uint32(msg.Data[0:4])); errno != 0 {
+						return syscall.Errno(-errno)
+					}
+				}
+				return nil
+			}
+
+			inode, bytes, ok := parseInetDiagMsg(msg.Data)
+			if ok && inode != 0 {
+				sockets[inode] = bytes
+			}
+		}
+	}
+}
+
+// parseInetDiagMsg 解析 inet_diag_msg 及其后的 INET_DIAG_INFO 属性
+func parseInetDiagMsg(data []byte) (uint64, tcpSocketBytes, bool) {
+	if len(data) < inetDiagMsgSize {
+		return 0, tcpSocketBytes{}, false
+	}
+	inode := uint64(binary.NativeEndian.Uint32(data[68:72]))
+
+	attrs := data[inetDiagMsgSize:]
+	for len(attrs) >= syscall.SizeofRtAttr {
+		attrLen := int(binary.NativeEndian.Uint16(attrs[0:2]))
+		attrType := binary.NativeEndian.Uint16(attrs[2:4])
+		if attrLen < syscall.SizeofRtAttr || attrLen > len(attrs) {
+			break
+		}
+
+		// 旧内核 (< 4.1) 的 tcp_info 没有 bytes_received
+		value := attrs[syscall.SizeofRtAttr:attrLen]
+		if attrType == inetDiagInfo && len(value) >= tcpInfoBytesReceivedOffset+8 {
+			return inode, tcpSocketBytes{
+				sent: binary.NativeEndian.Uint64(value[tcpInfoBytesAckedOffset:]),
+				recv: binary.NativeEndian.Uint64(value[tcpInfoBytesReceivedOffset:]),
+			}, true
+		}
+
+		// 属性按 4 字节对齐
+		aligned := (attrLen + syscall.RTA_ALIGNTO - 1) &^ (syscall.RTA_ALIGNTO - 1)
+		if aligned > len(attrs) {
+			break
+		}
+		attrs = attrs[aligned:]
+	}
+
+	return inode, tcpSocketBytes{}, false
+}
+
+// socketOwners 扫描 /proc/<pid>/fd，返回套接字 inode 到 PID 的映射，以及无权读取的进程数
+func socketOwners(procRoot string, sockets map[uint64]tcpSocketBytes) (map[uint64]int32, int) {
+	owners := make(map[uint64]int32)
+	unreadable := 0
+
+	entries, err := os.ReadDir(procRoot)
+	if err != nil {
+		return owners, 0
+	}
+
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+
+		fdDir := filepath.Join(procRoot, entry.Name(), "fd")
+		fds, err := os.ReadDir(fdDir)
+		if err != nil {
+			if os.IsPermission(err) {
+				unreadable++
+			}
+			continue
+		}
+
+		for _, fd := range fds {
+			link, err := os.Readlink(filepath.Join(fdDir, fd.Name()))
+			if err != nil || !strings.HasPrefix(link, "socket:[") {
+				continue
+			}
+			inode, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimPrefix(link, "socket:["), "]"), 10, 64)
+			if err != nil {
+				continue
+			}
+			// 多个进程共享同一个套接字时（如 fork 后），归属到第一个找到的进程
+			if _, ok := sockets[inode]; ok {
+				if _, seen := owners[inode]; !seen {
+					owners[inode] = int32(pid)
+				}
+			}
+		}
+	}
+
+	return owners, unreadable
+}
+
+// processContainerID 从 /proc/<pid>/cgroup 中解析容器 ID，不在容器中时返回空
+func processContainerID(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return containerIDPattern.FindString(string(data))
+}
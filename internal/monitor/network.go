@@ -1,21 +1,48 @@
 package monitor
 
 import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/shirou/gopsutil/v3/net"
 )
 
-// GetNetworkInfo 获取网络信息
-func GetNetworkInfo() NetworkInfo {
-	ioCounters, _ := net.IOCounters(true)
-	interfaces, _ := net.Interfaces()
+// 网络接口类型
+const (
+	InterfacePhysical = "physical"
+	InterfaceBridge   = "bridge"
+	InterfaceVeth     = "veth"
+	InterfaceVLAN     = "vlan"
+	InterfaceBond     = "bond"
+	InterfaceTunnel   = "tunnel"
+	InterfaceLoopback = "loopback"
+	InterfaceVirtual  = "virtual"
+)
+
+// 隧道类接口的 ARPHRD 类型（ipip、ip6tnl、sit、gre、ip6gre、none/tun）
+var tunnelLinkTypes = map[int64]bool{768: true, 769: true, 776: true, 778: true, 823: true, 65534: true}
+
+// 隧道类接口的 uevent DEVTYPE
+var tunnelDevTypes = map[string]bool{"vxlan": true, "geneve": true, "wireguard": true, "gretap": true, "ip6gretap": true, "ipip": true}
+
+// GetNetworkInfo 获取网络信息（显示满足过滤条件的接口）
+func GetNetworkInfo(ctx context.Context, filter NetworkFilter) NetworkInfo {
+	var errs FieldErrors
+	ioCounters, err := net.IOCountersWithContext(ctx, true)
+	errs.add("Interfaces.BytesSent", err)
+	interfaces, err := net.InterfacesWithContext(ctx)
+	errs.add("Interfaces", err)
 
 	var interfaceInfos []InterfaceInfo
 
 	for _, iface := range interfaces {
-		// 跳过回环接口
-		if iface.Name == "lo" {
+		dir := filepath.Join(DefaultSysRoot, "class", "net", iface.Name)
+		kind := classifyInterface(dir, iface.Name)
+
+		if !filter.Match(iface.Name, kind) {
 			continue
 		}
 
@@ -36,8 +63,21 @@ func GetNetworkInfo() NetworkInfo {
 			}
 		}
 
+		// 速率未知时内核返回 -1
+		speed, _ := readSysInt(filepath.Join(dir, "speed"))
+		if speed < 0 {
+			speed = 0
+		}
+
 		interfaceInfos = append(interfaceInfos, InterfaceInfo{
 			Name:        iface.Name,
+			Kind:        kind,
+			MTU:         iface.MTU,
+			MAC:         iface.HardwareAddr,
+			SpeedMbps:   speed,
+			Duplex:      readSysString(filepath.Join(dir, "duplex")),
+			OperState:   readSysString(filepath.Join(dir, "operstate")),
+			Flags:       iface.Flags,
 			BytesSent:   bytesSent,
 			BytesRecv:   bytesRecv,
 			PacketsSent: packetsSent,
@@ -48,6 +88,52 @@ func GetNetworkInfo() NetworkInfo {
 
 	return NetworkInfo{
 		Interfaces: interfaceInfos,
+		Errors:     errs,
 		Timestamp:  time.Now(),
 	}
 }
+
+// classifyInterface 根据 /sys/class/net/<name> 判断接口类型
+func classifyInterface(dir, name string) string {
+	linkType, _ := readSysInt(filepath.Join(dir, "type"))
+	if linkType == 772 || name == "lo" {
+		return InterfaceLoopback
+	}
+
+	devType := ""
+	for _, line := range strings.Split(readSysString(filepath.Join(dir, "uevent")), "\n") {
+		if strings.HasPrefix(line, "DEVTYPE=") {
+			devType = strings.TrimPrefix(line, "DEVTYPE=")
+		}
+	}
+
+	switch {
+	case devType == "bridge" || pathExists(filepath.Join(dir, "bridge")):
+		return InterfaceBridge
+	case devType == "bond" || pathExists(filepath.Join(dir, "bonding")):
+		return InterfaceBond
+	case devType == "vlan":
+		return InterfaceVLAN
+	case tunnelDevTypes[devType] || tunnelLinkTypes[linkType] || pathExists(filepath.Join(dir, "tun_flags")):
+		return InterfaceTunnel
+	case pathExists(filepath.Join(dir, "device")):
+		// 有对应硬件设备（PCI、USB、virtio 等）
+		return InterfacePhysical
+	case strings.HasPrefix(name, "veth"):
+		return InterfaceVeth
+	}
+
+	// veth 的 iflink 指向对端接口
+	ifindex, _ := readSysInt(filepath.Join(dir, "ifindex"))
+	iflink, _ := readSysInt(filepath.Join(dir, "iflink"))
+	if devType == "" && linkType == 1 && iflink != ifindex && iflink != 0 {
+		return InterfaceVeth
+	}
+
+	return InterfaceVirtual
+}
+
+func pathExists(path string) bool {
+	_, err := os.Lstat(path)
+	return err == nil
+}
@@ -0,0 +1,205 @@
+package monitor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxPluginOutput 插件标准输出的最大字节数，超出时视为失败
+const maxPluginOutput = 4 << 20
+
+// pluginWaitDelay 插件被取消后等待其输出管道关闭的时间（插件启动的子进程可能仍持有管道）
+const pluginWaitDelay = time.Second
+
+// 插件的输出格式
+const (
+	PluginFormatJSON       = "json"
+	PluginFormatPrometheus = "prometheus"
+)
+
+// PluginOptions 外部插件的设置
+type PluginOptions struct {
+	// 名称，同时是 Web 数据中的键和 WebSocket 主题
+	Name    string
+	Command string
+	Args    []string
+	// 输出格式：json、prometheus，留空时根据输出自动判断
+	Format   string
+	Interval time.Duration
+}
+
+// PluginInfo 插件最近一次执行的结果
+type PluginInfo struct {
+	Name    string
+	Metrics []Metric
+	// 执行或解析失败的原因，成功时为空
+	Error     string
+	Timestamp time.Time
+}
+
+// pluginCollector 执行外部程序并解析其标准输出的采集器
+type pluginCollector struct {
+	opts PluginOptions
+}
+
+// NewPluginCollector 创建外部插件采集器
+// 插件每次采集时执行一次，在标准输出打印 JSON 或 Prometheus 文本格式的指标后退出
+func NewPluginCollector(opts PluginOptions) Collector {
+	return &pluginCollector{opts: opts}
+}
+
+func (p *pluginCollector) Name() string            { return p.opts.Name }
+func (p *pluginCollector) Interval() time.Duration { return p.opts.Interval }
+
+func (p *pluginCollector) Collect(ctx context.Context) Sample {
+	info := RunPlugin(ctx, p.opts)
+	return Sample{Info: info, Metrics: withPluginLabel(info.Metrics, p.opts.Name)}
+}
+
+// withPluginLabel 为导出的插件指标加上 plugin 标签（覆盖插件自己输出的同名标签），
+// 不同插件输出的同名序列、以及与内置指标同名的序列因此不会重复
+func withPluginLabel(metrics []Metric, name string) []Metric {
+	labeled := make([]Metric, 0, len(metrics))
+	for _, m := range metrics {
+		labels := make(map[string]string, len(m.Labels)+1)
+		for key, value := range m.Labels {
+			labels[key] = value
+		}
+		labels["plugin"] = name
+		m.Labels = labels
+		labeled = append(labeled, m)
+	}
+	return labeled
+}
+
+// RunPlugin 执行插件并解析输出，ctx 取消时终止插件
+func RunPlugin(ctx context.Context, opts PluginOptions) PluginInfo {
+	info := PluginInfo{Name: opts.Name, Timestamp: time.Now()}
+
+	stdout := &limitedBuffer{limit: maxPluginOutput}
+	stderr := &limitedBuffer{limit: 4096}
+	cmd := exec.CommandContext(ctx, opts.Command, opts.Args...)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	cmd.WaitDelay = pluginWaitDelay
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			err = ctx.Err()
+		}
+		info.Error = err.Error()
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			info.Error += ": " + lastLine(msg)
+		}
+		return info
+	}
+	if stdout.overflow {
+		info.Error = fmt.Sprintf("输出超过 %d MiB", maxPluginOutput>>20)
+		return info
+	}
+
+	metrics, err := ParsePluginOutput(stdout.Bytes(), opts.Format)
+	if err != nil {
+		info.Error = "解析输出失败: " + err.Error()
+		return info
+	}
+	info.Metrics = metrics
+	return info
+}
+
+// ParsePluginOutput 解析插件输出，format 为空时以 { 开头的输出按 JSON 解析，否则按 Prometheus 文本格式解析
+//
+// JSON 支持两种写法：
+//
+//	{"metrics": [{"name": "queue_depth", "type": "gauge", "help": "...", "labels": {"queue": "mail"}, "value": 42}]}
+//	{"queue_depth": 42, "cache_hit_ratio": 0.93}
+//
+// 第二种写法的指标都是没有标签的 gauge
+func ParsePluginOutput(output []byte, format string) ([]Metric, error) {
+	if format == "" {
+		format = PluginFormatPrometheus
+		if bytes.HasPrefix(bytes.TrimSpace(output), []byte("{")) {
+			format = PluginFormatJSON
+		}
+	}
+
+	switch format {
+	case PluginFormatJSON:
+		return parsePluginJSON(output)
+	case PluginFormatPrometheus:
+		return ParsePrometheusText(bytes.NewReader(output))
+	}
+	return nil, fmt.Errorf("未知的输出格式 %q", format)
+}
+
+func parsePluginJSON(output []byte) ([]Metric, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(output, &fields); err != nil {
+		return nil, err
+	}
+
+	var metrics []Metric
+	if raw, ok := fields["metrics"]; ok {
+		if err := json.Unmarshal(raw, &metrics); err != nil {
+			return nil, fmt.Errorf("metrics: %w", err)
+		}
+	} else {
+		names := make([]string, 0, len(fields))
+		for name := range fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			var value float64
+			if err := json.Unmarshal(fields[name], &value); err != nil {
+				return nil, fmt.Errorf("%s: 值必须是数字", name)
+			}
+			metrics = append(metrics, Metric{Name: name, Value: value})
+		}
+	}
+
+	for i := range metrics {
+		m := &metrics[i]
+		if !validMetricName(m.Name) {
+			return nil, fmt.Errorf("无效的指标名 %q", m.Name)
+		}
+		switch m.Type {
+		case "":
+			m.Type = MetricGauge
+		case MetricGauge, MetricCounter, MetricUntyped:
+		default:
+			return nil, fmt.Errorf("%s: JSON 只支持 gauge 和 counter 类型，直方图请使用 Prometheus 文本格式", m.Name)
+		}
+	}
+	return metrics, nil
+}
+
+// limitedBuffer 最多保存 limit 字节的 io.Writer，超出的部分丢弃并记录 overflow
+type limitedBuffer struct {
+	bytes.Buffer
+	limit    int
+	overflow bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.limit - b.Len(); len(p) > room {
+		b.overflow = true
+		b.Buffer.Write(p[:max(room, 0)])
+		return len(p), nil
+	}
+	return b.Buffer.Write(p)
+}
+
+// lastLine 返回多行文本的最后一行（插件的错误信息通常在最后）
+func lastLine(s string) string {
+	if i := strings.LastIndexByte(s, '\n'); i >= 0 {
+		return s[i+1:]
+	}
+	return s
+}
@@ -0,0 +1,71 @@
+package monitor
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+)
+
+func TestPluginMetricsExport(t *testing.T) {
+	const output = `# TYPE queue_depth gauge
+queue_depth{queue="mail"} 3
+queue_depth{queue="mail",plugin="spoofed"} 4
+syspulse_cpu_usage_percent 99
+`
+	var metrics []Metric
+	metrics = append(metrics, gauge("syspulse_cpu_usage_percent", "CPU 使用率", 12))
+	for _, name := range []string{"mail", "mail-backup"} {
+		collector := NewPluginCollector(PluginOptions{
+			Name:     name,
+			Command:  "/bin/sh",
+			Args:     []string{"-c", "printf '%s' \"$0\"", output},
+			Interval: time.Minute,
+		})
+		sample := collector.Collect(context.Background())
+		info := sample.Info.(PluginInfo)
+		if info.Error != "" {
+			t.Fatalf("plugin %s: %s", name, info.Error)
+		}
+		// 面板显示插件自己的标签，只有导出的指标带 plugin 标签
+		if _, ok := info.Metrics[0].Labels["plugin"]; ok {
+			t.Errorf("plugin %s: info metrics labeled %v", name, info.Metrics[0].Labels)
+		}
+		metrics = append(metrics, sample.Metrics...)
+	}
+	// 同一采集器重复输出的序列
+	metrics = append(metrics, gauge("syspulse_cpu_usage_percent", "CPU 使用率", 13))
+
+	var buf bytes.Buffer
+	if err := WritePrometheusText(&buf, metrics); err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := ParsePrometheusText(&buf)
+	if err != nil {
+		t.Fatalf("exported text does not parse: %v\n%s", err, buf.String())
+	}
+
+	values := make(map[string]float64)
+	for _, m := range parsed {
+		if _, ok := values[m.Series()]; ok {
+			t.Errorf("duplicate series %s", m.Series())
+		}
+		values[m.Series()] = m.Value
+	}
+
+	want := map[string]float64{
+		`syspulse_cpu_usage_percent`:                       12,
+		`syspulse_cpu_usage_percent{plugin="mail"}`:        99,
+		`syspulse_cpu_usage_percent{plugin="mail-backup"}`: 99,
+		`queue_depth{plugin="mail",queue="mail"}`:          3,
+		`queue_depth{plugin="mail-backup",queue="mail"}`:   3,
+	}
+	if len(values) != len(want) {
+		t.Errorf("got %d series, want %d:\n%s", len(values), len(want), buf.String())
+	}
+	for series, value := range want {
+		if got, ok := values[series]; !ok || got != value {
+			t.Errorf("%s = %v (present %v), want %v", series, got, ok, value)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package monitor
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -11,6 +12,7 @@ import (
 // PortInfo 端口信息
 type PortInfo struct {
 	Listening []PortDetail
+	Errors    FieldErrors
 	Timestamp time.Time
 }
 
@@ -25,8 +27,10 @@ type PortDetail struct {
 }
 
 // GetPortInfo 获取端口信息
-func GetPortInfo() PortInfo {
-	connections, _ := net.Connections("all")
+func GetPortInfo(ctx context.Context) PortInfo {
+	var errs FieldErrors
+	connections, err := net.ConnectionsWithContext(ctx, "all")
+	errs.add("Listening", err)
 
 	portMap := make(map[string]*PortDetail)
 
@@ -36,7 +40,7 @@ func GetPortInfo() PortInfo {
 			continue
 		}
 
-		key := fmt.Sprintf("%s:%d", conn.Type, conn.Laddr.Port)
+		key := fmt.Sprintf("%d:%d", conn.Type, conn.Laddr.Port)
 
 		// 避免重复
 		if _, exists := portMap[key]; exists {
@@ -53,8 +57,8 @@ func GetPortInfo() PortInfo {
 
 		// 尝试获取进程名
 		if conn.Pid > 0 {
-			if p, err := process.NewProcess(conn.Pid); err == nil {
-				if name, err := p.Name(); err == nil {
+			if p, err := process.NewProcessWithContext(ctx, conn.Pid); err == nil {
+				if name, err := p.NameWithContext(ctx); err == nil {
 					detail.ProcessName = name
 				}
 			}
@@ -71,6 +75,7 @@ func GetPortInfo() PortInfo {
 
 	return PortInfo{
 		Listening: listening,
+		Errors:    errs,
 		Timestamp: time.Now(),
 	}
 }
@@ -0,0 +1,80 @@
+package monitor
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultPressureDir 系统级 PSI 文件所在目录
+const DefaultPressureDir = "/proc/pressure"
+
+// GetPressureInfo 获取系统级 Pressure Stall Information（只读取 procfs 文件，ctx 仅为统一接口）
+func GetPressureInfo(ctx context.Context) PressureInfo {
+	cpuPressure := readPressureFile(filepath.Join(DefaultPressureDir, "cpu"))
+	memPressure := readPressureFile(filepath.Join(DefaultPressureDir, "memory"))
+	ioPressure := readPressureFile(filepath.Join(DefaultPressureDir, "io"))
+
+	return PressureInfo{
+		Available: cpuPressure.Available || memPressure.Available || ioPressure.Available,
+		CPU:       cpuPressure,
+		Memory:    memPressure,
+		IO:        ioPressure,
+		Timestamp: time.Now(),
+	}
+}
+
+// readPressureFile 解析 PSI 文件，格式:
+//
+//	some avg10=0.00 avg60=0.00 avg300=0.00 total=0
+//	full avg10=0.00 avg60=0.00 avg300=0.00 total=0
+func readPressureFile(path string) PressureResource {
+	var resource PressureResource
+
+	file, err := os.Open(path)
+	if err != nil {
+		return resource
+	}
+	defer file.Close()
+
+	resource.Available = true
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+
+		var stat PressureStat
+		for _, field := range fields[1:] {
+			key, value, found := strings.Cut(field, "=")
+			if !found {
+				continue
+			}
+			switch key {
+			case "avg10":
+				stat.Avg10, _ = strconv.ParseFloat(value, 64)
+			case "avg60":
+				stat.Avg60, _ = strconv.ParseFloat(value, 64)
+			case "avg300":
+				stat.Avg300, _ = strconv.ParseFloat(value, 64)
+			case "total":
+				stat.TotalUsec, _ = strconv.ParseUint(value, 10, 64)
+			}
+		}
+
+		switch fields[0] {
+		case "some":
+			resource.Some = stat
+		case "full":
+			resource.Full = stat
+		}
+	}
+
+	return resource
+}
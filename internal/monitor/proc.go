@@ -0,0 +1,35 @@
+package monitor
+
+import (
+	"bufio"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// DefaultProcRoot procfs 挂载点
+const DefaultProcRoot = "/proc"
+
+// readKeyValueFile 读取 "key value" 格式的文件（/proc/vmstat、cpu.stat、memory.events 等）
+func readKeyValueFile(path string) map[string]uint64 {
+	values := make(map[string]uint64)
+
+	file, err := os.Open(path)
+	if err != nil {
+		return values
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		if n, err := strconv.ParseUint(fields[1], 10, 64); err == nil {
+			values[fields[0]] = n
+		}
+	}
+
+	return values
+}
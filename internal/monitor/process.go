@@ -1,32 +1,71 @@
 package monitor
 
 import (
+	"context"
+	"errors"
+	"io/fs"
 	"sort"
+	"strconv"
 	"time"
 
 	"github.com/shirou/gopsutil/v3/process"
 )
 
 // GetProcessInfo 获取进程信息
-func GetProcessInfo(topN int) ProcessInfo {
-	processes, _ := process.Processes()
+func GetProcessInfo(ctx context.Context, topN int) ProcessInfo {
+	var errs FieldErrors
+	processes, err := process.ProcessesWithContext(ctx)
+	errs.add("TotalProcesses", err)
 
 	var processDetails []ProcessDetail
+	failures := newErrorCounter()
 
 	for _, p := range processes {
-		name, _ := p.Name()
-		username, _ := p.Username()
-		cpuPercent, _ := p.CPUPercent()
-		memPercent, _ := p.MemoryPercent()
-		memInfo, _ := p.MemoryInfo()
-		status, _ := p.Status()
-		cmdline, _ := p.Cmdline()
+		// 超时后只统计已读取的进程
+		if err := ctx.Err(); err != nil {
+			errs.add("TopCPU", err)
+			break
+		}
+
+		name, err := p.NameWithContext(ctx)
+		if errors.Is(err, fs.ErrNotExist) || errors.Is(err, process.ErrorProcessNotRunning) {
+			// 进程在列出后已退出
+			continue
+		}
+		failures.add("TopCPU.Name", err)
+
+		username, err := p.UsernameWithContext(ctx)
+		if err != nil {
+			// 容器内的 UID 在宿主机上常常没有对应的用户名，此时显示 UID
+			if uids, uidErr := p.UidsWithContext(ctx); uidErr == nil && len(uids) > 0 {
+				username = strconv.Itoa(int(uids[0]))
+			} else {
+				failures.add("TopCPU.Username", err)
+			}
+		}
+
+		cpuPercent, err := p.CPUPercentWithContext(ctx)
+		failures.add("TopCPU.CPUPercent", err)
+		memPercent, err := p.MemoryPercentWithContext(ctx)
+		failures.add("TopCPU.MemPercent", err)
+		memInfo, err := p.MemoryInfoWithContext(ctx)
+		failures.add("TopCPU.MemoryMB", err)
+		status, err := p.StatusWithContext(ctx)
+		failures.add("TopCPU.Status", err)
+		cmdline, err := p.CmdlineWithContext(ctx)
+		failures.add("TopCPU.Command", err)
 
 		memoryMB := float64(0)
 		if memInfo != nil {
 			memoryMB = float64(memInfo.RSS) / 1024 / 1024
 		}
 
+		// 读取失败时 status 为空
+		state := ""
+		if len(status) > 0 {
+			state = status[0]
+		}
+
 		processDetails = append(processDetails, ProcessDetail{
 			PID:        p.Pid,
 			Name:       name,
@@ -34,10 +73,11 @@ func GetProcessInfo(topN int) ProcessInfo {
 			CPUPercent: cpuPercent,
 			MemoryMB:   memoryMB,
 			MemPercent: memPercent,
-			Status:     status[0],
+			Status:     state,
 			Command:    cmdline,
 		})
 	}
+	errs = append(errs, failures.errors("进程")...)
 
 	// 按 CPU 排序
 	topCPU := make([]ProcessDetail, len(processDetails))
@@ -63,6 +103,7 @@ func GetProcessInfo(topN int) ProcessInfo {
 		TotalProcesses: len(processes),
 		TopCPU:         topCPU,
 		TopMemory:      topMemory,
+		Errors:         errs,
 		Timestamp:      time.Now(),
 	}
 }
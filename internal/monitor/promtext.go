@@ -0,0 +1,298 @@
+package monitor
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// maxMetricLine 单行指标的最大长度
+const maxMetricLine = 1 << 20
+
+// familySuffixes 样本名相对指标族名的后缀（直方图、摘要和 OpenMetrics 的 counter）
+var familySuffixes = []string{"_bucket", "_sum", "_count", "_total", "_created", "_gsum", "_gcount"}
+
+// ParsePrometheusText 解析 Prometheus 文本格式（0.0.4）或 OpenMetrics 文本格式的指标
+// 每个样本行对应一个 Metric，类型和说明取自所属指标族的 # TYPE 和 # HELP；
+// 值为 NaN 或 ±Inf 的样本（如没有观测值的摘要分位数）被跳过，JSON 无法表示这些值
+func ParsePrometheusText(r io.Reader) ([]Metric, error) {
+	types := make(map[string]MetricType)
+	helps := make(map[string]string)
+
+	var metrics []Metric
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxMetricLine)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "#") {
+			fields := strings.SplitN(strings.TrimSpace(line[1:]), " ", 3)
+			if len(fields) < 3 {
+				continue
+			}
+			switch fields[0] {
+			case "TYPE":
+				types[fields[1]] = parseMetricType(fields[2])
+			case "HELP":
+				helps[fields[1]] = unescapeHelp(fields[2])
+			}
+			continue
+		}
+
+		m, err := parseSampleLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("第 %d 行: %w", lineNo, err)
+		}
+		if math.IsNaN(m.Value) || math.IsInf(m.Value, 0) {
+			continue
+		}
+		family := metricFamily(m.Name, types)
+		m.Type = types[family]
+		if m.Type == "" {
+			m.Type = MetricUntyped
+		}
+		m.Help = helps[family]
+		metrics = append(metrics, m)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return metrics, nil
+}
+
+// MetricFamily 返回样本在 Prometheus 文本格式（0.0.4）中所属的指标族名（直方图和摘要去掉 _bucket 等后缀）
+func MetricFamily(m Metric) string {
+	switch m.Type {
+	case MetricHistogram, MetricSummary:
+		for _, suffix := range familySuffixes {
+			if base, ok := strings.CutSuffix(m.Name, suffix); ok && base != "" {
+				return base
+			}
+		}
+	}
+	return m.Name
+}
+
+// metricFamily 在已声明的类型中查找样本所属的指标族
+func metricFamily(name string, types map[string]MetricType) string {
+	if _, ok := types[name]; ok {
+		return name
+	}
+	for _, suffix := range familySuffixes {
+		if base, ok := strings.CutSuffix(name, suffix); ok {
+			if _, declared := types[base]; declared {
+				return base
+			}
+		}
+	}
+	return name
+}
+
+func parseMetricType(s string) MetricType {
+	switch t := MetricType(strings.ToLower(strings.TrimSpace(s))); t {
+	case MetricGauge, MetricCounter, MetricHistogram, MetricSummary:
+		return t
+	case "gaugehistogram":
+		return MetricHistogram
+	}
+	return MetricUntyped
+}
+
+func unescapeHelp(s string) string {
+	return strings.NewReplacer(`\\`, `\`, `\n`, "\n").Replace(s)
+}
+
+// parseSampleLine 解析 name{label="value",...} value [timestamp]
+func parseSampleLine(line string) (Metric, error) {
+	var m Metric
+
+	end := strings.IndexAny(line, "{ \t")
+	if end <= 0 {
+		return m, fmt.Errorf("缺少样本值: %q", line)
+	}
+	m.Name = line[:end]
+	if !validMetricName(m.Name) {
+		return m, fmt.Errorf("无效的指标名 %q", m.Name)
+	}
+	rest := line[end:]
+
+	if strings.HasPrefix(rest, "{") {
+		labels, n, err := parseLabels(rest)
+		if err != nil {
+			return m, fmt.Errorf("%s: %w", m.Name, err)
+		}
+		m.Labels = labels
+		rest = rest[n:]
+	}
+
+	fields := strings.Fields(rest)
+	if len(fields) == 0 {
+		return m, fmt.Errorf("%s: 缺少样本值", m.Name)
+	}
+	value, err := parseMetricValue(fields[0])
+	if err != nil {
+		return m, fmt.Errorf("%s: 无效的样本值 %q", m.Name, fields[0])
+	}
+	m.Value = value
+	return m, nil
+}
+
+// parseLabels 解析以 { 开头的标签集合，返回标签和消耗的字节数
+func parseLabels(s string) (map[string]string, int, error) {
+	labels := make(map[string]string)
+	i := 1
+	for {
+		for i < len(s) && (s[i] == ' ' || s[i] == ',') {
+			i++
+		}
+		if i >= len(s) {
+			return nil, 0, fmt.Errorf("标签缺少 }")
+		}
+		if s[i] == '}' {
+			return labels, i + 1, nil
+		}
+
+		eq := strings.IndexByte(s[i:], '=')
+		if eq <= 0 {
+			return nil, 0, fmt.Errorf("标签格式错误")
+		}
+		name := strings.TrimSpace(s[i : i+eq])
+		i += eq + 1
+		if i >= len(s) || s[i] != '"' {
+			return nil, 0, fmt.Errorf("标签 %s 的值缺少引号", name)
+		}
+		i++
+
+		var value strings.Builder
+		for ; i < len(s) && s[i] != '"'; i++ {
+			if s[i] == '\\' && i+1 < len(s) {
+				i++
+				switch s[i] {
+				case 'n':
+					value.WriteByte('\n')
+				default:
+					value.WriteByte(s[i])
+				}
+				continue
+			}
+			value.WriteByte(s[i])
+		}
+		if i >= len(s) {
+			return nil, 0, fmt.Errorf("标签 %s 的值缺少结束引号", name)
+		}
+		i++
+		labels[name] = value.String()
+	}
+}
+
+func parseMetricValue(s string) (float64, error) {
+	switch s {
+	case "+Inf", "Inf":
+		return math.Inf(1), nil
+	case "-Inf":
+		return math.Inf(-1), nil
+	case "NaN":
+		return math.NaN(), nil
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+// validMetricName 指标名只能包含字母、数字、_ 和 :，且不以数字开头
+func validMetricName(name string) bool {
+	for i, c := range name {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c == '_', c == ':':
+		case c >= '0' && c <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+	return name != ""
+}
+
+// WritePrometheusText 以 Prometheus 文本格式（0.0.4）输出指标
+// 同一指标族的样本按首次出现的顺序合并输出，族的类型和说明取自第一个样本
+func WritePrometheusText(w io.Writer, metrics []Metric) error {
+	var families []string
+	samples := make(map[string][]Metric)
+	seen := make(map[string]bool, len(metrics))
+	for _, m := range metrics {
+		// 重复的序列会让 Prometheus 拒绝整次抓取，只保留第一个
+		series := m.Series()
+		if seen[series] {
+			continue
+		}
+		seen[series] = true
+
+		family := MetricFamily(m)
+		if _, ok := samples[family]; !ok {
+			families = append(families, family)
+		}
+		samples[family] = append(samples[family], m)
+	}
+
+	escape := strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	bw := bufio.NewWriter(w)
+	for _, family := range families {
+		first := samples[family][0]
+		if first.Help != "" {
+			fmt.Fprintf(bw, "# HELP %s %s\n", family, escape.Replace(first.Help))
+		}
+		fmt.Fprintf(bw, "# TYPE %s %s\n", family, first.Type)
+		for _, m := range samples[family] {
+			bw.WriteString(m.Series())
+			bw.WriteByte(' ')
+			bw.WriteString(formatMetricValue(m.Value))
+			bw.WriteByte('\n')
+		}
+	}
+	return bw.Flush()
+}
+
+// Series 返回样本的序列名，如 http_requests_total{code="200",method="get"}（标签按名称排序）
+func (m Metric) Series() string {
+	if len(m.Labels) == 0 {
+		return m.Name
+	}
+	return m.Name + formatLabels(m.Labels)
+}
+
+// formatLabels 按标签名排序输出 {a="1",b="2"}
+func formatLabels(labels map[string]string) string {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	escape := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, name := range names {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		fmt.Fprintf(&b, `%s="%s"`, name, escape.Replace(labels[name]))
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+func formatMetricValue(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
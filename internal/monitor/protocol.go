@@ -0,0 +1,171 @@
+package monitor
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// protocolSampler 协议计数器的速率采样，后台采样、告警和 HTTP 请求共用（见 minRateWindow）
+var protocolSampler counterSampler
+
+// GetProtocolStats 获取 TCP / UDP 协议计数器和 conntrack 表使用情况
+func GetProtocolStats(ctx context.Context) ProtocolStats {
+	return GetProtocolStatsFrom(ctx, DefaultProcRoot)
+}
+
+// GetProtocolStatsFrom 从指定的 proc 目录读取协议统计
+func GetProtocolStatsFrom(ctx context.Context, procRoot string) ProtocolStats {
+	read := func() map[string]uint64 {
+		counters := readSnmpFile(filepath.Join(procRoot, "net", "snmp"))
+		for key, value := range readSnmpFile(filepath.Join(procRoot, "net", "netstat")) {
+			counters[key] = value
+		}
+		for key, value := range readConntrackStat(filepath.Join(procRoot, "net", "stat", "nf_conntrack")) {
+			counters[key] = value
+		}
+		return counters
+	}
+
+	totals := read()
+	if len(totals) == 0 {
+		return ProtocolStats{Available: false, Timestamp: time.Now()}
+	}
+	rates := protocolSampler.rates(ctx, read)
+
+	counter := func(key string) ProtocolCounter {
+		return ProtocolCounter{Total: totals[key], PerSec: rates[key]}
+	}
+
+	tcp := TCPProtocolStats{
+		CurrEstab:       totals["Tcp.CurrEstab"],
+		ActiveOpens:     counter("Tcp.ActiveOpens"),
+		PassiveOpens:    counter("Tcp.PassiveOpens"),
+		OutSegs:         counter("Tcp.OutSegs"),
+		RetransSegs:     counter("Tcp.RetransSegs"),
+		EstabResets:     counter("Tcp.EstabResets"),
+		OutRsts:         counter("Tcp.OutRsts"),
+		InErrs:          counter("Tcp.InErrs"),
+		ListenOverflows: counter("TcpExt.ListenOverflows"),
+		ListenDrops:     counter("TcpExt.ListenDrops"),
+		SynBacklogDrops: counter("TcpExt.TCPReqQFullDrop"),
+	}
+	if tcp.OutSegs.PerSec > 0 {
+		tcp.RetransPercent = tcp.RetransSegs.PerSec / tcp.OutSegs.PerSec * 100
+	}
+
+	udp := UDPProtocolStats{
+		InDatagrams:  counter("Udp.InDatagrams"),
+		OutDatagrams: counter("Udp.OutDatagrams"),
+		NoPorts:      counter("Udp.NoPorts"),
+		InErrors:     counter("Udp.InErrors"),
+		RcvbufErrors: counter("Udp.RcvbufErrors"),
+		SndbufErrors: counter("Udp.SndbufErrors"),
+	}
+
+	return ProtocolStats{
+		Available: true,
+		TCP:       tcp,
+		UDP:       udp,
+		Conntrack: readConntrack(procRoot, totals, rates),
+		Timestamp: time.Now(),
+	}
+}
+
+// readSnmpFile 解析 /proc/net/snmp 和 /proc/net/netstat
+// 格式为成对的行：第一行是字段名，第二行是对应的值，键名为 "协议.字段"
+func readSnmpFile(path string) map[string]uint64 {
+	counters := make(map[string]uint64)
+
+	file, err := os.Open(path)
+	if err != nil {
+		return counters
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var header []string
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+
+		if header == nil || header[0] != fields[0] {
+			header = fields
+			continue
+		}
+
+		proto := strings.TrimSuffix(fields[0], ":")
+		for i := 1; i < len(fields) && i < len(header); i++ {
+			// 部分字段可能为负数（如 Tcp MaxConn 为 -1），忽略
+			if n, err := strconv.ParseUint(fields[i], 10, 64); err == nil {
+				counters[proto+"."+header[i]] = n
+			}
+		}
+		header = nil
+	}
+
+	return counters
+}
+
+// readConntrackStat 解析 /proc/net/stat/nf_conntrack（每个 CPU 一行，十六进制），返回各项合计
+func readConntrackStat(path string) map[string]uint64 {
+	counters := make(map[string]uint64)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return counters
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) < 2 {
+		return counters
+	}
+
+	header := strings.Fields(lines[0])
+	for _, line := range lines[1:] {
+		fields := strings.Fields(line)
+		for i := 0; i < len(fields) && i < len(header); i++ {
+			// entries 是全局值，每行都相同，不能累加
+			if header[i] == "entries" {
+				continue
+			}
+			if n, err := strconv.ParseUint(fields[i], 16, 64); err == nil {
+				counters["Conntrack."+header[i]] += n
+			}
+		}
+	}
+
+	return counters
+}
+
+// readConntrack 读取 conntrack 表使用率（未加载 nf_conntrack 模块时不可用）
+func readConntrack(procRoot string, totals map[string]uint64, rates map[string]float64) ConntrackStats {
+	dir := filepath.Join(procRoot, "sys", "net", "netfilter")
+	count, ok := readSysInt(filepath.Join(dir, "nf_conntrack_count"))
+	if !ok {
+		return ConntrackStats{}
+	}
+	limit, _ := readSysInt(filepath.Join(dir, "nf_conntrack_max"))
+
+	stats := ConntrackStats{
+		Available:    true,
+		Count:        uint64(count),
+		Max:          uint64(limit),
+		Drop:         ProtocolCounter{Total: totals["Conntrack.drop"], PerSec: rates["Conntrack.drop"]},
+		EarlyDrop:    ProtocolCounter{Total: totals["Conntrack.early_drop"], PerSec: rates["Conntrack.early_drop"]},
+		InsertFailed: ProtocolCounter{Total: totals["Conntrack.insert_failed"], PerSec: rates["Conntrack.insert_failed"]},
+	}
+	if limit > 0 {
+		stats.UsedPercent = float64(count) / float64(limit) * 100
+	}
+
+	return stats
+}
@@ -0,0 +1,63 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeProtocolCounters 写入 /proc/net 下的协议计数器
+func writeProtocolCounters(t *testing.T, procRoot string, outSegs, retransSegs, drop uint64) {
+	t.Helper()
+	writeTestFiles(t, procRoot, map[string]string{
+		"net/snmp": "Tcp: RtoAlgorithm ActiveOpens CurrEstab OutSegs RetransSegs MaxConn\n" +
+			fmt.Sprintf("Tcp: 1 100 12 %d %d -1\n", outSegs, retransSegs) +
+			"Udp: InDatagrams OutDatagrams NoPorts\nUdp: 500 400 3\n",
+		"net/netstat":                          "TcpExt: ListenOverflows ListenDrops\nTcpExt: 0 0\n",
+		"net/stat/nf_conntrack":                fmt.Sprintf("entries drop early_drop\n00000010 %08x 00000000\n00000010 00000000 00000000\n", drop),
+		"sys/net/netfilter/nf_conntrack_count": "16\n",
+		"sys/net/netfilter/nf_conntrack_max":   "64\n",
+	})
+}
+
+func TestGetProtocolStatsFromRates(t *testing.T) {
+	protocolSampler.mu.Lock()
+	protocolSampler.last, protocolSampler.lastRates = nil, nil
+	protocolSampler.mu.Unlock()
+
+	procRoot := filepath.Join(t.TempDir(), "proc")
+	writeProtocolCounters(t, procRoot, 10000, 10, 0)
+
+	stats := GetProtocolStatsFrom(context.Background(), procRoot)
+	if !stats.Available || stats.TCP.CurrEstab != 12 || stats.UDP.NoPorts.Total != 3 {
+		t.Fatalf("stats = %+v", stats)
+	}
+	if !stats.Conntrack.Available || stats.Conntrack.UsedPercent != 25 {
+		t.Errorf("conntrack = %+v", stats.Conntrack)
+	}
+
+	// 后台采样刚结束时另一个请求读取：一次重传和一次丢包不能被放大成高速率
+	writeProtocolCounters(t, procRoot, 10001, 11, 1)
+	stats = GetProtocolStatsFrom(context.Background(), procRoot)
+	if stats.TCP.RetransSegs.Total != 11 || stats.Conntrack.Drop.Total != 1 {
+		t.Errorf("totals = %d retrans, %d drop, want 11, 1", stats.TCP.RetransSegs.Total, stats.Conntrack.Drop.Total)
+	}
+	if stats.TCP.RetransSegs.PerSec != 0 || stats.TCP.RetransPercent != 0 || stats.Conntrack.Drop.PerSec != 0 {
+		t.Errorf("rates within window = %v retrans/s (%v%%), %v drop/s",
+			stats.TCP.RetransSegs.PerSec, stats.TCP.RetransPercent, stats.Conntrack.Drop.PerSec)
+	}
+
+	// 超过最短窗口后按实际间隔计算
+	protocolSampler.mu.Lock()
+	protocolSampler.at = protocolSampler.at.Add(-2 * time.Second)
+	protocolSampler.mu.Unlock()
+	stats = GetProtocolStatsFrom(context.Background(), procRoot)
+	if got := stats.TCP.RetransSegs.PerSec; got <= 0 || got > 0.5 {
+		t.Errorf("retrans rate = %v, want about 0.5/s", got)
+	}
+	if got := stats.Conntrack.Drop.PerSec; got <= 0 || got > 0.5 {
+		t.Errorf("drop rate = %v, want about 0.5/s", got)
+	}
+}
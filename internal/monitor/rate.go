@@ -0,0 +1,63 @@
+package monitor
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// rateBaselineInterval 首次计算速率时获取基线的等待时间
+const rateBaselineInterval = 500 * time.Millisecond
+
+// minRateWindow 计算速率的最短时间窗口
+// 采样器由后台采样和 HTTP 请求共用，间隔过短时返回上一次的速率，
+// 避免几毫秒内的一次重传或丢包被放大成每秒上百次
+const minRateWindow = time.Second
+
+// counterSampler 保存上一次的累计计数器，用于计算每秒速率
+type counterSampler struct {
+	mu        sync.Mutex
+	last      map[string]uint64
+	at        time.Time
+	lastRates map[string]float64
+}
+
+// rates 读取计数器并返回自上次计算以来的每秒速率
+// 首次调用时没有历史数据，会短暂等待以获得基线（ctx 取消时返回空结果，下次调用再计算）；
+// 距上次计算不足 minRateWindow 时不读取计数器，直接返回上一次的结果（调用方不能修改返回的 map）
+func (s *counterSampler) rates(ctx context.Context, read func() map[string]uint64) map[string]float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.lastRates != nil && time.Since(s.at) < minRateWindow {
+		return s.lastRates
+	}
+
+	if s.last == nil {
+		s.last = read()
+		s.at = time.Now()
+		if sleepContext(ctx, rateBaselineInterval) != nil {
+			return map[string]float64{}
+		}
+	}
+
+	current := read()
+	now := time.Now()
+	elapsed := now.Sub(s.at).Seconds()
+
+	rates := make(map[string]float64, len(current))
+	for key, value := range current {
+		prev, ok := s.last[key]
+		// 计数器重置或回绕时跳过
+		if !ok || value < prev || elapsed <= 0 {
+			continue
+		}
+		rates[key] = float64(value-prev) / elapsed
+	}
+
+	s.last = current
+	s.at = now
+	s.lastRates = rates
+
+	return rates
+}
@@ -0,0 +1,63 @@
+package monitor
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestCounterSamplerRates(t *testing.T) {
+	var sampler counterSampler
+	counters := map[string]uint64{"pgfault": 1000, "pswpin": 50}
+	reads := 0
+	read := func() map[string]uint64 {
+		reads++
+		snapshot := make(map[string]uint64, len(counters))
+		for key, value := range counters {
+			snapshot[key] = value
+		}
+		return snapshot
+	}
+
+	// 首次调用等待基线，两次读取之间没有变化
+	rates := sampler.rates(context.Background(), read)
+	if reads != 2 || rates["pgfault"] != 0 {
+		t.Fatalf("first call: reads = %d, rates = %v", reads, rates)
+	}
+
+	// 另一个调用方紧接着采样：不读取计数器，沿用上一次的结果
+	counters["pgfault"] += 10
+	rates = sampler.rates(context.Background(), read)
+	if reads != 2 || rates["pgfault"] != 0 {
+		t.Errorf("call within window: reads = %d, rates = %v", reads, rates)
+	}
+
+	// 超过最短窗口后按实际间隔计算，窗口内的增量没有丢失
+	sampler.at = sampler.at.Add(-2 * time.Second)
+	counters["pgfault"] += 10
+	counters["pswpin"] = 10
+	rates = sampler.rates(context.Background(), read)
+	if reads != 3 {
+		t.Fatalf("call after window: reads = %d", reads)
+	}
+	if got := rates["pgfault"]; got < 9 || got > 10 {
+		t.Errorf("pgfault rate = %v, want about 10/s", got)
+	}
+	if _, ok := rates["pswpin"]; ok {
+		t.Errorf("counter reset reported as rate %v", rates["pswpin"])
+	}
+}
+
+func TestCounterSamplerBaselineCanceled(t *testing.T) {
+	var sampler counterSampler
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	read := func() map[string]uint64 { return map[string]uint64{"pgfault": 1} }
+	if rates := sampler.rates(ctx, read); len(rates) != 0 {
+		t.Errorf("rates = %v, want empty", rates)
+	}
+	if sampler.lastRates != nil {
+		t.Error("canceled baseline cached as a result")
+	}
+}
@@ -0,0 +1,498 @@
+package monitor
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxScrapeSize 单次抓取的响应体最大字节数
+const maxScrapeSize = 16 << 20
+
+// scrapeRateWindow 首次抓取时两次抓取的间隔，用于立即得到速率（与 CPU 使用率的采样方式相同）
+const scrapeRateWindow = time.Second
+
+// scrapeSlack 判断目标是否到期时允许的提前量，避免采集器调度的抖动导致跳过一个周期
+const scrapeSlack = time.Second
+
+// scrapeAccept 请求 Prometheus 文本格式（0.0.4）
+const scrapeAccept = "text/plain;version=0.0.4;q=1,*/*;q=0.1"
+
+// 固定序列的取值方式
+const (
+	PinValue    = "value"    // gauge 或累计值
+	PinRate     = "rate"     // counter 的每秒速率
+	PinAverage  = "average"  // 直方图或摘要在抓取间隔内的平均值
+	PinQuantile = "quantile" // 直方图或摘要的分位数
+)
+
+// Selector 序列选择器：指标名加上必须相等的标签，如 http_requests_total{code="500"}
+// 指标名可以是直方图和摘要的指标族名
+type Selector struct {
+	Name   string
+	Labels map[string]string
+}
+
+// ParseSelector 解析 name 或 name{label="value",...} 形式的选择器
+func ParseSelector(s string) (Selector, error) {
+	s = strings.TrimSpace(s)
+	name, rest, hasLabels := strings.Cut(s, "{")
+	sel := Selector{Name: strings.TrimSpace(name)}
+	if !validMetricName(sel.Name) {
+		return sel, fmt.Errorf("无效的指标名 %q", sel.Name)
+	}
+	if !hasLabels {
+		return sel, nil
+	}
+
+	labels, n, err := parseLabels("{" + rest)
+	if err != nil {
+		return sel, err
+	}
+	if strings.TrimSpace(rest[n-1:]) != "" {
+		return sel, fmt.Errorf("} 之后有多余的内容")
+	}
+	if len(labels) > 0 {
+		sel.Labels = labels
+	}
+	return sel, nil
+}
+
+// String 返回选择器的文本形式（标签按名称排序）
+func (s Selector) String() string {
+	return Metric{Name: s.Name, Labels: s.Labels}.Series()
+}
+
+// matches 样本名或其指标族名与选择器相同，且包含选择器的所有标签
+func (s Selector) matches(m Metric) bool {
+	if m.Name != s.Name && MetricFamily(m) != s.Name {
+		return false
+	}
+	for name, value := range s.Labels {
+		if m.Labels[name] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// Pin 固定显示在仪表盘和 Web 面板中的序列
+type Pin struct {
+	Selector Selector
+	// 显示名称，见 DisplayTitle
+	Title string
+	// counter 显示累计值而不换算为速率
+	Raw bool
+	// 直方图或摘要显示该分位数（0-1），为 0 时显示平均值
+	Quantile float64
+}
+
+// DisplayTitle 显示名称，未设置 Title 时为选择器（分位数加上 p95 等后缀）
+func (p Pin) DisplayTitle() string {
+	if p.Title != "" {
+		return p.Title
+	}
+	if p.Quantile > 0 {
+		return p.Selector.String() + " p" + strconv.FormatFloat(p.Quantile*100, 'g', 4, 64)
+	}
+	return p.Selector.String()
+}
+
+// ScrapeTarget 抓取目标
+type ScrapeTarget struct {
+	Name string
+	// Prometheus 文本格式的指标地址，如 http://127.0.0.1:8080/metrics
+	URL      string
+	Interval time.Duration
+	// 单次请求的超时
+	Timeout time.Duration
+	Pins    []Pin
+}
+
+// ScrapeInfo 所有抓取目标的最新结果
+type ScrapeInfo struct {
+	Targets []ScrapeTargetInfo
+}
+
+// ScrapeTargetInfo 单个抓取目标最近一次抓取的结果
+type ScrapeTargetInfo struct {
+	Name string
+	URL  string
+	Up   bool
+	// 抓取失败的原因
+	Error       string
+	DurationMs  float64
+	SampleCount int
+	Pins        []PinnedValue
+	Timestamp   time.Time
+}
+
+// PinnedValue 固定序列的当前值
+type PinnedValue struct {
+	Title    string
+	Selector string
+	Type     MetricType
+	// 取值方式：value、rate（每秒）、average、quantile
+	Kind string
+	// Kind 为 quantile 时的分位数
+	Quantile float64
+	Value    float64
+	// 无法计算的原因（没有匹配的序列、等待下一次抓取等），此时 Value 无意义
+	Error string
+}
+
+// scrapeState 抓取目标的状态，保留上一次的样本用于计算速率
+type scrapeState struct {
+	target ScrapeTarget
+	// 上一次抓取成功的样本和时间
+	prev   []Metric
+	prevAt time.Time
+	// 最近一次开始抓取的时间
+	last time.Time
+	info ScrapeTargetInfo
+}
+
+// scrapeCollector 按各自间隔抓取所有目标的采集器
+type scrapeCollector struct {
+	interval time.Duration
+	client   *http.Client
+
+	mu     sync.Mutex
+	states []*scrapeState
+}
+
+// NewScrapeCollector 创建抓取本机服务 Prometheus 指标的采集器（名称为 scrape）
+// 采集间隔为各目标间隔的最小值，未到期的目标沿用上一次的结果
+func NewScrapeCollector(targets []ScrapeTarget) Collector {
+	c := &scrapeCollector{client: &http.Client{}}
+	for _, t := range targets {
+		if c.interval == 0 || t.Interval < c.interval {
+			c.interval = t.Interval
+		}
+		c.states = append(c.states, &scrapeState{
+			target: t,
+			info:   ScrapeTargetInfo{Name: t.Name, URL: t.URL, Error: "尚未抓取"},
+		})
+	}
+	return c
+}
+
+func (c *scrapeCollector) Name() string            { return "scrape" }
+func (c *scrapeCollector) Interval() time.Duration { return c.interval }
+
+func (c *scrapeCollector) Collect(ctx context.Context) Sample {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	var wg sync.WaitGroup
+	for _, state := range c.states {
+		if !state.last.IsZero() && now.Sub(state.last)+scrapeSlack < state.target.Interval {
+			continue
+		}
+		state.last = now
+		wg.Add(1)
+		go func(state *scrapeState) {
+			defer wg.Done()
+			c.scrapeTarget(ctx, state)
+		}(state)
+	}
+	wg.Wait()
+
+	info := ScrapeInfo{Targets: make([]ScrapeTargetInfo, 0, len(c.states))}
+	for _, state := range c.states {
+		info.Targets = append(info.Targets, state.info)
+	}
+	return Sample{Info: info, Metrics: scrapeMetrics(info)}
+}
+
+// scrapeTarget 抓取一个目标并计算固定序列；首次抓取且需要速率时间隔 scrapeRateWindow 再抓取一次
+func (c *scrapeCollector) scrapeTarget(ctx context.Context, state *scrapeState) {
+	if state.prev == nil && len(state.target.Pins) > 0 {
+		if metrics, err := c.scrape(ctx, state.target); err == nil {
+			state.prev, state.prevAt = metrics, time.Now()
+			sleepContext(ctx, scrapeRateWindow)
+		}
+	}
+
+	start := time.Now()
+	metrics, err := c.scrape(ctx, state.target)
+	info := ScrapeTargetInfo{
+		Name:       state.target.Name,
+		URL:        state.target.URL,
+		DurationMs: float64(time.Since(start).Microseconds()) / 1000,
+		Timestamp:  start,
+	}
+	if err != nil {
+		info.Error = err.Error()
+		state.info = info
+		return
+	}
+
+	info.Up = true
+	info.SampleCount = len(metrics)
+	elapsed := start.Sub(state.prevAt).Seconds()
+	for _, pin := range state.target.Pins {
+		info.Pins = append(info.Pins, EvaluatePin(pin, metrics, state.prev, elapsed))
+	}
+	state.prev, state.prevAt = metrics, start
+	state.info = info
+}
+
+// scrape 请求目标并解析响应
+func (c *scrapeCollector) scrape(ctx context.Context, target ScrapeTarget) ([]Metric, error) {
+	if target.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, target.Timeout)
+		defer cancel()
+	}
+	return Scrape(ctx, c.client, target.URL)
+}
+
+// Scrape 抓取一个 Prometheus 文本格式的指标地址，client 为 nil 时使用 http.DefaultClient
+func Scrape(ctx context.Context, client *http.Client, url string) ([]Metric, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", scrapeAccept)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxScrapeSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(body) > maxScrapeSize {
+		return nil, fmt.Errorf("响应超过 %d MiB", maxScrapeSize>>20)
+	}
+	return ParsePrometheusText(bytes.NewReader(body))
+}
+
+// EvaluatePin 计算固定序列的当前值，prev 为 elapsed 秒之前的样本（没有时为 nil）
+// 匹配多个序列时，gauge、累计值和速率求和（同 PromQL 的 sum），直方图合并所有序列的桶
+func EvaluatePin(pin Pin, metrics, prev []Metric, elapsed float64) PinnedValue {
+	v := PinnedValue{Title: pin.DisplayTitle(), Selector: pin.Selector.String(), Kind: PinValue}
+
+	var matched []Metric
+	family := false
+	for _, m := range metrics {
+		if pin.Selector.matches(m) {
+			matched = append(matched, m)
+			// 选择器是直方图或摘要的指标族名（而不是其中的 _count 等样本名）
+			if (m.Type == MetricHistogram || m.Type == MetricSummary) && MetricFamily(m) == pin.Selector.Name {
+				family = true
+			}
+		}
+	}
+	if len(matched) == 0 {
+		v.Error = "没有匹配的序列"
+		return v
+	}
+	v.Type = matched[0].Type
+
+	if family {
+		if pin.Quantile > 0 {
+			v.Kind, v.Quantile = PinQuantile, pin.Quantile
+			v.Value, v.Error = familyQuantile(pin, matched, prev)
+		} else {
+			v.Kind = PinAverage
+			v.Value, v.Error = familyAverage(pin, matched, prev)
+		}
+		return v
+	}
+
+	cumulative := v.Type == MetricCounter || v.Type == MetricHistogram || v.Type == MetricSummary
+	if !cumulative || pin.Raw {
+		for _, m := range matched {
+			v.Value += m.Value
+		}
+		return v
+	}
+
+	v.Kind = PinRate
+	if prev == nil || elapsed <= 0 {
+		v.Error = "等待下一次抓取以计算速率"
+		return v
+	}
+	v.Value = increase(matched, prev) / elapsed
+	return v
+}
+
+// increase 各序列相对上一次样本的增量之和，上一次没有的序列不计入，计数器重置时以当前值为增量
+func increase(metrics, prev []Metric) float64 {
+	previous := make(map[string]float64, len(prev))
+	for _, m := range prev {
+		previous[m.Series()] = m.Value
+	}
+
+	total := 0.0
+	for _, m := range metrics {
+		before, ok := previous[m.Series()]
+		if !ok {
+			continue
+		}
+		if m.Value < before {
+			total += m.Value
+		} else {
+			total += m.Value - before
+		}
+	}
+	return total
+}
+
+// familyAverage 抓取间隔内的平均观测值：increase(_sum) / increase(_count)
+func familyAverage(pin Pin, matched, prev []Metric) (float64, string) {
+	if prev == nil {
+		return 0, "等待下一次抓取以计算平均值"
+	}
+	sum := increase(withName(matched, pin.Selector.Name+"_sum"), prev)
+	count := increase(withName(matched, pin.Selector.Name+"_count"), prev)
+	if count == 0 {
+		return 0, "抓取间隔内没有新的观测值"
+	}
+	return sum / count, ""
+}
+
+// familyQuantile 直方图按抓取间隔内的桶增量估算分位数（同 histogram_quantile），摘要直接读取分位数样本
+func familyQuantile(pin Pin, matched, prev []Metric) (float64, string) {
+	if matched[0].Type == MetricSummary {
+		var found []Metric
+		for _, m := range matched {
+			if q, err := strconv.ParseFloat(m.Labels["quantile"], 64); err == nil && q == pin.Quantile {
+				found = append(found, m)
+			}
+		}
+		switch len(found) {
+		case 0:
+			return 0, fmt.Sprintf("摘要没有 %g 分位数", pin.Quantile)
+		case 1:
+			return found[0].Value, ""
+		}
+		return 0, "匹配了多个摘要，分位数不能相加，请用标签缩小范围"
+	}
+
+	if prev == nil {
+		return 0, "等待下一次抓取以计算分位数"
+	}
+	previous := make(map[string]float64, len(prev))
+	for _, m := range prev {
+		previous[m.Series()] = m.Value
+	}
+	buckets := make(map[float64]float64)
+	for _, m := range withName(matched, pin.Selector.Name+"_bucket") {
+		le, err := parseMetricValue(m.Labels["le"])
+		if err != nil {
+			continue
+		}
+		before, ok := previous[m.Series()]
+		if !ok {
+			continue
+		}
+		if m.Value < before {
+			before = 0
+		}
+		buckets[le] += m.Value - before
+	}
+	q, ok := histogramQuantile(pin.Quantile, buckets)
+	if !ok {
+		return 0, "抓取间隔内没有新的观测值"
+	}
+	return q, ""
+}
+
+// histogramQuantile 在累计桶计数中线性插值估算分位数，落在 +Inf 桶时返回最大的有限上界
+func histogramQuantile(q float64, buckets map[float64]float64) (float64, bool) {
+	bounds := make([]float64, 0, len(buckets))
+	for le := range buckets {
+		bounds = append(bounds, le)
+	}
+	sort.Float64s(bounds)
+	if len(bounds) == 0 || !math.IsInf(bounds[len(bounds)-1], 1) {
+		return 0, false
+	}
+	total := buckets[bounds[len(bounds)-1]]
+	if total <= 0 {
+		return 0, false
+	}
+
+	rank := q * total
+	lower, lowerCount := 0.0, 0.0
+	for i, le := range bounds {
+		count := buckets[le]
+		if count < rank {
+			lower, lowerCount = le, count
+			continue
+		}
+		if math.IsInf(le, 1) {
+			if i == 0 {
+				return 0, false
+			}
+			return bounds[i-1], true
+		}
+		if i == 0 && le <= 0 {
+			return le, true
+		}
+		return lower + (le-lower)*(rank-lowerCount)/(count-lowerCount), true
+	}
+	return bounds[len(bounds)-1], true
+}
+
+// withName 筛选指定样本名的样本
+func withName(metrics []Metric, name string) []Metric {
+	var found []Metric
+	for _, m := range metrics {
+		if m.Name == name {
+			found = append(found, m)
+		}
+	}
+	return found
+}
+
+func scrapeMetrics(info ScrapeInfo) []Metric {
+	var metrics []Metric
+	for _, t := range info.Targets {
+		up := 0.0
+		if t.Up {
+			up = 1
+		}
+		metrics = append(metrics,
+			gauge("syspulse_scrape_up", "抓取目标是否可用", up, "target", t.Name),
+			gauge("syspulse_scrape_duration_seconds", "抓取耗时", t.DurationMs/1000, "target", t.Name),
+			gauge("syspulse_scrape_samples", "抓取到的样本数", float64(t.SampleCount), "target", t.Name),
+		)
+		for _, p := range t.Pins {
+			if p.Error != "" {
+				continue
+			}
+			// 同一选择器可以按不同方式固定多次（速率、原始值、不同分位数），用 selector/kind/quantile 区分
+			labels := []string{"target", t.Name, "pin", p.Title, "selector", p.Selector, "kind", p.Kind}
+			if p.Kind == PinQuantile {
+				labels = append(labels, "quantile", strconv.FormatFloat(p.Quantile, 'g', -1, 64))
+			}
+			metrics = append(metrics, gauge("syspulse_pinned_value", "固定序列的当前值（counter 为每秒速率）", p.Value, labels...))
+		}
+	}
+	return metrics
+}
@@ -0,0 +1,75 @@
+package monitor
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestScrapeMetricsPinnedLabels(t *testing.T) {
+	info := ScrapeInfo{Targets: []ScrapeTargetInfo{{
+		Name: "app",
+		Up:   true,
+		Pins: []PinnedValue{
+			{Title: "latency p50", Selector: `http_request_duration_seconds{job="api"}`, Kind: PinQuantile, Quantile: 0.5, Value: 0.1},
+			{Title: "latency p95", Selector: `http_request_duration_seconds{job="api"}`, Kind: PinQuantile, Quantile: 0.95, Value: 0.4},
+			{Title: "requests", Selector: "http_requests_total", Kind: PinRate, Value: 12},
+			{Title: "requests total", Selector: "http_requests_total", Kind: PinValue, Value: 3400},
+			{Title: "waiting", Selector: "jobs_total", Kind: PinRate, Error: "等待下一次抓取"},
+		},
+	}}}
+
+	var buf bytes.Buffer
+	if err := WritePrometheusText(&buf, scrapeMetrics(info)); err != nil {
+		t.Fatal(err)
+	}
+	// 导出的文本必须能被重新解析，且每个序列唯一
+	metrics, err := ParsePrometheusText(&buf)
+	if err != nil {
+		t.Fatalf("exported text does not parse: %v\n%s", err, buf.String())
+	}
+
+	pinned := make(map[string]Metric)
+	series := make(map[string]bool)
+	for _, m := range metrics {
+		if m.Name != "syspulse_pinned_value" {
+			continue
+		}
+		if series[m.Series()] {
+			t.Errorf("duplicate series %s", m.Series())
+		}
+		series[m.Series()] = true
+		pinned[m.Labels["pin"]] = m
+	}
+
+	tests := []struct {
+		pin      string
+		selector string
+		kind     string
+		quantile string
+		value    float64
+	}{
+		{pin: "latency p50", selector: `http_request_duration_seconds{job="api"}`, kind: PinQuantile, quantile: "0.5", value: 0.1},
+		{pin: "latency p95", selector: `http_request_duration_seconds{job="api"}`, kind: PinQuantile, quantile: "0.95", value: 0.4},
+		{pin: "requests", selector: "http_requests_total", kind: PinRate, value: 12},
+		{pin: "requests total", selector: "http_requests_total", kind: PinValue, value: 3400},
+	}
+
+	if len(pinned) != len(tests) {
+		t.Fatalf("got %d pinned series, want %d (pins with errors are not exported)", len(pinned), len(tests))
+	}
+	for _, tt := range tests {
+		t.Run(tt.pin, func(t *testing.T) {
+			m, ok := pinned[tt.pin]
+			if !ok {
+				t.Fatal("series missing")
+			}
+			labels := m.Labels
+			if labels["target"] != "app" || labels["selector"] != tt.selector || labels["kind"] != tt.kind || labels["quantile"] != tt.quantile {
+				t.Errorf("labels = %v", labels)
+			}
+			if m.Value != tt.value {
+				t.Errorf("value = %v, want %v", m.Value, tt.value)
+			}
+		})
+	}
+}
@@ -0,0 +1,260 @@
+package monitor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultSysRoot sysfs 挂载点
+const DefaultSysRoot = "/sys"
+
+// raplSampleInterval 计算 RAPL 功耗的采样间隔
+const raplSampleInterval = 500 * time.Millisecond
+
+// 温度传感器类型
+const (
+	SensorCPUPackage  = "cpu_package"
+	SensorCPUCore     = "cpu_core"
+	SensorNVMe        = "nvme"
+	SensorThermalZone = "thermal_zone"
+	SensorOther       = "other"
+)
+
+// GetSensorsInfo 获取温度、风扇和功耗传感器数据（hwmon、thermal zone、RAPL）
+func GetSensorsInfo(ctx context.Context) SensorsInfo {
+	return GetSensorsInfoFrom(ctx, DefaultSysRoot)
+}
+
+// GetSensorsInfoFrom 从指定的 sysfs 目录读取传感器数据
+func GetSensorsInfoFrom(ctx context.Context, sysRoot string) SensorsInfo {
+	temps, fans := readHwmon(sysRoot)
+	// x86 上温区与 hwmon（acpitz、x86_pkg_temp 等）读的是同一批传感器，只在 hwmon 没有温度时使用
+	if len(temps) == 0 {
+		temps = readThermalZones(sysRoot)
+	}
+	power := readRAPLPower(ctx, sysRoot)
+
+	return SensorsInfo{
+		Available:    len(temps) > 0 || len(fans) > 0 || len(power) > 0,
+		Temperatures: temps,
+		Fans:         fans,
+		Power:        power,
+		Timestamp:    time.Now(),
+	}
+}
+
+// readCPUTemperature 读取 CPU 温度（取 package 或最高核心温度），不可用时返回 0
+func readCPUTemperature(sysRoot string) float64 {
+	temps, _ := readHwmon(sysRoot)
+	return maxTemperature(temps, SensorCPUPackage, SensorCPUCore)
+}
+
+// maxTemperature 返回指定类型传感器中的最高温度
+func maxTemperature(temps []TemperatureSensor, kinds ...string) float64 {
+	max := 0.0
+	for _, t := range temps {
+		for _, kind := range kinds {
+			if t.Kind == kind && t.Celsius > max {
+				max = t.Celsius
+			}
+		}
+	}
+	return max
+}
+
+// readHwmon 读取 /sys/class/hwmon 下的温度和风扇传感器
+func readHwmon(sysRoot string) ([]TemperatureSensor, []FanSensor) {
+	var temps []TemperatureSensor
+	var fans []FanSensor
+
+	dirs, _ := filepath.Glob(filepath.Join(sysRoot, "class", "hwmon", "hwmon*"))
+	sort.Strings(dirs)
+
+	for _, dir := range dirs {
+		chip := readSysString(filepath.Join(dir, "name"))
+
+		inputs, _ := filepath.Glob(filepath.Join(dir, "temp*_input"))
+		sort.Strings(inputs)
+		for _, input := range inputs {
+			prefix := strings.TrimSuffix(input, "_input")
+			milli, ok := readSysInt(input)
+			if !ok {
+				continue
+			}
+
+			label := readSysString(prefix + "_label")
+			if label == "" {
+				label = filepath.Base(prefix)
+			}
+
+			sensor := TemperatureSensor{
+				Chip:    chip,
+				Label:   label,
+				Kind:    classifyTemperature(chip, label),
+				Celsius: float64(milli) / 1000,
+			}
+			if v, ok := readSysInt(prefix + "_max"); ok {
+				sensor.High = float64(v) / 1000
+			}
+			if v, ok := readSysInt(prefix + "_crit"); ok {
+				sensor.Critical = float64(v) / 1000
+			}
+			temps = append(temps, sensor)
+		}
+
+		fanInputs, _ := filepath.Glob(filepath.Join(dir, "fan*_input"))
+		sort.Strings(fanInputs)
+		for _, input := range fanInputs {
+			prefix := strings.TrimSuffix(input, "_input")
+			rpm, ok := readSysInt(input)
+			if !ok {
+				continue
+			}
+
+			label := readSysString(prefix + "_label")
+			if label == "" {
+				label = filepath.Base(prefix)
+			}
+
+			fans = append(fans, FanSensor{
+				Chip:  chip,
+				Label: label,
+				RPM:   uint64(rpm),
+			})
+		}
+	}
+
+	return temps, fans
+}
+
+// classifyTemperature 根据驱动名和标签判断传感器类型
+func classifyTemperature(chip, label string) string {
+	switch chip {
+	case "coretemp":
+		if strings.HasPrefix(label, "Package") {
+			return SensorCPUPackage
+		}
+		return SensorCPUCore
+	case "k10temp", "zenpower":
+		// AMD 的 Tctl/Tdie 相当于 package 温度，Tccd 为各 CCD 温度
+		if strings.HasPrefix(label, "Tccd") {
+			return SensorCPUCore
+		}
+		return SensorCPUPackage
+	case "cpu_thermal", "cpu-thermal":
+		return SensorCPUPackage
+	case "nvme":
+		return SensorNVMe
+	default:
+		return SensorOther
+	}
+}
+
+// readThermalZones 读取 /sys/class/thermal 下的温区（hwmon 缺失时的补充，例如 ARM 设备）
+func readThermalZones(sysRoot string) []TemperatureSensor {
+	var temps []TemperatureSensor
+
+	zones, _ := filepath.Glob(filepath.Join(sysRoot, "class", "thermal", "thermal_zone*"))
+	sort.Strings(zones)
+
+	for _, zone := range zones {
+		milli, ok := readSysInt(filepath.Join(zone, "temp"))
+		if !ok {
+			continue
+		}
+
+		sensor := TemperatureSensor{
+			Chip:    filepath.Base(zone),
+			Label:   readSysString(filepath.Join(zone, "type")),
+			Kind:    SensorThermalZone,
+			Celsius: float64(milli) / 1000,
+		}
+
+		// 取类型为 critical 的触发点作为临界温度
+		trips, _ := filepath.Glob(filepath.Join(zone, "trip_point_*_type"))
+		for _, trip := range trips {
+			if readSysString(trip) != "critical" {
+				continue
+			}
+			if v, ok := readSysInt(strings.TrimSuffix(trip, "_type") + "_temp"); ok {
+				sensor.Critical = float64(v) / 1000
+			}
+		}
+
+		temps = append(temps, sensor)
+	}
+
+	return temps
+}
+
+// readRAPLPower 两次采样 RAPL energy_uj 计算功耗（需要 root 权限读取）
+func readRAPLPower(ctx context.Context, sysRoot string) []PowerSensor {
+	domains, _ := filepath.Glob(filepath.Join(sysRoot, "class", "powercap", "intel-rapl:*"))
+	sort.Strings(domains)
+
+	before := make(map[string]int64)
+	for _, domain := range domains {
+		if v, ok := readSysInt(filepath.Join(domain, "energy_uj")); ok {
+			before[domain] = v
+		}
+	}
+	if len(before) == 0 {
+		return nil
+	}
+
+	start := time.Now()
+	if sleepContext(ctx, raplSampleInterval) != nil {
+		return nil
+	}
+	elapsed := time.Since(start)
+
+	var power []PowerSensor
+	for _, domain := range domains {
+		prev, ok := before[domain]
+		if !ok {
+			continue
+		}
+		v, ok := readSysInt(filepath.Join(domain, "energy_uj"))
+		// 计数器回绕时跳过本次
+		if !ok || v < prev {
+			continue
+		}
+
+		name := readSysString(filepath.Join(domain, "name"))
+		if name == "" {
+			name = filepath.Base(domain)
+		}
+
+		power = append(power, PowerSensor{
+			Domain: name,
+			Watts:  float64(v-prev) / 1e6 / elapsed.Seconds(),
+		})
+	}
+
+	return power
+}
+
+func readSysString(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+func readSysInt(path string) (int64, bool) {
+	value := readSysString(path)
+	if value == "" {
+		return 0, false
+	}
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
@@ -0,0 +1,60 @@
+package monitor
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGetSensorsInfoThermalZones(t *testing.T) {
+	thermal := map[string]string{
+		"class/thermal/thermal_zone0/temp":              "45000",
+		"class/thermal/thermal_zone0/type":              "acpitz",
+		"class/thermal/thermal_zone0/trip_point_0_type": "critical",
+		"class/thermal/thermal_zone0/trip_point_0_temp": "105000",
+		"class/thermal/thermal_zone1/temp":              "52000",
+		"class/thermal/thermal_zone1/type":              "x86_pkg_temp",
+	}
+
+	t.Run("hwmon present", func(t *testing.T) {
+		root := t.TempDir()
+		writeTestFiles(t, root, thermal)
+		writeTestFiles(t, root, map[string]string{
+			"class/hwmon/hwmon0/name":        "coretemp",
+			"class/hwmon/hwmon0/temp1_input": "52000",
+			"class/hwmon/hwmon0/temp1_label": "Package id 0",
+			"class/hwmon/hwmon0/temp2_input": "50000",
+			"class/hwmon/hwmon0/temp2_label": "Core 0",
+			"class/hwmon/hwmon1/name":        "acpitz",
+			"class/hwmon/hwmon1/temp1_input": "45000",
+		})
+
+		info := GetSensorsInfoFrom(context.Background(), root)
+		if len(info.Temperatures) != 3 {
+			t.Fatalf("temperatures = %+v, want 3 hwmon sensors", info.Temperatures)
+		}
+		for _, sensor := range info.Temperatures {
+			if sensor.Kind == SensorThermalZone {
+				t.Errorf("thermal zone %s duplicated alongside hwmon", sensor.Label)
+			}
+		}
+	})
+
+	t.Run("thermal zones only", func(t *testing.T) {
+		root := t.TempDir()
+		writeTestFiles(t, root, thermal)
+		writeTestFiles(t, root, map[string]string{
+			// 只有风扇的 hwmon 不影响温区回退
+			"class/hwmon/hwmon0/name":       "pwmfan",
+			"class/hwmon/hwmon0/fan1_input": "1200",
+		})
+
+		info := GetSensorsInfoFrom(context.Background(), root)
+		if !info.Available || len(info.Fans) != 1 || len(info.Temperatures) != 2 {
+			t.Fatalf("info = %+v", info)
+		}
+		zone := info.Temperatures[0]
+		if zone.Kind != SensorThermalZone || zone.Chip != "thermal_zone0" || zone.Label != "acpitz" || zone.Celsius != 45 || zone.Critical != 105 {
+			t.Errorf("zone = %+v", zone)
+		}
+	})
+}
@@ -0,0 +1,229 @@
+package monitor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// smartctlTimeout 单个设备调用 smartctl 的超时时间
+const smartctlTimeout = 10 * time.Second
+
+// 不需要检查健康状态的块设备前缀（虚拟设备）
+var virtualBlockPrefixes = []string{"loop", "ram", "zram", "dm-", "md", "sr", "fd", "nbd"}
+
+// GetDriveHealth 获取所有块设备的 SMART / NVMe 健康信息（依赖 smartctl）
+func GetDriveHealth(ctx context.Context) DriveHealthInfo {
+	path, err := exec.LookPath("smartctl")
+	if err != nil {
+		return DriveHealthInfo{
+			Available: false,
+			Error:     "未找到 smartctl，请安装 smartmontools",
+			Timestamp: time.Now(),
+		}
+	}
+
+	var drives []DriveHealth
+	for _, device := range listBlockDevices(DefaultSysRoot) {
+		if ctx.Err() != nil {
+			drives = append(drives, DriveHealth{Device: device, Error: "采集超时"})
+			continue
+		}
+		output, runErr := runSmartctl(ctx, path, device)
+		// smartctl 的退出码是位掩码，磁盘有问题时也会返回非零，只要输出了 JSON 就解析
+		drive, err := ParseSmartctlJSON(output)
+		if err != nil {
+			if runErr != nil {
+				err = runErr
+			}
+			drives = append(drives, DriveHealth{Device: device, Error: err.Error()})
+			continue
+		}
+		if drive.Device == "" {
+			drive.Device = device
+		}
+		drives = append(drives, drive)
+	}
+
+	return DriveHealthInfo{
+		Available: true,
+		Drives:    drives,
+		Timestamp: time.Now(),
+	}
+}
+
+// listBlockDevices 列出 /sys/block 下的物理块设备
+func listBlockDevices(sysRoot string) []string {
+	entries, err := os.ReadDir(filepath.Join(sysRoot, "block"))
+	if err != nil {
+		return nil
+	}
+
+	var devices []string
+	for _, entry := range entries {
+		name := entry.Name()
+		virtual := false
+		for _, prefix := range virtualBlockPrefixes {
+			if strings.HasPrefix(name, prefix) {
+				virtual = true
+				break
+			}
+		}
+		if !virtual {
+			devices = append(devices, "/dev/"+name)
+		}
+	}
+
+	sort.Strings(devices)
+	return devices
+}
+
+func runSmartctl(ctx context.Context, path, device string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, smartctlTimeout)
+	defer cancel()
+
+	return exec.CommandContext(ctx, path, "--json", "--all", device).Output()
+}
+
+// smartctlOutput smartctl --json --all 输出中用到的字段
+type smartctlOutput struct {
+	Smartctl struct {
+		Messages []struct {
+			String   string `json:"string"`
+			Severity string `json:"severity"`
+		} `json:"messages"`
+	} `json:"smartctl"`
+	Device struct {
+		Name     string `json:"name"`
+		Protocol string `json:"protocol"`
+	} `json:"device"`
+	ModelName    string `json:"model_name"`
+	SerialNumber string `json:"serial_number"`
+	SmartStatus  *struct {
+		Passed bool `json:"passed"`
+	} `json:"smart_status"`
+	Temperature struct {
+		Current float64 `json:"current"`
+	} `json:"temperature"`
+	PowerOnTime struct {
+		Hours uint64 `json:"hours"`
+	} `json:"power_on_time"`
+	NVMeHealth *struct {
+		CriticalWarning uint64  `json:"critical_warning"`
+		Temperature     float64 `json:"temperature"`
+		AvailableSpare  float64 `json:"available_spare"`
+		PercentageUsed  float64 `json:"percentage_used"`
+		PowerOnHours    uint64  `json:"power_on_hours"`
+		UnsafeShutdowns uint64  `json:"unsafe_shutdowns"`
+		MediaErrors     uint64  `json:"media_errors"`
+	} `json:"nvme_smart_health_information_log"`
+	ATAAttributes *struct {
+		Table []struct {
+			ID    int    `json:"id"`
+			Name  string `json:"name"`
+			Value int    `json:"value"`
+			Raw   struct {
+				Value uint64 `json:"value"`
+			} `json:"raw"`
+		} `json:"table"`
+	} `json:"ata_smart_attributes"`
+}
+
+// ParseSmartctlJSON 解析 smartctl --json --all 的输出（可用于回放已保存的输出）
+func ParseSmartctlJSON(data []byte) (DriveHealth, error) {
+	var out smartctlOutput
+	if err := json.Unmarshal(data, &out); err != nil {
+		return DriveHealth{}, fmt.Errorf("解析 smartctl 输出失败: %w", err)
+	}
+
+	drive := DriveHealth{
+		Device:       out.Device.Name,
+		Protocol:     out.Device.Protocol,
+		Model:        out.ModelName,
+		Serial:       out.SerialNumber,
+		Temperature:  out.Temperature.Current,
+		PowerOnHours: out.PowerOnTime.Hours,
+	}
+
+	if out.SmartStatus == nil {
+		// 没有健康状态时通常是设备不支持 SMART，附上 smartctl 的错误信息
+		for _, msg := range out.Smartctl.Messages {
+			if msg.Severity == "error" {
+				return drive, fmt.Errorf("%s", msg.String)
+			}
+		}
+		drive.Error = "设备不支持 SMART"
+		return drive, nil
+	}
+	drive.Supported = true
+	drive.Passed = out.SmartStatus.Passed
+
+	// NVMe 健康日志
+	if nvme := out.NVMeHealth; nvme != nil {
+		drive.WearKnown = true
+		drive.PercentUsed = nvme.PercentageUsed
+		drive.AvailableSpare = nvme.AvailableSpare
+		drive.MediaErrors = nvme.MediaErrors
+		drive.CriticalWarning = nvme.CriticalWarning
+		drive.UnsafeShutdowns = nvme.UnsafeShutdowns
+		if drive.Temperature == 0 {
+			drive.Temperature = nvme.Temperature
+		}
+		if drive.PowerOnHours == 0 {
+			drive.PowerOnHours = nvme.PowerOnHours
+		}
+	}
+
+	// ATA SMART 属性
+	if ata := out.ATAAttributes; ata != nil {
+		for _, attr := range ata.Table {
+			switch attr.ID {
+			case 5: // Reallocated_Sector_Ct
+				drive.ReallocatedSectors = attr.Raw.Value
+			case 197: // Current_Pending_Sector
+				drive.PendingSectors = attr.Raw.Value
+			case 198: // Offline_Uncorrectable
+				drive.MediaErrors = attr.Raw.Value
+			case 177, 231, 233: // Wear_Leveling_Count / SSD_Life_Left / Media_Wearout_Indicator
+				// 归一化值从 100 递减，换算为已使用寿命
+				if !drive.WearKnown && attr.Value > 0 && attr.Value <= 100 {
+					drive.WearKnown = true
+					drive.PercentUsed = float64(100 - attr.Value)
+				}
+			}
+		}
+	}
+
+	drive.Issues = driveIssues(drive)
+	return drive, nil
+}
+
+// driveIssues 根据健康数据生成问题列表
+func driveIssues(d DriveHealth) []string {
+	var issues []string
+	if !d.Passed {
+		issues = append(issues, "SMART 自检未通过")
+	}
+	if d.CriticalWarning > 0 {
+		issues = append(issues, fmt.Sprintf("NVMe 严重警告标志 0x%x", d.CriticalWarning))
+	}
+	if d.ReallocatedSectors > 0 {
+		issues = append(issues, fmt.Sprintf("%d 个重映射扇区", d.ReallocatedSectors))
+	}
+	if d.PendingSectors > 0 {
+		issues = append(issues, fmt.Sprintf("%d 个待映射扇区", d.PendingSectors))
+	}
+	if d.MediaErrors > 0 {
+		issues = append(issues, fmt.Sprintf("%d 个介质错误", d.MediaErrors))
+	}
+	if d.WearKnown && d.PercentUsed >= 90 {
+		issues = append(issues, fmt.Sprintf("寿命已使用 %.0f%%", d.PercentUsed))
+	}
+	return issues
+}
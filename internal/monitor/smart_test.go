@@ -0,0 +1,106 @@
+package monitor
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseSmartctlJSON(t *testing.T) {
+	tests := []struct {
+		file    string
+		want    DriveHealth
+		wantErr string
+	}{
+		{
+			file: "nvme_healthy.json",
+			want: DriveHealth{
+				Device: "/dev/nvme0n1", Protocol: "NVMe", Model: "Samsung SSD 980 PRO 1TB", Serial: "S5GXNF0R123456",
+				Supported: true, Passed: true, Temperature: 41, PowerOnHours: 8123,
+				WearKnown: true, PercentUsed: 3, AvailableSpare: 100, UnsafeShutdowns: 27,
+			},
+		},
+		{
+			file: "nvme_critical.json",
+			want: DriveHealth{
+				Device: "/dev/nvme1n1", Protocol: "NVMe", Model: "INTEL SSDPEKNW512G8", Serial: "BTNH9123456789",
+				Supported: true, Temperature: 58, PowerOnHours: 30211,
+				WearKnown: true, PercentUsed: 97, AvailableSpare: 4, MediaErrors: 12, CriticalWarning: 4, UnsafeShutdowns: 140,
+				Issues: []string{"SMART 自检未通过", "NVMe 严重警告标志 0x4", "12 个介质错误", "寿命已使用 97%"},
+			},
+		},
+		{
+			file: "ata_failing.json",
+			want: DriveHealth{
+				Device: "/dev/sda", Protocol: "ATA", Model: "ST2000DM008-2FR102", Serial: "ZFL1ABCD",
+				Supported: true, Temperature: 36, PowerOnHours: 34512,
+				ReallocatedSectors: 1432, PendingSectors: 16, MediaErrors: 16,
+				Issues: []string{"SMART 自检未通过", "1432 个重映射扇区", "16 个待映射扇区", "16 个介质错误"},
+			},
+		},
+		{
+			file: "ata_ssd_worn.json",
+			want: DriveHealth{
+				Device: "/dev/sdb", Protocol: "ATA", Model: "Samsung SSD 860 EVO 500GB", Serial: "S3Z2NB0K654321",
+				Supported: true, Passed: true, Temperature: 32, PowerOnHours: 38102,
+				WearKnown: true, PercentUsed: 92,
+				Issues: []string{"寿命已使用 92%"},
+			},
+		},
+		{
+			file: "scsi_no_smart.json",
+			want: DriveHealth{
+				Device: "/dev/sdd", Protocol: "SCSI", Model: "QEMU QEMU HARDDISK",
+				Error: "设备不支持 SMART",
+			},
+		},
+		{
+			file:    "usb_unknown_bridge.json",
+			wantErr: "Unknown USB bridge",
+		},
+		{
+			file:    "truncated.json",
+			wantErr: "解析 smartctl 输出失败",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.TrimSuffix(tt.file, ".json"), func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join("testdata", "smartctl", tt.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := ParseSmartctlJSON(data)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got  %+v\nwant %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListBlockDevices(t *testing.T) {
+	root := t.TempDir()
+	for _, name := range []string{"sdb", "nvme0n1", "sda", "loop0", "dm-0", "zram0", "sr0", "md127", "vda"} {
+		if err := os.MkdirAll(filepath.Join(root, "block", name), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got := listBlockDevices(root)
+	want := []string{"/dev/nvme0n1", "/dev/sda", "/dev/sdb", "/dev/vda"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("listBlockDevices = %v, want %v", got, want)
+	}
+}
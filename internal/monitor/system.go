@@ -1,20 +1,32 @@
 package monitor
 
 import (
+	"context"
+	"os"
+	"runtime"
 	"time"
 
 	"github.com/shirou/gopsutil/v3/host"
 )
 
 // GetSystemInfo 获取系统基本信息
-func GetSystemInfo() SystemInfo {
-	info, _ := host.Info()
+// 逐项读取而不是使用 host.Info：后者任意一项（如 HostID）失败都会丢弃全部结果
+func GetSystemInfo(ctx context.Context) SystemInfo {
+	var errs FieldErrors
+
+	hostname, err := os.Hostname()
+	errs.add("Hostname", err)
+	kernel, err := host.KernelVersionWithContext(ctx)
+	errs.add("Kernel", err)
+	uptime, err := host.UptimeWithContext(ctx)
+	errs.add("Uptime", err)
 
 	return SystemInfo{
-		Hostname:  info.Hostname,
-		OS:        info.OS,
-		Kernel:    info.KernelVersion,
-		Uptime:    info.Uptime,
+		Hostname:  hostname,
+		OS:        runtime.GOOS,
+		Kernel:    kernel,
+		Uptime:    uptime,
+		Errors:    errs,
 		Timestamp: time.Now(),
 	}
 }
@@ -0,0 +1,307 @@
+package monitor
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// 频繁重启判定：flappingWindow 内重启次数达到 flappingRestarts
+const (
+	flappingRestarts = 3
+	flappingWindow   = 10 * time.Minute
+)
+
+// restartSample 某个服务在某一时刻的累计重启次数 (NRestarts)
+type restartSample struct {
+	at       time.Time
+	restarts uint32
+}
+
+// restartHistory 记录每个服务窗口内的重启次数采样
+var restartHistory = struct {
+	sync.Mutex
+	samples map[string][]restartSample
+}{
+	samples: make(map[string][]restartSample),
+}
+
+// SystemdUnit ListUnits 返回的单元状态
+type SystemdUnit struct {
+	Name        string
+	Description string
+	LoadState   string
+	ActiveState string
+	SubState    string
+	Path        dbus.ObjectPath
+}
+
+// SystemdServiceProperties org.freedesktop.systemd1.Service 的部分属性
+type SystemdServiceProperties struct {
+	MainPID      uint32
+	NRestarts    uint32
+	ControlGroup string
+}
+
+// SystemdBus 与 systemd 通信的接口，测试时可替换为 StaticSystemdBus
+type SystemdBus interface {
+	ListUnits() ([]SystemdUnit, error)
+	ServiceProperties(path dbus.ObjectPath) (SystemdServiceProperties, error)
+	Close() error
+}
+
+// GetServicesInfo 通过 D-Bus 获取 systemd 服务状态
+func GetServicesInfo(ctx context.Context) ServicesInfo {
+	bus, err := NewSystemdBus()
+	if err != nil {
+		return ServicesInfo{Available: false, Timestamp: time.Now()}
+	}
+	defer bus.Close()
+
+	return GetServicesInfoFrom(ctx, bus, DefaultCgroupRoot)
+}
+
+// GetServicesInfoFrom 从指定的 bus 和 cgroup 目录获取服务状态
+func GetServicesInfoFrom(ctx context.Context, bus SystemdBus, cgroupRoot string) ServicesInfo {
+	units, err := bus.ListUnits()
+	if err != nil {
+		return ServicesInfo{Available: false, Timestamp: time.Now()}
+	}
+
+	unifiedRoot, hasCgroup := findCgroupV2Root(cgroupRoot)
+
+	var services []ServiceInfo
+	for _, unit := range units {
+		if !strings.HasSuffix(unit.Name, ".service") || unit.LoadState == "not-found" {
+			continue
+		}
+
+		service := ServiceInfo{
+			Name:        unit.Name,
+			Description: unit.Description,
+			LoadState:   unit.LoadState,
+			ActiveState: unit.ActiveState,
+			SubState:    unit.SubState,
+		}
+
+		if props, err := bus.ServiceProperties(unit.Path); err == nil {
+			service.MainPID = props.MainPID
+			service.Restarts = props.NRestarts
+			service.ControlGroup = props.ControlGroup
+		}
+
+		if hasCgroup && service.ControlGroup != "" {
+			dir := filepath.Join(unifiedRoot, service.ControlGroup)
+			service.MemoryBytes, _ = readCgroupUint(filepath.Join(dir, "memory.current"))
+		}
+
+		service.Failed = service.ActiveState == "failed"
+
+		services = append(services, service)
+	}
+
+	recordRestarts(services, time.Now())
+
+	// 两次采样 cgroup 的 cpu.stat 计算 CPU 使用率
+	if hasCgroup {
+		applyServiceCPUPercent(ctx, services, unifiedRoot)
+	}
+
+	sortServices(services)
+
+	info := ServicesInfo{
+		Available:  true,
+		Services:   services,
+		TotalCount: len(services),
+		Timestamp:  time.Now(),
+	}
+	for _, service := range services {
+		if service.Failed {
+			info.FailedCount++
+		}
+		if service.Flapping {
+			info.FlappingCount++
+		}
+		if service.ActiveState == "active" {
+			info.ActiveCount++
+		}
+	}
+
+	return info
+}
+
+// recordRestarts 记录本次的 NRestarts，并根据窗口内最早的采样计算最近重启次数
+// NRestarts 是服务启动以来的累计值，只比较窗口内的增量，避免很久以前的重启一直被当作频繁重启
+func recordRestarts(services []ServiceInfo, now time.Time) {
+	restartHistory.Lock()
+	defer restartHistory.Unlock()
+
+	seen := make(map[string]bool, len(services))
+	for i := range services {
+		service := &services[i]
+		seen[service.Name] = true
+
+		samples := restartHistory.samples[service.Name]
+		// 丢弃窗口外的采样；计数器变小（systemctl reset-failed、daemon-reexec）时重新开始
+		kept := samples[:0]
+		for _, sample := range samples {
+			if now.Sub(sample.at) <= flappingWindow && sample.restarts <= service.Restarts {
+				kept = append(kept, sample)
+			}
+		}
+		samples = append(kept, restartSample{at: now, restarts: service.Restarts})
+		restartHistory.samples[service.Name] = samples
+
+		service.RecentRestarts = service.Restarts - samples[0].restarts
+		service.Flapping = service.SubState == "auto-restart" || service.RecentRestarts >= flappingRestarts
+	}
+
+	// 已消失的服务不再保留历史
+	for name := range restartHistory.samples {
+		if !seen[name] {
+			delete(restartHistory.samples, name)
+		}
+	}
+}
+
+func applyServiceCPUPercent(ctx context.Context, services []ServiceInfo, root string) {
+	readUsage := func() map[int]uint64 {
+		usage := make(map[int]uint64)
+		for i, service := range services {
+			if service.ControlGroup == "" {
+				continue
+			}
+			stat := readKeyValueFile(filepath.Join(root, service.ControlGroup, "cpu.stat"))
+			if v, ok := stat["usage_usec"]; ok {
+				usage[i] = v
+			}
+		}
+		return usage
+	}
+
+	before := readUsage()
+	if len(before) == 0 {
+		return
+	}
+	start := time.Now()
+	if sleepContext(ctx, cgroupSampleInterval) != nil {
+		return
+	}
+	after := readUsage()
+	elapsed := time.Since(start)
+
+	for i, v := range after {
+		if prev, ok := before[i]; ok && v >= prev {
+			services[i].CPUPercent = float64(v-prev) / float64(elapsed.Microseconds()) * 100
+		}
+	}
+}
+
+// sortServices 失败的在前，其次是频繁重启的，然后按内存降序
+func sortServices(services []ServiceInfo) {
+	rank := func(s ServiceInfo) int {
+		if s.Failed {
+			return 0
+		}
+		if s.Flapping {
+			return 1
+		}
+		return 2
+	}
+	sort.SliceStable(services, func(i, j int) bool {
+		ri, rj := rank(services[i]), rank(services[j])
+		if ri != rj {
+			return ri < rj
+		}
+		return services[i].MemoryBytes > services[j].MemoryBytes
+	})
+}
+
+// dbusSystemdBus 基于系统 D-Bus 的实现
+type dbusSystemdBus struct {
+	conn *dbus.Conn
+}
+
+// NewSystemdBus 连接系统 D-Bus
+func NewSystemdBus() (SystemdBus, error) {
+	conn, err := dbus.ConnectSystemBus()
+	if err != nil {
+		return nil, err
+	}
+	return &dbusSystemdBus{conn: conn}, nil
+}
+
+func (b *dbusSystemdBus) ListUnits() ([]SystemdUnit, error) {
+	// a(ssssssouso): name, description, load, active, sub, following, path, job id, job type, job path
+	var raw [][]interface{}
+	obj := b.conn.Object("org.freedesktop.systemd1", "/org/freedesktop/systemd1")
+	if err := obj.Call("org.freedesktop.systemd1.Manager.ListUnits", 0).Store(&raw); err != nil {
+		return nil, err
+	}
+
+	units := make([]SystemdUnit, 0, len(raw))
+	for _, fields := range raw {
+		if len(fields) < 7 {
+			continue
+		}
+		unit := SystemdUnit{}
+		unit.Name, _ = fields[0].(string)
+		unit.Description, _ = fields[1].(string)
+		unit.LoadState, _ = fields[2].(string)
+		unit.ActiveState, _ = fields[3].(string)
+		unit.SubState, _ = fields[4].(string)
+		unit.Path, _ = fields[6].(dbus.ObjectPath)
+		units = append(units, unit)
+	}
+
+	return units, nil
+}
+
+func (b *dbusSystemdBus) ServiceProperties(path dbus.ObjectPath) (SystemdServiceProperties, error) {
+	var props SystemdServiceProperties
+
+	var values map[string]dbus.Variant
+	obj := b.conn.Object("org.freedesktop.systemd1", path)
+	if err := obj.Call("org.freedesktop.DBus.Properties.GetAll", 0, "org.freedesktop.systemd1.Service").Store(&values); err != nil {
+		return props, err
+	}
+
+	if v, ok := values["MainPID"]; ok {
+		props.MainPID, _ = v.Value().(uint32)
+	}
+	if v, ok := values["NRestarts"]; ok {
+		props.NRestarts, _ = v.Value().(uint32)
+	}
+	if v, ok := values["ControlGroup"]; ok {
+		props.ControlGroup, _ = v.Value().(string)
+	}
+
+	return props, nil
+}
+
+func (b *dbusSystemdBus) Close() error {
+	return b.conn.Close()
+}
+
+// StaticSystemdBus 返回固定数据的 SystemdBus，用于测试和离线演示
+type StaticSystemdBus struct {
+	Units      []SystemdUnit
+	Properties map[dbus.ObjectPath]SystemdServiceProperties
+}
+
+func (b *StaticSystemdBus) ListUnits() ([]SystemdUnit, error) {
+	return b.Units, nil
+}
+
+func (b *StaticSystemdBus) ServiceProperties(path dbus.ObjectPath) (SystemdServiceProperties, error) {
+	return b.Properties[path], nil
+}
+
+func (b *StaticSystemdBus) Close() error {
+	return nil
+}
@@ -0,0 +1,170 @@
+package monitor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// resetRestartHistory 清空全局的重启历史，避免测试之间互相影响
+func resetRestartHistory(t *testing.T) {
+	t.Helper()
+	restartHistory.Lock()
+	restartHistory.samples = make(map[string][]restartSample)
+	restartHistory.Unlock()
+}
+
+func TestGetServicesInfoFrom(t *testing.T) {
+	resetRestartHistory(t)
+
+	root := t.TempDir()
+	writeTestFiles(t, root, map[string]string{
+		"cgroup.controllers":                        "cpu memory\n",
+		"system.slice/nginx.service/memory.current": "2048\n",
+		"system.slice/app.service/memory.current":   "4096\n",
+	})
+
+	bus := &StaticSystemdBus{
+		Units: []SystemdUnit{
+			{Name: "nginx.service", LoadState: "loaded", ActiveState: "active", SubState: "running", Path: "/nginx"},
+			{Name: "db.service", LoadState: "loaded", ActiveState: "failed", SubState: "failed", Path: "/db"},
+			{Name: "app.service", LoadState: "loaded", ActiveState: "activating", SubState: "auto-restart", Path: "/app"},
+			{Name: "old.service", LoadState: "loaded", ActiveState: "inactive", SubState: "dead", Path: "/old"},
+			{Name: "gone.service", LoadState: "not-found", ActiveState: "inactive", SubState: "dead", Path: "/gone"},
+			{Name: "ssh.socket", LoadState: "loaded", ActiveState: "active", SubState: "listening", Path: "/ssh"},
+		},
+		Properties: map[dbus.ObjectPath]SystemdServiceProperties{
+			"/nginx": {MainPID: 100, NRestarts: 0, ControlGroup: "/system.slice/nginx.service"},
+			"/db":    {NRestarts: 1},
+			"/app":   {NRestarts: 7, ControlGroup: "/system.slice/app.service"},
+			// 很久以前重启过很多次，但没有新的重启，不应视为频繁重启
+			"/old": {NRestarts: 50},
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	info := GetServicesInfoFrom(ctx, bus, root)
+
+	if !info.Available {
+		t.Fatal("Available = false")
+	}
+	if info.TotalCount != 4 || info.ActiveCount != 1 || info.FailedCount != 1 || info.FlappingCount != 1 {
+		t.Errorf("counts total/active/failed/flapping = %d/%d/%d/%d, want 4/1/1/1",
+			info.TotalCount, info.ActiveCount, info.FailedCount, info.FlappingCount)
+	}
+
+	tests := []struct {
+		name     string
+		failed   bool
+		flapping bool
+		memory   uint64
+		restarts uint32
+	}{
+		{name: "db.service", failed: true, restarts: 1},
+		{name: "app.service", flapping: true, memory: 4096, restarts: 7},
+		{name: "nginx.service", memory: 2048},
+		{name: "old.service", restarts: 50},
+	}
+
+	if len(info.Services) != len(tests) {
+		t.Fatalf("got %d services, want %d", len(info.Services), len(tests))
+	}
+	// 顺序：失败、频繁重启、然后按内存降序
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := info.Services[i]
+			if s.Name != tt.name {
+				t.Fatalf("Services[%d] = %s, want %s", i, s.Name, tt.name)
+			}
+			if s.Failed != tt.failed || s.Flapping != tt.flapping {
+				t.Errorf("Failed, Flapping = %v, %v, want %v, %v", s.Failed, s.Flapping, tt.failed, tt.flapping)
+			}
+			if s.MemoryBytes != tt.memory {
+				t.Errorf("MemoryBytes = %d, want %d", s.MemoryBytes, tt.memory)
+			}
+			if s.Restarts != tt.restarts || s.RecentRestarts != 0 {
+				t.Errorf("Restarts, RecentRestarts = %d, %d, want %d, 0", s.Restarts, s.RecentRestarts, tt.restarts)
+			}
+		})
+	}
+}
+
+func TestRecordRestarts(t *testing.T) {
+	type sample struct {
+		after    time.Duration // 相对第一次采样的时间
+		restarts uint32
+	}
+
+	tests := []struct {
+		name     string
+		samples  []sample
+		recent   uint32
+		flapping bool
+	}{
+		{
+			name:    "first sample",
+			samples: []sample{{0, 40}},
+		},
+		{
+			name:    "stable lifetime count",
+			samples: []sample{{0, 40}, {time.Minute, 40}, {2 * time.Minute, 40}},
+		},
+		{
+			name:     "restarts within window",
+			samples:  []sample{{0, 2}, {time.Minute, 3}, {2 * time.Minute, 5}},
+			recent:   3,
+			flapping: true,
+		},
+		{
+			name:    "below threshold",
+			samples: []sample{{0, 2}, {5 * time.Minute, 4}},
+			recent:  2,
+		},
+		{
+			name:    "old restarts leave the window",
+			samples: []sample{{0, 2}, {time.Minute, 6}, {12 * time.Minute, 6}},
+			recent:  0,
+		},
+		{
+			name:    "counter reset",
+			samples: []sample{{0, 9}, {time.Minute, 1}},
+			recent:  0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetRestartHistory(t)
+			start := time.Now()
+			var service ServiceInfo
+			for _, s := range tt.samples {
+				services := []ServiceInfo{{Name: "app.service", SubState: "running", Restarts: s.restarts}}
+				recordRestarts(services, start.Add(s.after))
+				service = services[0]
+			}
+			if service.RecentRestarts != tt.recent || service.Flapping != tt.flapping {
+				t.Errorf("RecentRestarts, Flapping = %d, %v, want %d, %v",
+					service.RecentRestarts, service.Flapping, tt.recent, tt.flapping)
+			}
+		})
+	}
+}
+
+func TestRecordRestartsForgetsRemovedServices(t *testing.T) {
+	resetRestartHistory(t)
+	now := time.Now()
+	recordRestarts([]ServiceInfo{{Name: "a.service"}, {Name: "b.service"}}, now)
+	recordRestarts([]ServiceInfo{{Name: "a.service"}}, now.Add(time.Minute))
+
+	restartHistory.Lock()
+	defer restartHistory.Unlock()
+	if _, ok := restartHistory.samples["b.service"]; ok {
+		t.Error("history of removed service was kept")
+	}
+	if n := len(restartHistory.samples["a.service"]); n != 2 {
+		t.Errorf("a.service has %d samples, want 2", n)
+	}
+}
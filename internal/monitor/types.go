@@ -4,10 +4,12 @@ import "time"
 
 // SystemInfo 系统基本信息
 type SystemInfo struct {
-	Hostname  string
-	OS        string
-	Kernel    string
-	Uptime    uint64
+	Hostname string
+	OS       string
+	Kernel   string
+	Uptime   uint64
+	// Errors 采集失败的字段（对应字段为零值）
+	Errors    FieldErrors
 	Timestamp time.Time
 }
 
@@ -20,7 +22,36 @@ type CPUInfo struct {
 	LoadAvg5     float64
 	LoadAvg15    float64
 	PerCoreUsage []float64
-	Timestamp    time.Time
+	Modes        CPUModePercent
+	Frequencies  []CPUFrequency
+	// 每秒上下文切换和中断次数
+	ContextSwitchesPerSec float64
+	InterruptsPerSec      float64
+	Temperature           float64
+	Pressure              PressureResource
+	Errors                FieldErrors
+	Timestamp             time.Time
+}
+
+// CPUModePercent 各模式 CPU 时间占比（百分比）
+type CPUModePercent struct {
+	User    float64
+	Nice    float64
+	System  float64
+	Idle    float64
+	IOWait  float64
+	IRQ     float64
+	SoftIRQ float64
+	Steal   float64
+	Guest   float64
+}
+
+// CPUFrequency 单个核心的频率（MHz，Min/Max 为 0 表示不可用）
+type CPUFrequency struct {
+	Core       int
+	CurrentMHz float64
+	MinMHz     float64
+	MaxMHz     float64
 }
 
 // MemoryInfo 内存信息
@@ -34,35 +65,93 @@ type MemoryInfo struct {
 	SwapPercent float64
 	Cached      uint64
 	Buffers     uint64
+	Shared      uint64
+	Slab        uint64
+	SlabReclaim uint64
+	SlabUnrecl  uint64
+	Dirty       uint64
+	Writeback   uint64
+	CommittedAS uint64
+	CommitLimit uint64
+	HugePages   HugePagesInfo
+	Paging      PagingRates
+	NUMANodes   []NUMANodeMemory
+	Pressure    PressureResource
+	Errors      FieldErrors
 	Timestamp   time.Time
 }
 
+// HugePagesInfo 大页统计（Total、Free、Reserved 为页数，PageSize 为字节）
+type HugePagesInfo struct {
+	Total    uint64
+	Free     uint64
+	Reserved uint64
+	PageSize uint64
+}
+
+// PagingRates 缺页和换入换出速率（每秒，来自 /proc/vmstat）
+type PagingRates struct {
+	PageFaults  float64
+	MajorFaults float64
+	SwapIn      float64
+	SwapOut     float64
+}
+
+// NUMANodeMemory 单个 NUMA 节点的内存使用
+type NUMANodeMemory struct {
+	Node  int
+	Total uint64
+	Free  uint64
+	Used  uint64
+}
+
 // DiskInfo 磁盘信息
 type DiskInfo struct {
 	Partitions []PartitionInfo
+	Errors     FieldErrors
 	Timestamp  time.Time
 }
 
 // PartitionInfo 分区信息
 type PartitionInfo struct {
-	Device      string
-	Mountpoint  string
-	Fstype      string
-	Total       uint64
-	Used        uint64
-	Free        uint64
-	UsedPercent float64
+	Device            string
+	Mountpoint        string
+	Fstype            string
+	Options           []string
+	Total             uint64
+	Used              uint64
+	Free              uint64
+	UsedPercent       float64
+	InodesTotal       uint64
+	InodesUsed        uint64
+	InodesFree        uint64
+	InodesUsedPercent float64
+	ReadOnly          bool
+	NoExec            bool
+	// ReadOnlyRemount 可写文件系统当前为只读（通常是出错后被内核重新挂载）
+	ReadOnlyRemount bool
+	// GrowthBytesPerSec 最近的增长速率，FillETASeconds 按该速率写满的预计时间（0 表示未增长或数据不足）
+	GrowthBytesPerSec float64
+	FillETASeconds    float64
 }
 
 // NetworkInfo 网络信息
 type NetworkInfo struct {
 	Interfaces []InterfaceInfo
+	Errors     FieldErrors
 	Timestamp  time.Time
 }
 
 // InterfaceInfo 网络接口信息
 type InterfaceInfo struct {
 	Name        string
+	Kind        string // physical、bridge、veth、vlan、bond、tunnel、loopback、virtual
+	MTU         int
+	MAC         string
+	SpeedMbps   int64 // 未知时为 0
+	Duplex      string
+	OperState   string
+	Flags       []string
 	BytesSent   uint64
 	BytesRecv   uint64
 	PacketsSent uint64
@@ -75,6 +164,7 @@ type ProcessInfo struct {
 	TotalProcesses int
 	TopCPU         []ProcessDetail
 	TopMemory      []ProcessDetail
+	Errors         FieldErrors
 	Timestamp      time.Time
 }
 
@@ -96,6 +186,7 @@ type DockerInfo struct {
 	Containers   []ContainerInfo
 	RunningCount int
 	TotalCount   int
+	Errors       FieldErrors
 	Timestamp    time.Time
 }
 
@@ -126,3 +217,341 @@ type PortMapping struct {
 	Type        string
 	IP          string
 }
+
+// CgroupInfo cgroup v2 资源统计
+type CgroupInfo struct {
+	Available bool
+	Root      string
+	Tree      CgroupNode
+	Timestamp time.Time
+}
+
+// CgroupNode 单个 cgroup（slice、service、scope 等）
+type CgroupNode struct {
+	Path     string
+	Name     string
+	Depth    int
+	CPU      CgroupCPUStat
+	Memory   CgroupMemoryStat
+	IO       CgroupIOStat
+	Pids     CgroupPidsStat
+	Pressure CgroupPressure
+	Children []CgroupNode
+}
+
+// CgroupCPUStat cpu.stat 统计
+type CgroupCPUStat struct {
+	UsagePercent  float64
+	UsageUsec     uint64
+	UserUsec      uint64
+	SystemUsec    uint64
+	NrPeriods     uint64
+	NrThrottled   uint64
+	ThrottledUsec uint64
+}
+
+// CgroupMemoryStat 内存统计（Max 为 0 表示无限制）
+type CgroupMemoryStat struct {
+	Current uint64
+	Max     uint64
+	Events  CgroupMemoryEvents
+}
+
+// CgroupMemoryEvents memory.events 统计
+type CgroupMemoryEvents struct {
+	Low     uint64
+	High    uint64
+	Max     uint64
+	OOM     uint64
+	OOMKill uint64
+}
+
+// CgroupIOStat io.stat 统计（所有设备汇总）
+type CgroupIOStat struct {
+	ReadBytes  uint64
+	WriteBytes uint64
+	ReadIOs    uint64
+	WriteIOs   uint64
+}
+
+// CgroupPidsStat 进程数统计（Max 为 0 表示无限制）
+type CgroupPidsStat struct {
+	Current uint64
+	Max     uint64
+}
+
+// CgroupPressure cgroup 级别的 PSI（cpu.pressure、memory.pressure、io.pressure）
+type CgroupPressure struct {
+	CPU    PressureResource
+	Memory PressureResource
+	IO     PressureResource
+}
+
+// PressureInfo 系统级 Pressure Stall Information（/proc/pressure）
+type PressureInfo struct {
+	Available bool
+	CPU       PressureResource
+	Memory    PressureResource
+	IO        PressureResource
+	Timestamp time.Time
+}
+
+// PressureResource 单个资源的压力（some: 至少一个任务停顿，full: 所有任务停顿）
+type PressureResource struct {
+	Available bool
+	Some      PressureStat
+	Full      PressureStat
+}
+
+// PressureStat 停顿时间占比（百分比）及累计停顿时间（微秒）
+type PressureStat struct {
+	Avg10     float64
+	Avg60     float64
+	Avg300    float64
+	TotalUsec uint64
+}
+
+// ServicesInfo systemd 服务信息
+type ServicesInfo struct {
+	Available     bool
+	Services      []ServiceInfo
+	ActiveCount   int
+	FailedCount   int
+	FlappingCount int
+	TotalCount    int
+	Timestamp     time.Time
+}
+
+// ServiceInfo 单个 systemd 服务
+type ServiceInfo struct {
+	Name        string
+	Description string
+	LoadState   string
+	ActiveState string
+	SubState    string
+	MainPID     uint32
+	Restarts    uint32
+	// RecentRestarts 最近 10 分钟内的重启次数（需要之前的采样，首次采样为 0）
+	RecentRestarts uint32
+	ControlGroup   string
+	MemoryBytes    uint64
+	CPUPercent     float64
+	Failed         bool
+	Flapping       bool
+}
+
+// SensorsInfo 传感器信息
+type SensorsInfo struct {
+	Available    bool
+	Temperatures []TemperatureSensor
+	Fans         []FanSensor
+	Power        []PowerSensor
+	Timestamp    time.Time
+}
+
+// TemperatureSensor 温度传感器（High、Critical 为 0 表示硬件未提供阈值）
+type TemperatureSensor struct {
+	Chip     string
+	Label    string
+	Kind     string
+	Celsius  float64
+	High     float64
+	Critical float64
+}
+
+// FanSensor 风扇转速
+type FanSensor struct {
+	Chip  string
+	Label string
+	RPM   uint64
+}
+
+// PowerSensor RAPL 功耗
+type PowerSensor struct {
+	Domain string
+	Watts  float64
+}
+
+// DirUsageInfo 目录占用扫描结果
+type DirUsageInfo struct {
+	Root         string
+	Tree         DirUsageNode
+	LargestFiles []FileUsage
+	TotalBytes   uint64
+	FileCount    uint64
+	DirCount     uint64
+	ErrorCount   uint64
+	TimedOut     bool
+	Elapsed      float64
+	Timestamp    time.Time
+}
+
+// DirUsageNode 目录及其占用空间（含所有子目录）
+type DirUsageNode struct {
+	Path     string
+	Name     string
+	Bytes    uint64
+	Files    uint64
+	Children []DirUsageNode
+}
+
+// FileUsage 单个文件的占用空间
+type FileUsage struct {
+	Path  string
+	Bytes uint64
+}
+
+// DriveHealthInfo 磁盘 SMART / NVMe 健康信息
+type DriveHealthInfo struct {
+	Available bool // 是否安装了 smartctl
+	Error     string
+	Drives    []DriveHealth
+	Timestamp time.Time
+}
+
+// DriveHealth 单块磁盘的健康状态
+type DriveHealth struct {
+	Device             string
+	Protocol           string // ATA、NVMe、SCSI
+	Model              string
+	Serial             string
+	Supported          bool // 是否支持 SMART
+	Passed             bool // SMART 整体自检结果
+	Temperature        float64
+	PowerOnHours       uint64
+	WearKnown          bool
+	PercentUsed        float64 // 已使用寿命百分比
+	AvailableSpare     float64 // NVMe 可用备用空间百分比
+	ReallocatedSectors uint64
+	PendingSectors     uint64
+	MediaErrors        uint64
+	CriticalWarning    uint64
+	UnsafeShutdowns    uint64
+	Issues             []string
+	Error              string
+}
+
+// ProtocolStats 内核协议计数器（/proc/net/snmp、/proc/net/netstat、nf_conntrack）
+type ProtocolStats struct {
+	Available bool
+	TCP       TCPProtocolStats
+	UDP       UDPProtocolStats
+	Conntrack ConntrackStats
+	Timestamp time.Time
+}
+
+// ProtocolCounter 累计计数及每秒速率
+type ProtocolCounter struct {
+	Total  uint64
+	PerSec float64
+}
+
+// TCPProtocolStats TCP 计数器
+type TCPProtocolStats struct {
+	CurrEstab       uint64
+	ActiveOpens     ProtocolCounter
+	PassiveOpens    ProtocolCounter
+	OutSegs         ProtocolCounter
+	RetransSegs     ProtocolCounter
+	RetransPercent  float64 // 重传段占发送段的百分比（按速率计算）
+	EstabResets     ProtocolCounter
+	OutRsts         ProtocolCounter
+	InErrs          ProtocolCounter
+	ListenOverflows ProtocolCounter // accept 队列溢出
+	ListenDrops     ProtocolCounter
+	SynBacklogDrops ProtocolCounter // SYN 队列满丢弃
+}
+
+// UDPProtocolStats UDP 计数器
+type UDPProtocolStats struct {
+	InDatagrams  ProtocolCounter
+	OutDatagrams ProtocolCounter
+	NoPorts      ProtocolCounter
+	InErrors     ProtocolCounter
+	RcvbufErrors ProtocolCounter // 接收缓冲区满丢包
+	SndbufErrors ProtocolCounter
+}
+
+// ConntrackStats 连接跟踪表使用情况
+type ConntrackStats struct {
+	Available    bool
+	Count        uint64
+	Max          uint64
+	UsedPercent  float64
+	Drop         ProtocolCounter
+	EarlyDrop    ProtocolCounter
+	InsertFailed ProtocolCounter
+}
+
+// ProcessNetworkInfo 按进程和容器统计的 TCP 带宽
+type ProcessNetworkInfo struct {
+	Available bool
+	Error     string
+	// Partial 有进程的 fd 无权读取（非 root 运行），其流量计入未归属
+	Partial                bool
+	UnreadableProcesses    int
+	Processes              []ProcessNetUsage
+	Containers             []ContainerNetUsage
+	UnattributedSendPerSec float64
+	UnattributedRecvPerSec float64
+	Timestamp              time.Time
+}
+
+// ProcessNetUsage 单个进程的网络带宽
+type ProcessNetUsage struct {
+	PID             int32
+	Name            string
+	ContainerID     string
+	ContainerName   string
+	Connections     int
+	SendBytesPerSec float64
+	RecvBytesPerSec float64
+}
+
+// ContainerNetUsage 单个容器的网络带宽（容器内所有进程之和）
+type ContainerNetUsage struct {
+	ID              string
+	Name            string
+	Processes       int
+	Connections     int
+	SendBytesPerSec float64
+	RecvBytesPerSec float64
+}
+
+// CertificatesInfo TLS 证书扫描结果
+type CertificatesInfo struct {
+	Certificates    []CertificateInfo
+	ScannedCount    int // 尝试握手的地址数（含非 TLS 端口）
+	ExpiredCount    int
+	ExpiringCount   int
+	SelfSignedCount int
+	WarnDays        int
+	Timestamp       time.Time
+}
+
+// CertificateInfo 某个地址提供的服务端证书
+type CertificateInfo struct {
+	Address      string
+	Source       string // listener: 本机监听端口，config: 配置的地址
+	PID          int32
+	ProcessName  string
+	Subject      string
+	DNSNames     []string // SAN（含 IP）
+	Issuer       string
+	NotBefore    time.Time
+	NotAfter     time.Time
+	DaysLeft     float64
+	Expired      bool
+	ExpiringSoon bool
+	SelfSigned   bool
+	Verified     bool // 是否能通过系统根证书校验
+	VerifyError  string
+	Chain        []CertificateSummary
+}
+
+// CertificateSummary 证书链中的单个证书
+type CertificateSummary struct {
+	Subject  string
+	Issuer   string
+	NotAfter time.Time
+}
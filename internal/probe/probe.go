@@ -0,0 +1,343 @@
+package probe
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+)
+
+// 探测类型
+const (
+	TypeTCP  = "tcp"
+	TypeHTTP = "http"
+	TypeDNS  = "dns"
+	TypeICMP = "icmp"
+)
+
+// 默认值
+const (
+	DefaultTimeout   = 5 * time.Second
+	DefaultInterval  = 30 * time.Second
+	DefaultPingCount = 3
+)
+
+// Target 探测目标
+type Target struct {
+	Name string
+	Type string
+	// Target TCP 为 host:port，HTTP 为 URL，DNS 为域名，ICMP 为主机名或 IP
+	Target  string
+	Timeout time.Duration
+	// MaxLatency 延迟超过该值时标记为慢（0 表示不检查）
+	MaxLatency time.Duration
+	// ExpectStatus HTTP 期望的状态码（0 表示接受 2xx 和 3xx）
+	ExpectStatus int
+	// Insecure HTTPS 不校验证书（用于自签名的内部服务，仍会记录证书过期时间）
+	Insecure bool
+	// Server DNS 服务器地址 (host:port)，为空时使用系统配置
+	Server string
+	// Count ICMP 发送的报文数
+	Count int
+}
+
+// Result 单次探测结果
+type Result struct {
+	Name       string
+	Type       string
+	Target     string
+	Success    bool
+	Slow       bool
+	LatencyMs  float64
+	Error      string
+	StatusCode int      // HTTP
+	Addresses  []string // DNS
+	PacketLoss float64  // ICMP，百分比
+	// CertNotAfter / CertDaysLeft HTTPS 证书过期时间
+	CertNotAfter time.Time
+	CertDaysLeft float64
+	CheckedAt    time.Time
+}
+
+// Info 所有探测的最新结果
+type Info struct {
+	Results      []Result
+	TotalCount   int
+	FailedCount  int
+	SlowCount    int
+	Interval     float64 // 秒
+	LastRun      time.Time
+	MinCertDays  float64
+	HasCertCheck bool
+}
+
+// Run 执行一次探测
+func Run(ctx context.Context, target Target) Result {
+	timeout := target.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	result := Result{
+		Name:      target.Name,
+		Type:      target.Type,
+		Target:    target.Target,
+		CheckedAt: time.Now(),
+	}
+
+	var err error
+	start := time.Now()
+	switch target.Type {
+	case TypeTCP:
+		err = probeTCP(ctx, target)
+	case TypeHTTP:
+		err = probeHTTP(ctx, target, &result)
+	case TypeDNS:
+		err = probeDNS(ctx, target, &result)
+	case TypeICMP:
+		err = probeICMP(ctx, target, &result)
+	default:
+		err = fmt.Errorf("不支持的探测类型: %s", target.Type)
+	}
+	if result.LatencyMs == 0 {
+		result.LatencyMs = float64(time.Since(start).Microseconds()) / 1000
+	}
+
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	result.Success = true
+	if target.MaxLatency > 0 && result.LatencyMs > float64(target.MaxLatency.Microseconds())/1000 {
+		result.Slow = true
+	}
+	return result
+}
+
+// RunAll 并发执行所有探测，结果顺序与 targets 一致
+func RunAll(ctx context.Context, targets []Target) []Result {
+	results := make([]Result, len(targets))
+
+	var wg sync.WaitGroup
+	for i, target := range targets {
+		wg.Add(1)
+		go func(i int, target Target) {
+			defer wg.Done()
+			results[i] = Run(ctx, target)
+		}(i, target)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// Summarize 汇总探测结果
+func Summarize(results []Result, interval time.Duration) Info {
+	info := Info{
+		Results:    results,
+		TotalCount: len(results),
+		Interval:   interval.Seconds(),
+	}
+	for _, r := range results {
+		if !r.Success {
+			info.FailedCount++
+		}
+		if r.Slow {
+			info.SlowCount++
+		}
+		if !r.CertNotAfter.IsZero() && (!info.HasCertCheck || r.CertDaysLeft < info.MinCertDays) {
+			info.HasCertCheck = true
+			info.MinCertDays = r.CertDaysLeft
+		}
+		if r.CheckedAt.After(info.LastRun) {
+			info.LastRun = r.CheckedAt
+		}
+	}
+	return info
+}
+
+func probeTCP(ctx context.Context, target Target) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", target.Target)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+func probeHTTP(ctx context.Context, target Target, result *Result) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.Target, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("User-Agent", "syspulse-probe")
+
+	// 每次探测使用新连接，延迟包含建连和 TLS 握手
+	client := &http.Client{
+		Transport: &http.Transport{
+			Proxy:             http.ProxyFromEnvironment,
+			DisableKeepAlives: true,
+			TLSClientConfig:   &tls.Config{InsecureSkipVerify: target.Insecure},
+		},
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		// 证书校验失败时仍记录证书的过期时间
+		var certErr *tls.CertificateVerificationError
+		if errors.As(err, &certErr) && len(certErr.UnverifiedCertificates) > 0 {
+			recordCertificate(&tls.ConnectionState{PeerCertificates: certErr.UnverifiedCertificates}, result)
+		}
+		return err
+	}
+	defer resp.Body.Close()
+
+	result.StatusCode = resp.StatusCode
+	if resp.TLS != nil {
+		recordCertificate(resp.TLS, result)
+	}
+
+	if target.ExpectStatus > 0 {
+		if resp.StatusCode != target.ExpectStatus {
+			return fmt.Errorf("状态码 %d，期望 %d", resp.StatusCode, target.ExpectStatus)
+		}
+	} else if resp.StatusCode >= 400 {
+		return fmt.Errorf("状态码 %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// recordCertificate 记录叶子证书的过期时间
+func recordCertificate(state *tls.ConnectionState, result *Result) {
+	if len(state.PeerCertificates) == 0 {
+		return
+	}
+	result.CertNotAfter = state.PeerCertificates[0].NotAfter
+	result.CertDaysLeft = time.Until(result.CertNotAfter).Hours() / 24
+}
+
+func probeDNS(ctx context.Context, target Target, result *Result) error {
+	resolver := net.DefaultResolver
+	if target.Server != "" {
+		resolver = &net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+				var dialer net.Dialer
+				return dialer.DialContext(ctx, network, target.Server)
+			},
+		}
+	}
+
+	addrs, err := resolver.LookupHost(ctx, target.Target)
+	if err != nil {
+		return err
+	}
+	result.Addresses = addrs
+	return nil
+}
+
+// probeICMP 发送 ICMP Echo，优先使用无需 root 的 ping socket (net.ipv4.ping_group_range)
+func probeICMP(ctx context.Context, target Target, result *Result) error {
+	count := target.Count
+	if count <= 0 {
+		count = DefaultPingCount
+	}
+
+	ipAddr, err := net.DefaultResolver.LookupIPAddr(ctx, target.Target)
+	if err != nil {
+		return err
+	}
+	var dst net.IP
+	for _, addr := range ipAddr {
+		if ip4 := addr.IP.To4(); ip4 != nil {
+			dst = ip4
+			break
+		}
+	}
+	if dst == nil {
+		return fmt.Errorf("%s 没有 IPv4 地址", target.Target)
+	}
+
+	// raw socket 会收到本机所有的 ICMP 报文，需要同时匹配 ID 才能排除其他 ping 进程的回复
+	address, matchID := net.Addr(&net.UDPAddr{IP: dst}), false
+	conn, err := icmp.ListenPacket("udp4", "0.0.0.0")
+	if err != nil {
+		address, matchID = &net.IPAddr{IP: dst}, true
+		conn, err = icmp.ListenPacket("ip4:icmp", "0.0.0.0")
+		if err != nil {
+			return fmt.Errorf("无法创建 ICMP socket（需要 root 或配置 net.ipv4.ping_group_range）: %w", err)
+		}
+	}
+	defer conn.Close()
+
+	deadline, _ := ctx.Deadline()
+	perPacket := time.Until(deadline) / time.Duration(count)
+
+	id := os.Getpid() & 0xffff
+	received := 0
+	var total time.Duration
+	for seq := 0; seq < count; seq++ {
+		msg := icmp.Message{
+			Type: ipv4.ICMPTypeEcho,
+			Body: &icmp.Echo{ID: id, Seq: seq, Data: []byte("syspulse")},
+		}
+		data, err := msg.Marshal(nil)
+		if err != nil {
+			return err
+		}
+
+		start := time.Now()
+		if _, err := conn.WriteTo(data, address); err != nil {
+			return err
+		}
+		if rtt, ok := waitEchoReply(conn, id, seq, matchID, start, start.Add(perPacket)); ok {
+			received++
+			total += rtt
+		}
+	}
+
+	result.PacketLoss = float64(count-received) / float64(count) * 100
+	if received == 0 {
+		return fmt.Errorf("%d 个报文全部丢失", count)
+	}
+	result.LatencyMs = float64(total.Microseconds()) / 1000 / float64(received)
+	return nil
+}
+
+// waitEchoReply 等待指定序号的 Echo Reply，返回往返时间
+func waitEchoReply(conn *icmp.PacketConn, id, seq int, matchID bool, start, deadline time.Time) (time.Duration, bool) {
+	buf := make([]byte, 1500)
+	conn.SetReadDeadline(deadline)
+	for {
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			return 0, false
+		}
+		rtt := time.Since(start)
+
+		if isEchoReply(buf[:n], id, seq, matchID) {
+			return rtt, true
+		}
+	}
+}
+
+// isEchoReply 判断报文是否是我们发出的 Echo 的回复
+// ping socket 模式下内核会改写 ID（回复只会投递给本 socket），因此只匹配序号
+func isEchoReply(data []byte, id, seq int, matchID bool) bool {
+	msg, err := icmp.ParseMessage(1, data)
+	if err != nil || msg.Type != ipv4.ICMPTypeEchoReply {
+		return false
+	}
+	echo, ok := msg.Body.(*icmp.Echo)
+	return ok && echo.Seq == seq && (!matchID || echo.ID == id)
+}
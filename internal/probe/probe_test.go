@@ -0,0 +1,228 @@
+package probe
+
+import (
+	"context"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+)
+
+// closedAddr 返回一个刚释放、没有进程监听的本地地址
+func closedAddr(t *testing.T) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+	return addr
+}
+
+func TestRunTCP(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	tests := []struct {
+		name    string
+		target  string
+		success bool
+	}{
+		{name: "listening", target: ln.Addr().String(), success: true},
+		{name: "refused", target: closedAddr(t), success: false},
+		{name: "missing port", target: "127.0.0.1", success: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Run(context.Background(), Target{Name: tt.name, Type: TypeTCP, Target: tt.target, Timeout: time.Second})
+			if result.Success != tt.success {
+				t.Fatalf("Success = %v, want %v (error %q)", result.Success, tt.success, result.Error)
+			}
+			if !tt.success && result.Error == "" {
+				t.Error("failed probe without error message")
+			}
+			if result.Name != tt.name || result.Type != TypeTCP || result.CheckedAt.IsZero() {
+				t.Errorf("result metadata = %+v", result)
+			}
+		})
+	}
+}
+
+func TestRunHTTP(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {
+		if r.UserAgent() != "syspulse-probe" {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+	mux.HandleFunc("/down", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	mux.HandleFunc("/created", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	})
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(100 * time.Millisecond):
+		case <-r.Context().Done():
+		}
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	// 证书校验失败的探测会让服务端记录握手错误，测试中不需要
+	tls := httptest.NewUnstartedServer(mux)
+	tls.Config.ErrorLog = log.New(io.Discard, "", 0)
+	tls.StartTLS()
+	defer tls.Close()
+
+	tests := []struct {
+		name     string
+		target   Target
+		success  bool
+		slow     bool
+		status   int
+		errorHas string
+		cert     bool
+	}{
+		{name: "ok", target: Target{Target: server.URL + "/ok"}, success: true, status: 200},
+		{name: "server error", target: Target{Target: server.URL + "/down"}, status: 503, errorHas: "状态码 503"},
+		{name: "expected status", target: Target{Target: server.URL + "/down", ExpectStatus: 503}, success: true, status: 503},
+		{name: "unexpected status", target: Target{Target: server.URL + "/created", ExpectStatus: 200}, status: 201, errorHas: "期望 200"},
+		{name: "not found", target: Target{Target: server.URL + "/missing"}, status: 404, errorHas: "状态码 404"},
+		{name: "slow", target: Target{Target: server.URL + "/slow", MaxLatency: 10 * time.Millisecond}, success: true, slow: true, status: 200},
+		{name: "timeout", target: Target{Target: server.URL + "/slow", Timeout: 20 * time.Millisecond}, errorHas: "deadline exceeded"},
+		{name: "refused", target: Target{Target: "http://" + closedAddr(t) + "/"}, errorHas: "refused"},
+		{name: "tls insecure", target: Target{Target: tls.URL + "/ok", Insecure: true}, success: true, status: 200, cert: true},
+		{name: "tls unverified", target: Target{Target: tls.URL + "/ok"}, errorHas: "certificate", cert: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := tt.target
+			target.Name = tt.name
+			target.Type = TypeHTTP
+			result := Run(context.Background(), target)
+
+			if result.Success != tt.success || result.Slow != tt.slow {
+				t.Fatalf("Success, Slow = %v, %v, want %v, %v (error %q)", result.Success, result.Slow, tt.success, tt.slow, result.Error)
+			}
+			if result.StatusCode != tt.status {
+				t.Errorf("StatusCode = %d, want %d", result.StatusCode, tt.status)
+			}
+			if !strings.Contains(result.Error, tt.errorHas) {
+				t.Errorf("Error = %q, want containing %q", result.Error, tt.errorHas)
+			}
+			if got := !result.CertNotAfter.IsZero(); got != tt.cert {
+				t.Errorf("certificate recorded = %v, want %v", got, tt.cert)
+			}
+			if tt.cert && result.CertDaysLeft <= 0 {
+				t.Errorf("CertDaysLeft = %v, want > 0", result.CertDaysLeft)
+			}
+			if result.LatencyMs <= 0 {
+				t.Errorf("LatencyMs = %v, want > 0", result.LatencyMs)
+			}
+		})
+	}
+}
+
+func TestRunUnsupportedType(t *testing.T) {
+	result := Run(context.Background(), Target{Type: "smtp", Target: "localhost:25"})
+	if result.Success || !strings.Contains(result.Error, "不支持的探测类型") {
+		t.Errorf("result = %+v", result)
+	}
+}
+
+func TestRunAllAndSummarize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	tls := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer tls.Close()
+
+	targets := []Target{
+		{Name: "web", Type: TypeHTTP, Target: server.URL},
+		{Name: "dead", Type: TypeTCP, Target: closedAddr(t), Timeout: time.Second},
+		{Name: "dns", Type: TypeDNS, Target: "127.0.0.1"},
+		{Name: "https", Type: TypeHTTP, Target: tls.URL, Insecure: true},
+	}
+	results := RunAll(context.Background(), targets)
+
+	for i, target := range targets {
+		if results[i].Name != target.Name {
+			t.Fatalf("results[%d].Name = %s, want %s", i, results[i].Name, target.Name)
+		}
+	}
+	if got := results[2].Addresses; len(got) != 1 || got[0] != "127.0.0.1" {
+		t.Errorf("dns Addresses = %v", got)
+	}
+
+	info := Summarize(results, 30*time.Second)
+	if info.TotalCount != 4 || info.FailedCount != 1 || info.SlowCount != 0 {
+		t.Errorf("total/failed/slow = %d/%d/%d, want 4/1/0", info.TotalCount, info.FailedCount, info.SlowCount)
+	}
+	if info.Interval != 30 {
+		t.Errorf("Interval = %v, want 30", info.Interval)
+	}
+	if !info.HasCertCheck || info.MinCertDays != results[3].CertDaysLeft {
+		t.Errorf("HasCertCheck, MinCertDays = %v, %v", info.HasCertCheck, info.MinCertDays)
+	}
+	for _, r := range results {
+		if r.CheckedAt.After(info.LastRun) {
+			t.Errorf("LastRun %v before %s checked at %v", info.LastRun, r.Name, r.CheckedAt)
+		}
+	}
+}
+
+func TestIsEchoReply(t *testing.T) {
+	marshal := func(typ icmp.Type, id, seq int) []byte {
+		msg := icmp.Message{Type: typ, Body: &icmp.Echo{ID: id, Seq: seq, Data: []byte("syspulse")}}
+		data, err := msg.Marshal(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+
+	tests := []struct {
+		name    string
+		data    []byte
+		matchID bool
+		want    bool
+	}{
+		{name: "raw socket reply", data: marshal(ipv4.ICMPTypeEchoReply, 42, 1), matchID: true, want: true},
+		{name: "raw socket other process", data: marshal(ipv4.ICMPTypeEchoReply, 7, 1), matchID: true, want: false},
+		{name: "ping socket rewritten id", data: marshal(ipv4.ICMPTypeEchoReply, 7, 1), matchID: false, want: true},
+		{name: "wrong sequence", data: marshal(ipv4.ICMPTypeEchoReply, 42, 2), matchID: true, want: false},
+		{name: "echo request", data: marshal(ipv4.ICMPTypeEcho, 42, 1), matchID: true, want: false},
+		{name: "garbage", data: []byte{0}, matchID: false, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isEchoReply(tt.data, 42, 1, tt.matchID); got != tt.want {
+				t.Errorf("isEchoReply = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
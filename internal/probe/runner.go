@@ -0,0 +1,76 @@
+package probe
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Runner 按固定间隔在后台执行探测，并保存最新结果
+type Runner struct {
+	targets  []Target
+	interval time.Duration
+
+	mu      sync.RWMutex
+	results []Result
+
+	stop chan struct{}
+	once sync.Once
+}
+
+// NewRunner 创建探测调度器（interval 为 0 时使用默认间隔）
+func NewRunner(targets []Target, interval time.Duration) *Runner {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	return &Runner{
+		targets:  targets,
+		interval: interval,
+		stop:     make(chan struct{}),
+	}
+}
+
+// Start 立即执行一次探测，之后按间隔执行，直到调用 Stop
+func (r *Runner) Start() {
+	if len(r.targets) == 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+
+		for {
+			results := RunAll(context.Background(), r.targets)
+			r.mu.Lock()
+			r.results = results
+			r.mu.Unlock()
+
+			select {
+			case <-ticker.C:
+			case <-r.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop 停止后台探测
+func (r *Runner) Stop() {
+	r.once.Do(func() { close(r.stop) })
+}
+
+// Info 返回最新的探测结果（首次探测完成前结果为空）
+func (r *Runner) Info() Info {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	results := make([]Result, len(r.results))
+	copy(results, r.results)
+	return Summarize(results, r.interval)
+}
+
+// Targets 返回配置的探测目标
+func (r *Runner) Targets() []Target {
+	return r.targets
+}
@@ -0,0 +1,193 @@
+package web
+
+import (
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+
+	"syspulse/internal/monitor"
+)
+
+// /api/v1 的 JSON 字段名由 Go 字段名按固定规则转换为 snake_case（CPUPercent -> cpu_percent），
+// 响应编码和 OpenAPI 文档使用同一套规则，文档与实际输出始终一致。
+// 字段名属于公开契约，由 testdata/openapi.json 固定：重命名结构体字段会导致 TestOpenAPIGolden 失败，
+// 确认是有意的变更后用 go test ./internal/web -run TestOpenAPIGolden -update 更新。
+// 旧的 /api 路由仍然直接输出 Go 结构体（PascalCase），保持兼容。
+
+// apiAcronyms 转换前先规范化的混合大小写缩写，避免拆成 current_m_hz、read_i_os
+var apiAcronyms = strings.NewReplacer("MHz", "Mhz", "IOs", "Ios")
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	durationType   = reflect.TypeOf(time.Duration(0))
+	fieldErrorType = reflect.TypeOf(monitor.FieldError{})
+)
+
+// apiName 把 Go 字段名转换为 API 字段名
+func apiName(field string) string {
+	runes := []rune(apiAcronyms.Replace(field))
+
+	var b strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			// 小写或数字后的大写字母开始新单词；连续大写（缩写）的最后一个字母后跟小写时也开始新单词
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
+// apiFieldPath 转换以 . 连接的字段路径（Interfaces.BytesSent -> interfaces.bytes_sent）
+func apiFieldPath(path string) string {
+	parts := strings.Split(path, ".")
+	for i, part := range parts {
+		parts[i] = apiName(part)
+	}
+	return strings.Join(parts, ".")
+}
+
+// apiValue 把 Go 值转换为 /api/v1 的 JSON 表示
+// 时间为 RFC 3339 字符串（零值为 null），时长为秒，nil 切片为空数组
+func apiValue(value interface{}) interface{} {
+	return apiReflectValue(reflect.ValueOf(value))
+}
+
+func apiReflectValue(v reflect.Value) interface{} {
+	if !v.IsValid() {
+		return nil
+	}
+
+	switch v.Type() {
+	case timeType:
+		t := v.Interface().(time.Time)
+		if t.IsZero() {
+			return nil
+		}
+		return t
+	case durationType:
+		return time.Duration(v.Int()).Seconds()
+	case fieldErrorType:
+		// 错误中的字段名与响应中的字段名保持一致
+		e := v.Interface().(monitor.FieldError)
+		return map[string]interface{}{"field": apiFieldPath(e.Field), "kind": e.Kind, "message": e.Message}
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return apiReflectValue(v.Elem())
+
+	case reflect.Struct:
+		object := make(map[string]interface{}, v.NumField())
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			object[apiName(field.Name)] = apiReflectValue(v.Field(i))
+		}
+		return object
+
+	case reflect.Slice, reflect.Array:
+		items := make([]interface{}, v.Len())
+		for i := range items {
+			items[i] = apiReflectValue(v.Index(i))
+		}
+		return items
+
+	case reflect.Map:
+		object := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			object[iter.Key().String()] = apiReflectValue(iter.Value())
+		}
+		return object
+	}
+
+	return v.Interface()
+}
+
+// schemaBuilder 根据 Go 类型生成 OpenAPI schema，具名结构体放入 components
+type schemaBuilder struct {
+	components map[string]interface{}
+}
+
+func newSchemaBuilder() *schemaBuilder {
+	return &schemaBuilder{components: make(map[string]interface{})}
+}
+
+// componentName monitor 包的类型直接使用类型名，其他包加上包名前缀（probe.Result -> ProbeResult）
+func componentName(t reflect.Type) string {
+	pkg := t.PkgPath()
+	pkg = pkg[strings.LastIndex(pkg, "/")+1:]
+	if pkg == "monitor" || pkg == "web" {
+		return t.Name()
+	}
+	return strings.ToUpper(pkg[:1]) + pkg[1:] + t.Name()
+}
+
+// schema 返回类型的 schema（具名结构体返回 $ref）
+func (b *schemaBuilder) schema(t reflect.Type) map[string]interface{} {
+	switch t {
+	case timeType:
+		return map[string]interface{}{"type": "string", "format": "date-time", "nullable": true}
+	case durationType:
+		return map[string]interface{}{"type": "number", "description": "秒"}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		schema := b.schema(t.Elem())
+		if _, ok := schema["$ref"]; ok {
+			// OpenAPI 3.0 忽略 $ref 的同级字段，需要用 allOf 包一层
+			return map[string]interface{}{"allOf": []interface{}{schema}, "nullable": true}
+		}
+		schema["nullable"] = true
+		return schema
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer", "minimum": 0}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": b.schema(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": b.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return b.structSchema(t)
+		}
+		name := componentName(t)
+		if _, ok := b.components[name]; !ok {
+			b.components[name] = nil // 先占位，防止递归类型（如 CgroupNode）无限展开
+			b.components[name] = b.structSchema(t)
+		}
+		return map[string]interface{}{"$ref": "#/components/schemas/" + name}
+	}
+
+	return map[string]interface{}{}
+}
+
+func (b *schemaBuilder) structSchema(t reflect.Type) map[string]interface{} {
+	properties := make(map[string]interface{}, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.IsExported() {
+			properties[apiName(field.Name)] = b.schema(field.Type)
+		}
+	}
+	return map[string]interface{}{"type": "object", "properties": properties}
+}
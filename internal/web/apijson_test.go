@@ -0,0 +1,80 @@
+package web
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"syspulse/internal/config"
+)
+
+func TestAPIName(t *testing.T) {
+	tests := []struct {
+		field string
+		want  string
+	}{
+		{"Name", "name"},
+		{"CPUPercent", "cpu_percent"},
+		{"UsedPercent", "used_percent"},
+		{"MemoryMB", "memory_mb"},
+		{"PID", "pid"},
+		{"CurrentMHz", "current_mhz"},
+		{"ReadIOs", "read_ios"},
+		{"Load1", "load1"},
+		{"TCP", "tcp"},
+		{"HTTPStatus", "http_status"},
+		{"TimeoutSeconds", "timeout_seconds"},
+	}
+	for _, tt := range tests {
+		if got := apiName(tt.field); got != tt.want {
+			t.Errorf("apiName(%q) = %q, want %q", tt.field, got, tt.want)
+		}
+	}
+}
+
+// 响应编码与 OpenAPI 文档使用同一套字段名
+func TestAPIValueMatchesSchema(t *testing.T) {
+	s := NewServer("127.0.0.1", 0, config.Default())
+	schemas := newSchemaBuilder()
+
+	for _, route := range s.apiRoutes() {
+		if route.response == nil {
+			continue
+		}
+		t.Run(route.method+" "+route.path, func(t *testing.T) {
+			schema := schemas.schema(reflect.TypeOf(route.response))
+			compareWithSchema(t, "", apiValue(route.response), schema, schemas.components)
+		})
+	}
+}
+
+// compareWithSchema 递归比较对象的字段名与 schema 的 properties
+func compareWithSchema(t *testing.T, path string, value interface{}, schema map[string]interface{}, components map[string]interface{}) {
+	t.Helper()
+	if ref, ok := schema["$ref"].(string); ok {
+		schema = components[strings.TrimPrefix(ref, "#/components/schemas/")].(map[string]interface{})
+	}
+	properties, ok := schema["properties"].(map[string]interface{})
+	object, isObject := value.(map[string]interface{})
+	if !ok || !isObject {
+		return
+	}
+
+	if got, want := sortedKeys(object), sortedKeys(properties); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("%s: response fields %v, schema properties %v", path, got, want)
+		return
+	}
+	for key, field := range object {
+		compareWithSchema(t, path+"."+key, field, properties[key].(map[string]interface{}), components)
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
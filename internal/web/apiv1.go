@@ -0,0 +1,673 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"syspulse/internal/alert"
+	"syspulse/internal/monitor"
+	"syspulse/internal/probe"
+
+	"github.com/gorilla/mux"
+)
+
+// apiV1Prefix 版本化 API 的路径前缀
+const apiV1Prefix = "/api/v1"
+
+// 列表接口的分页参数
+const (
+	defaultPageLimit = 100
+	maxPageLimit     = 1000
+)
+
+// apiRoute /api/v1 的一个路由，同时用于注册路由和生成 OpenAPI 文档
+type apiRoute struct {
+	method  string
+	path    string
+	summary string
+	// 需要的最低角色
+	role   role
+	params []apiParam
+	// 响应类型的零值，用于生成 schema；nil 表示 YAML 文本响应
+	response interface{}
+	// 响应是否为分页列表（response 为元素类型）
+	list bool
+	// 除 400/401 外可能返回的错误状态码
+	errors  []int
+	handler http.HandlerFunc
+}
+
+// apiParam 查询参数或路径参数
+type apiParam struct {
+	name        string
+	in          string // query 或 path
+	kind        string // string、integer、number、boolean
+	description string
+}
+
+func queryParam(name, kind, description string) apiParam {
+	return apiParam{name: name, in: "query", kind: kind, description: description}
+}
+
+// timeoutErrors 采集超过子系统超时的接口返回 504
+var timeoutErrors = []int{http.StatusGatewayTimeout}
+
+// v1Handler 在子系统的采集超时内执行采集并输出结果
+func v1Handler[T any](s *Server, subsystem string, fn func(context.Context) T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if info, ok := collect(s, w, r, subsystem, fn); ok {
+			respondAPI(w, info)
+		}
+	}
+}
+
+// apiRoutes 所有 /api/v1 路由
+func (s *Server) apiRoutes() []apiRoute {
+	idParam := apiParam{name: "id", in: "path", kind: "string", description: "容器 ID（完整或至少 12 位）或名称"}
+
+	return []apiRoute{
+		{method: "GET", path: "/system", summary: "系统信息", response: monitor.SystemInfo{},
+			errors: timeoutErrors, handler: v1Handler(s, "system", monitor.GetSystemInfo)},
+		{method: "GET", path: "/cpu", summary: "CPU 使用率、负载和频率", response: monitor.CPUInfo{},
+			errors: timeoutErrors, handler: v1Handler(s, "cpu", monitor.GetCPUInfo)},
+		{method: "GET", path: "/memory", summary: "内存和交换空间", response: monitor.MemoryInfo{},
+			errors: timeoutErrors, handler: v1Handler(s, "memory", monitor.GetMemoryInfo)},
+		{method: "GET", path: "/pressure", summary: "资源压力 (PSI)", response: monitor.PressureInfo{},
+			errors: timeoutErrors, handler: v1Handler(s, "pressure", monitor.GetPressureInfo)},
+		{method: "GET", path: "/sensors", summary: "温度、风扇和功耗传感器", response: monitor.SensorsInfo{},
+			errors: []int{http.StatusServiceUnavailable, http.StatusGatewayTimeout}, handler: s.handleV1Sensors},
+		{method: "GET", path: "/alerts", summary: "当前触发的告警", response: alert.Alert{}, list: true,
+			params:  []apiParam{queryParam("level", "string", "只返回该级别: warning、critical")},
+			handler: s.handleV1Alerts},
+		{method: "GET", path: "/disks", summary: "磁盘分区", response: monitor.PartitionInfo{}, list: true,
+			params: []apiParam{
+				queryParam("device", "string", "设备名"),
+				queryParam("fstype", "string", "文件系统类型"),
+				queryParam("mountpoint", "string", "挂载点"),
+			},
+			errors: timeoutErrors, handler: s.handleV1Disks},
+		{method: "GET", path: "/disks/usage", summary: "目录占用分析", role: roleOperator, response: monitor.DirUsageInfo{},
+			params: []apiParam{
+				queryParam("path", "string", "起始目录，默认 /"),
+				queryParam("depth", "integer", "展开的层数（0-64）"),
+				queryParam("top", "integer", "每层返回的条目数（1-1000）"),
+				queryParam("timeout", "integer", "扫描超时（1-3600 秒）"),
+			},
+			errors: []int{http.StatusNotFound, http.StatusTooManyRequests}, handler: s.handleV1DiskUsage},
+		{method: "GET", path: "/disks/health", summary: "磁盘 SMART / NVMe 健康状态", response: monitor.DriveHealthInfo{},
+			errors: []int{http.StatusServiceUnavailable, http.StatusGatewayTimeout}, handler: s.handleV1DiskHealth},
+		{method: "GET", path: "/network/interfaces", summary: "网络接口", response: monitor.InterfaceInfo{}, list: true,
+			params: []apiParam{
+				queryParam("name", "string", "接口名"),
+				queryParam("kind", "string", "接口类型: physical、bridge、veth、vlan、bond、tunnel、loopback、virtual"),
+				queryParam("oper_state", "string", "链路状态，如 up、down"),
+			},
+			errors: timeoutErrors, handler: s.handleV1Interfaces},
+		{method: "GET", path: "/network/protocols", summary: "TCP / UDP 协议计数器和 conntrack 使用率", response: monitor.ProtocolStats{},
+			errors: timeoutErrors, handler: v1Handler(s, "protocols", monitor.GetProtocolStats)},
+		{method: "GET", path: "/network/processes", summary: "按进程和容器统计的 TCP 带宽（采样 1 秒）", response: monitor.ProcessNetworkInfo{},
+			params: []apiParam{queryParam("top", "integer", "返回的进程数，默认 20")},
+			errors: []int{http.StatusServiceUnavailable, http.StatusGatewayTimeout}, handler: s.handleV1ProcessNetwork},
+		{method: "GET", path: "/ports", summary: "监听端口", response: monitor.PortDetail{}, list: true,
+			params: []apiParam{
+				queryParam("protocol", "string", "协议: tcp、udp、tcp6、udp6"),
+				queryParam("port", "integer", "端口号"),
+				queryParam("process", "string", "进程名"),
+			},
+			errors: timeoutErrors, handler: s.handleV1Ports},
+		{method: "GET", path: "/processes", summary: "进程列表（operator 以下角色看不到命令行）", response: monitor.ProcessDetail{}, list: true,
+			params: []apiParam{
+				queryParam("sort", "string", "排序: cpu（默认）、memory"),
+				queryParam("name", "string", "进程名包含该字符串"),
+				queryParam("user", "string", "用户名"),
+			},
+			errors: timeoutErrors, handler: s.handleV1Processes},
+		{method: "GET", path: "/containers", summary: "Docker 容器", response: monitor.ContainerInfo{}, list: true,
+			params: []apiParam{
+				queryParam("state", "string", "容器状态，如 running、exited"),
+				queryParam("name", "string", "容器名包含该字符串"),
+			},
+			errors: []int{http.StatusServiceUnavailable, http.StatusGatewayTimeout}, handler: s.handleV1Containers},
+		{method: "GET", path: "/containers/{id}", summary: "容器详情", response: monitor.ContainerInfo{},
+			params: []apiParam{idParam}, errors: []int{http.StatusNotFound, http.StatusServiceUnavailable, http.StatusGatewayTimeout},
+			handler: s.handleV1Container},
+		{method: "POST", path: "/containers/{id}/restart", summary: "重启容器", role: roleOperator, response: restartResult{},
+			params: []apiParam{idParam}, errors: []int{http.StatusNotFound, http.StatusServiceUnavailable, http.StatusInternalServerError},
+			handler: s.handleV1RestartContainer},
+		{method: "GET", path: "/services", summary: "systemd 服务", response: monitor.ServiceInfo{}, list: true,
+			params: []apiParam{
+				queryParam("state", "string", "活动状态，如 active、failed"),
+				queryParam("name", "string", "服务名包含该字符串"),
+			},
+			errors: []int{http.StatusServiceUnavailable, http.StatusGatewayTimeout}, handler: s.handleV1Services},
+		{method: "GET", path: "/probes", summary: "连通性探测结果（后台按间隔执行）", response: probe.Result{}, list: true,
+			params:  []apiParam{queryParam("status", "string", "只返回该状态: ok、failed、slow")},
+			handler: s.handleV1Probes},
+		{method: "GET", path: "/certificates", summary: "TLS 证书扫描结果（缓存 10 分钟）", response: monitor.CertificateInfo{}, list: true,
+			params: []apiParam{
+				queryParam("status", "string", "只返回该状态: expired、expiring、self_signed"),
+				queryParam("refresh", "boolean", "强制重新扫描"),
+			},
+			handler: s.handleV1Certificates},
+		{method: "GET", path: "/plugins", summary: "外部插件最近一次输出的指标（后台按插件的间隔执行）", response: monitor.PluginInfo{}, list: true,
+			params:  []apiParam{queryParam("name", "string", "插件名")},
+			handler: s.handleV1Plugins},
+		{method: "GET", path: "/scrape", summary: "本机服务指标的抓取结果和固定序列的当前值（后台按目标的间隔抓取）", response: monitor.ScrapeTargetInfo{}, list: true,
+			params: []apiParam{
+				queryParam("name", "string", "目标名"),
+				queryParam("status", "string", "只返回该状态: up、down"),
+			},
+			errors: timeoutErrors, handler: s.handleV1Scrape},
+		{method: "GET", path: "/whoami", summary: "当前用户和角色", response: whoamiResult{},
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				who := principalFrom(r)
+				respondAPI(w, whoamiResult{Name: who.Name, Role: who.Role.String()})
+			}},
+		{method: "GET", path: "/config", summary: "读取配置文件 (YAML)", role: roleAdmin,
+			errors: []int{http.StatusInternalServerError}, handler: s.handleGetConfig},
+		{method: "PUT", path: "/config", summary: "校验并保存 YAML 配置", role: roleAdmin, response: configSaveResult{},
+			errors:  []int{http.StatusConflict, http.StatusRequestEntityTooLarge, http.StatusInternalServerError},
+			handler: s.handlePutConfig},
+	}
+}
+
+// setupAPIV1 注册 /api/v1 路由
+// 同一路径的不同方法合并为一个路由，由 methodHandler 分发：gorilla/mux 的子路由无法可靠地区分 404 和 405
+func (s *Server) setupAPIV1() {
+	v1 := s.router.PathPrefix(apiV1Prefix).Subrouter()
+	v1.NotFoundHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		respondAPIError(w, http.StatusNotFound, "not_found", "接口不存在")
+	})
+
+	var paths []string
+	handlers := make(map[string]methodHandler)
+	for _, route := range s.apiRoutes() {
+		handler := route.handler
+		if route.role > roleViewer {
+			handler = s.require(route.role, handler)
+		}
+		if handlers[route.path] == nil {
+			paths = append(paths, route.path)
+			handlers[route.path] = make(methodHandler)
+		}
+		handlers[route.path][route.method] = handler
+	}
+	for _, path := range paths {
+		v1.Handle(path, handlers[path])
+	}
+	v1.Handle("/openapi.json", methodHandler{"GET": s.handleOpenAPI})
+}
+
+// methodHandler 按请求方法分发，不支持的方法返回 405
+type methodHandler map[string]http.HandlerFunc
+
+func (m methodHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if handler, ok := m[r.Method]; ok {
+		handler(w, r)
+		return
+	}
+	if r.Method == http.MethodOptions {
+		return
+	}
+
+	allowed := make([]string, 0, len(m))
+	for method := range m {
+		allowed = append(allowed, method)
+	}
+	sort.Strings(allowed)
+	w.Header().Set("Allow", strings.Join(allowed, ", "))
+	respondAPIError(w, http.StatusMethodNotAllowed, "method_not_allowed", "不支持该请求方法")
+}
+
+// legacySuccessors 旧版 /api 路由到 /api/v1 路由的前缀映射（按顺序匹配，先匹配更长的前缀）
+var legacySuccessors = []struct{ legacy, successor string }{
+	{"/api/disk", "/api/v1/disks"},
+	{"/api/network/protocols", "/api/v1/network/protocols"},
+	{"/api/network/processes", "/api/v1/network/processes"},
+	{"/api/network", "/api/v1/network/interfaces"},
+	{"/api/port", "/api/v1/ports"},
+	{"/api/process", "/api/v1/processes"},
+	{"/api/docker", "/api/v1/containers"},
+	{"/api/certs", "/api/v1/certificates"},
+	{"/api/", "/api/v1/"},
+}
+
+// deprecationMiddleware 为旧版路由加上 Deprecation 头和指向新路由的 Link 头
+// /api/all 和 /api/stream 在 v1 中没有对应的路由，不标记为弃用
+func deprecationMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := r.URL.Path
+		if path != "/api/all" && path != "/api/stream" {
+			for _, m := range legacySuccessors {
+				if strings.HasPrefix(path, m.legacy) {
+					w.Header().Set("Deprecation", "true")
+					w.Header().Set("Link", fmt.Sprintf("<%s%s>; rel=\"successor-version\"", m.successor, strings.TrimPrefix(path, m.legacy)))
+					break
+				}
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// whoamiResult /whoami 的响应
+type whoamiResult struct {
+	Name string
+	Role string
+}
+
+// restartResult 重启容器的响应
+type restartResult struct {
+	Restarted string
+}
+
+// configSaveResult 保存配置的响应
+type configSaveResult struct {
+	Saved string
+	// 需要重启 web 服务才能生效的配置段
+	RestartRequired []string
+}
+
+// apiErrorBody 错误响应
+type apiErrorBody struct {
+	Error apiErrorDetail `json:"error"`
+}
+
+type apiErrorDetail struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// respondAPI 以 /api/v1 的字段命名输出 JSON
+func respondAPI(w http.ResponseWriter, value interface{}) {
+	respondAPIStatus(w, http.StatusOK, apiValue(value))
+}
+
+func respondAPIStatus(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+// respondAPIError 输出统一格式的错误
+func respondAPIError(w http.ResponseWriter, status int, code, message string) {
+	respondAPIStatus(w, status, apiErrorBody{Error: apiErrorDetail{Code: code, Message: message}})
+}
+
+// writeError /api/v1 下输出 JSON 错误，旧路由保持纯文本
+func writeError(w http.ResponseWriter, r *http.Request, status int, code, message string) {
+	if isAPIV1(r) {
+		respondAPIError(w, status, code, message)
+		return
+	}
+	http.Error(w, message, status)
+}
+
+// isAPIV1 请求是否属于 /api/v1
+func isAPIV1(r *http.Request) bool {
+	return strings.HasPrefix(r.URL.Path, apiV1Prefix+"/")
+}
+
+// respondList 对切片分页（limit、offset 参数）并输出 {items, total, offset, limit, errors}
+// errs 为采集时失败的字段，items 可能因此不完整
+func respondList(w http.ResponseWriter, r *http.Request, items interface{}, errs monitor.FieldErrors) {
+	query := r.URL.Query()
+
+	limit, err := intParam(query.Get("limit"), defaultPageLimit, 1, maxPageLimit)
+	if err != nil {
+		respondAPIError(w, http.StatusBadRequest, "invalid_parameter", "limit "+err.Error())
+		return
+	}
+	offset, err := intParam(query.Get("offset"), 0, 0, math.MaxInt32)
+	if err != nil {
+		respondAPIError(w, http.StatusBadRequest, "invalid_parameter", "offset "+err.Error())
+		return
+	}
+
+	v := reflect.ValueOf(items)
+	total := v.Len()
+	start := min(offset, total)
+	end := min(start+limit, total)
+
+	respondAPIStatus(w, http.StatusOK, map[string]interface{}{
+		"items":  apiReflectValue(v.Slice(start, end)),
+		"total":  total,
+		"offset": offset,
+		"limit":  limit,
+		"errors": apiValue(errs),
+	})
+}
+
+// intParam 解析整数参数，为空时返回默认值
+func intParam(raw string, def, lo, hi int) (int, error) {
+	if raw == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < lo || n > hi {
+		return 0, fmt.Errorf("必须是 %d-%d 之间的整数", lo, hi)
+	}
+	return n, nil
+}
+
+// filterEqual 参数为空或与值相等（不区分大小写）
+func filterEqual(param, value string) bool {
+	return param == "" || strings.EqualFold(param, value)
+}
+
+// filterContains 参数为空或值包含参数（不区分大小写）
+func filterContains(param, value string) bool {
+	return param == "" || strings.Contains(strings.ToLower(value), strings.ToLower(param))
+}
+
+func (s *Server) handleV1Sensors(w http.ResponseWriter, r *http.Request) {
+	info, ok := collect(s, w, r, "sensors", monitor.GetSensorsInfo)
+	if !ok {
+		return
+	}
+	if !info.Available {
+		respondAPIError(w, http.StatusServiceUnavailable, "unavailable", "未检测到传感器")
+		return
+	}
+	respondAPI(w, info)
+}
+
+func (s *Server) handleV1Alerts(w http.ResponseWriter, r *http.Request) {
+	level := r.URL.Query().Get("level")
+	var matched []alert.Alert
+	for _, a := range s.evaluateAlerts(r.Context()) {
+		if filterEqual(level, string(a.Level)) {
+			matched = append(matched, a)
+		}
+	}
+	respondList(w, r, matched, nil)
+}
+
+func (s *Server) handleV1Disks(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := s.currentConfig().DiskFilter()
+	info, ok := collect(s, w, r, "disk", func(ctx context.Context) monitor.DiskInfo { return monitor.GetDiskInfo(ctx, filter) })
+	if !ok {
+		return
+	}
+	var matched []monitor.PartitionInfo
+	for _, p := range info.Partitions {
+		if filterEqual(query.Get("device"), p.Device) &&
+			filterEqual(query.Get("fstype"), p.Fstype) &&
+			filterEqual(query.Get("mountpoint"), p.Mountpoint) {
+			matched = append(matched, p)
+		}
+	}
+	respondList(w, r, matched, info.Errors)
+}
+
+func (s *Server) handleV1DiskUsage(w http.ResponseWriter, r *http.Request) {
+	if info, ok := s.dirUsage(w, r); ok {
+		respondAPI(w, info)
+	}
+}
+
+func (s *Server) handleV1DiskHealth(w http.ResponseWriter, r *http.Request) {
+	info, ok := collect(s, w, r, "disk_health", monitor.GetDriveHealth)
+	if !ok {
+		return
+	}
+	if !info.Available {
+		respondAPIError(w, http.StatusServiceUnavailable, "unavailable", info.Error)
+		return
+	}
+	respondAPI(w, info)
+}
+
+func (s *Server) handleV1Interfaces(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := s.currentConfig().NetworkFilter()
+	info, ok := collect(s, w, r, "network", func(ctx context.Context) monitor.NetworkInfo {
+		return monitor.GetNetworkInfo(ctx, filter)
+	})
+	if !ok {
+		return
+	}
+	var matched []monitor.InterfaceInfo
+	for _, iface := range info.Interfaces {
+		if filterEqual(query.Get("name"), iface.Name) &&
+			filterEqual(query.Get("kind"), iface.Kind) &&
+			filterEqual(query.Get("oper_state"), iface.OperState) {
+			matched = append(matched, iface)
+		}
+	}
+	respondList(w, r, matched, info.Errors)
+}
+
+func (s *Server) handleV1ProcessNetwork(w http.ResponseWriter, r *http.Request) {
+	top, err := intParam(r.URL.Query().Get("top"), defaultNetTopCount, 1, 1000)
+	if err != nil {
+		respondAPIError(w, http.StatusBadRequest, "invalid_parameter", "top "+err.Error())
+		return
+	}
+	info, ok := collect(s, w, r, "nettop", func(ctx context.Context) monitor.ProcessNetworkInfo {
+		return monitor.GetProcessNetworkInfo(ctx, top)
+	})
+	if !ok {
+		return
+	}
+	if !info.Available {
+		respondAPIError(w, http.StatusServiceUnavailable, "unavailable", info.Error)
+		return
+	}
+	respondAPI(w, info)
+}
+
+func (s *Server) handleV1Ports(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	port := query.Get("port")
+	info, ok := collect(s, w, r, "ports", monitor.GetPortInfo)
+	if !ok {
+		return
+	}
+	var matched []monitor.PortDetail
+	for _, p := range info.Listening {
+		if filterEqual(query.Get("protocol"), p.Protocol) &&
+			filterEqual(port, strconv.FormatUint(uint64(p.Port), 10)) &&
+			filterEqual(query.Get("process"), p.ProcessName) {
+			matched = append(matched, p)
+		}
+	}
+	respondList(w, r, matched, info.Errors)
+}
+
+func (s *Server) handleV1Processes(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	sortBy := query.Get("sort")
+	if sortBy != "" && sortBy != "cpu" && sortBy != "memory" {
+		respondAPIError(w, http.StatusBadRequest, "invalid_parameter", "sort 必须是 cpu 或 memory")
+		return
+	}
+	info, ok := collect(s, w, r, "process", func(ctx context.Context) monitor.ProcessInfo {
+		return monitor.GetProcessInfo(ctx, math.MaxInt32)
+	})
+	if !ok {
+		return
+	}
+	processes := info.TopCPU
+	if sortBy == "memory" {
+		processes = info.TopMemory
+	}
+	if principalFrom(r).Role < roleOperator {
+		processes = redactProcesses(processes)
+	}
+
+	var matched []monitor.ProcessDetail
+	for _, p := range processes {
+		if filterContains(query.Get("name"), p.Name) && filterEqual(query.Get("user"), p.Username) {
+			matched = append(matched, p)
+		}
+	}
+	respondList(w, r, matched, info.Errors)
+}
+
+func (s *Server) handleV1Containers(w http.ResponseWriter, r *http.Request) {
+	info, ok := collect(s, w, r, "docker", monitor.GetDockerInfo)
+	if !ok {
+		return
+	}
+	if !info.Available {
+		respondAPIError(w, http.StatusServiceUnavailable, "unavailable", monitor.ErrDockerUnavailable.Error())
+		return
+	}
+
+	query := r.URL.Query()
+	var matched []monitor.ContainerInfo
+	for _, c := range info.Containers {
+		if filterEqual(query.Get("state"), c.State) && filterContains(query.Get("name"), c.Name) {
+			matched = append(matched, c)
+		}
+	}
+	respondList(w, r, matched, info.Errors)
+}
+
+// respondContainerError 把容器操作的错误映射为 HTTP 状态码
+func respondContainerError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, monitor.ErrContainerNotFound):
+		respondAPIError(w, http.StatusNotFound, "not_found", err.Error())
+	case errors.Is(err, monitor.ErrDockerUnavailable):
+		respondAPIError(w, http.StatusServiceUnavailable, "unavailable", err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		respondAPIError(w, http.StatusGatewayTimeout, "timeout", err.Error())
+	default:
+		respondAPIError(w, http.StatusInternalServerError, "internal", err.Error())
+	}
+}
+
+func (s *Server) handleV1Container(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := s.collectContext(r, "docker")
+	defer cancel()
+	info, err := monitor.FindContainer(ctx, mux.Vars(r)["id"])
+	if err != nil {
+		respondContainerError(w, err)
+		return
+	}
+	respondAPI(w, info)
+}
+
+func (s *Server) handleV1RestartContainer(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	if err := monitor.RestartContainer(r.Context(), id); err != nil {
+		respondContainerError(w, err)
+		return
+	}
+	respondAPI(w, restartResult{Restarted: id})
+}
+
+func (s *Server) handleV1Services(w http.ResponseWriter, r *http.Request) {
+	info, ok := collect(s, w, r, "services", monitor.GetServicesInfo)
+	if !ok {
+		return
+	}
+	if !info.Available {
+		respondAPIError(w, http.StatusServiceUnavailable, "unavailable", "无法连接 systemd")
+		return
+	}
+
+	query := r.URL.Query()
+	var matched []monitor.ServiceInfo
+	for _, svc := range info.Services {
+		if filterEqual(query.Get("state"), svc.ActiveState) && filterContains(query.Get("name"), svc.Name) {
+			matched = append(matched, svc)
+		}
+	}
+	respondList(w, r, matched, nil)
+}
+
+func (s *Server) handleV1Probes(w http.ResponseWriter, r *http.Request) {
+	status := r.URL.Query().Get("status")
+	var matched []probe.Result
+	for _, result := range s.probes.Info().Results {
+		var state string
+		switch {
+		case !result.Success:
+			state = "failed"
+		case result.Slow:
+			state = "slow"
+		default:
+			state = "ok"
+		}
+		if filterEqual(status, state) {
+			matched = append(matched, result)
+		}
+	}
+	respondList(w, r, matched, nil)
+}
+
+func (s *Server) handleV1Certificates(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	refresh, _ := strconv.ParseBool(query.Get("refresh"))
+
+	status := query.Get("status")
+	var matched []monitor.CertificateInfo
+	for _, cert := range s.certificates(r.Context(), refresh, true).Certificates {
+		ok := true
+		switch status {
+		case "":
+		case "expired":
+			ok = cert.Expired
+		case "expiring":
+			ok = cert.ExpiringSoon
+		case "self_signed":
+			ok = cert.SelfSigned
+		default:
+			respondAPIError(w, http.StatusBadRequest, "invalid_parameter", "status 必须是 expired、expiring 或 self_signed")
+			return
+		}
+		if ok {
+			matched = append(matched, cert)
+		}
+	}
+	respondList(w, r, matched, nil)
+}
+
+func (s *Server) handleV1Plugins(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("name")
+	var matched []monitor.PluginInfo
+	for _, plugin := range s.pluginResults() {
+		if filterEqual(name, plugin.Name) {
+			matched = append(matched, plugin)
+		}
+	}
+	respondList(w, r, matched, nil)
+}
+
+func (s *Server) handleV1Scrape(w http.ResponseWriter, r *http.Request) {
+	var info monitor.ScrapeInfo
+	switch value := s.sampler.Snapshot()["scrape"].(type) {
+	case monitor.ScrapeInfo:
+		info = value
+	case TimedOut:
+		writeError(w, r, http.StatusGatewayTimeout, "timeout", fmt.Sprintf("抓取超过 %gs 未完成", value.TimeoutSeconds))
+		return
+	}
+
+	query := r.URL.Query()
+	name, status := query.Get("name"), query.Get("status")
+	var matched []monitor.ScrapeTargetInfo
+	for _, target := range info.Targets {
+		state := "down"
+		if target.Up {
+			state = "up"
+		}
+		if filterEqual(name, target.Name) && filterEqual(status, state) {
+			matched = append(matched, target)
+		}
+	}
+	respondList(w, r, matched, nil)
+}
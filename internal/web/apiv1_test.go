@@ -0,0 +1,133 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"syspulse/internal/config"
+)
+
+func TestRespondListPagination(t *testing.T) {
+	items := make([]int, 250)
+	for i := range items {
+		items[i] = i
+	}
+
+	tests := []struct {
+		query   string
+		status  int
+		first   int
+		count   int
+		limit   int
+		offset  int
+		errorIn string
+	}{
+		{query: "", status: 200, first: 0, count: defaultPageLimit, limit: defaultPageLimit},
+		{query: "limit=10&offset=20", status: 200, first: 20, count: 10, limit: 10, offset: 20},
+		{query: "limit=1000", status: 200, first: 0, count: 250, limit: maxPageLimit},
+		{query: "offset=240", status: 200, first: 240, count: 10, limit: defaultPageLimit, offset: 240},
+		{query: "offset=250", status: 200, count: 0, limit: defaultPageLimit, offset: 250},
+		{query: "offset=10000", status: 200, count: 0, limit: defaultPageLimit, offset: 10000},
+		{query: "limit=0", status: 400, errorIn: "limit"},
+		{query: "limit=1001", status: 400, errorIn: "limit"},
+		{query: "limit=ten", status: 400, errorIn: "limit"},
+		{query: "offset=-1", status: 400, errorIn: "offset"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			respondList(rec, httptest.NewRequest("GET", "/api/v1/things?"+tt.query, nil), items, nil)
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.status)
+			}
+
+			if tt.status != http.StatusOK {
+				var body apiErrorBody
+				if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+					t.Fatal(err)
+				}
+				if body.Error.Code != "invalid_parameter" || len(body.Error.Message) < len(tt.errorIn) || body.Error.Message[:len(tt.errorIn)] != tt.errorIn {
+					t.Errorf("error = %+v", body.Error)
+				}
+				return
+			}
+
+			var page struct {
+				Items  []int           `json:"items"`
+				Total  int             `json:"total"`
+				Offset int             `json:"offset"`
+				Limit  int             `json:"limit"`
+				Errors json.RawMessage `json:"errors"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &page); err != nil {
+				t.Fatal(err)
+			}
+			if page.Total != len(items) || page.Limit != tt.limit || page.Offset != tt.offset || len(page.Items) != tt.count {
+				t.Errorf("page = total %d, limit %d, offset %d, %d items", page.Total, page.Limit, page.Offset, len(page.Items))
+			}
+			if tt.count > 0 && page.Items[0] != tt.first {
+				t.Errorf("first item = %d, want %d", page.Items[0], tt.first)
+			}
+			// 空列表和没有错误时输出 [] 而不是 null
+			if page.Items == nil || string(page.Errors) != "[]" {
+				t.Errorf("items = %v, errors = %s", page.Items, page.Errors)
+			}
+		})
+	}
+}
+
+func TestAPIErrorResponses(t *testing.T) {
+	_, ts := newTestServer(t, config.Default(), nil)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		status int
+		code   string
+		allow  string
+	}{
+		{name: "unknown route", method: "GET", path: "/api/v1/gpus", status: 404, code: "not_found"},
+		{name: "method not allowed", method: "DELETE", path: "/api/v1/config", status: 405, code: "method_not_allowed", allow: "GET, PUT"},
+		{name: "invalid limit", method: "GET", path: "/api/v1/plugins?limit=0", status: 400, code: "invalid_parameter"},
+		{name: "invalid filter", method: "GET", path: "/api/v1/processes?sort=pid", status: 400, code: "invalid_parameter"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(tt.method, ts.URL+tt.path, nil)
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.status {
+				t.Fatalf("status = %d, want %d", resp.StatusCode, tt.status)
+			}
+			if got := resp.Header.Get("Content-Type"); got != "application/json" {
+				t.Errorf("Content-Type = %q", got)
+			}
+			if got := resp.Header.Get("Allow"); got != tt.allow {
+				t.Errorf("Allow = %q, want %q", got, tt.allow)
+			}
+
+			// 错误响应只有 error.code 和 error.message 两个字段
+			var body map[string]map[string]string
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+			detail := body["error"]
+			if len(body) != 1 || detail["code"] != tt.code || detail["message"] == "" {
+				t.Errorf("body = %v, want error code %s", body, tt.code)
+			}
+			if keys := sortedKeys(detail); !reflect.DeepEqual(keys, []string{"code", "message"}) {
+				t.Errorf("error fields = %v", keys)
+			}
+		})
+	}
+}
@@ -0,0 +1,219 @@
+package web
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"syspulse/internal/config"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// tokenHashPrefix 令牌哈希的格式前缀
+const tokenHashPrefix = "sha256:"
+
+// HashPassword 生成用于配置文件的 bcrypt 密码哈希
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// GenerateToken 生成随机 Bearer 令牌及其用于配置文件的哈希
+func GenerateToken() (token, hash string, err error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	token = hex.EncodeToString(buf)
+	return token, HashToken(token), nil
+}
+
+// HashToken 计算令牌的 sha256 哈希（令牌本身是高熵随机值，不需要慢哈希）
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return tokenHashPrefix + hex.EncodeToString(sum[:])
+}
+
+// authenticator 校验 Basic 和 Bearer 认证
+type authenticator struct {
+	users  map[string]config.WebUser
+	tokens []config.WebToken
+
+	// bcrypt 很慢，缓存校验通过的凭据（按 sha256 存储，不保存明文）
+	mu       sync.Mutex
+	verified map[[32]byte]bool
+}
+
+func newAuthenticator(cfg config.WebAuthConfig) *authenticator {
+	a := &authenticator{
+		users:    make(map[string]config.WebUser),
+		verified: make(map[[32]byte]bool),
+	}
+	for _, user := range cfg.Users {
+		a.users[user.Username] = user
+	}
+	for _, token := range cfg.Tokens {
+		token.TokenHash = strings.ToLower(token.TokenHash)
+		a.tokens = append(a.tokens, token)
+	}
+	return a
+}
+
+// authenticate 校验请求携带的凭据，返回对应的身份
+// 浏览器的 WebSocket 和 EventSource 无法设置请求头，因此 /ws 和 /api/stream 也接受 access_token 查询参数
+func (a *authenticator) authenticate(r *http.Request) (principal, bool) {
+	if username, password, ok := r.BasicAuth(); ok {
+		return a.checkPassword(username, password)
+	}
+
+	header := r.Header.Get("Authorization")
+	if token, ok := strings.CutPrefix(header, "Bearer "); ok {
+		return a.checkToken(strings.TrimSpace(token))
+	}
+
+	if r.URL.Path == "/ws" || r.URL.Path == "/api/stream" {
+		if token := r.URL.Query().Get("access_token"); token != "" {
+			return a.checkToken(token)
+		}
+	}
+
+	return principal{}, false
+}
+
+func (a *authenticator) checkPassword(username, password string) (principal, bool) {
+	user, ok := a.users[username]
+	if !ok {
+		return principal{}, false
+	}
+	who := principal{Name: user.Username, Role: parseRole(user.Role)}
+
+	key := sha256.Sum256([]byte(username + "\x00" + password + "\x00" + user.PasswordHash))
+	a.mu.Lock()
+	cached := a.verified[key]
+	a.mu.Unlock()
+	if cached {
+		return who, true
+	}
+
+	if bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)) != nil {
+		return principal{}, false
+	}
+
+	a.mu.Lock()
+	a.verified[key] = true
+	a.mu.Unlock()
+	return who, true
+}
+
+func (a *authenticator) checkToken(token string) (principal, bool) {
+	hash := []byte(HashToken(token))
+	var who principal
+	matched := false
+	for _, expected := range a.tokens {
+		if subtle.ConstantTimeCompare(hash, []byte(expected.TokenHash)) == 1 {
+			who = principal{Name: "token:" + expected.Name, Role: parseRole(expected.Role)}
+			matched = true
+		}
+	}
+	return who, matched
+}
+
+// authMiddleware 识别请求的身份并放入上下文，未通过认证的请求返回 401
+// 未配置认证时所有请求都以 anonymous_role 角色的匿名身份访问
+func (s *Server) authMiddleware(next http.Handler) http.Handler {
+	if !s.config.Web.Auth.Enabled() {
+		anonymous := principal{Name: "anonymous", Role: parseRole(s.config.Web.Auth.AnonymousRole)}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, withPrincipal(r, anonymous))
+		})
+	}
+
+	auth := newAuthenticator(s.config.Web.Auth)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// CORS 预检请求不携带凭据
+		if r.Method == http.MethodOptions {
+			next.ServeHTTP(w, r)
+			return
+		}
+		// 健康检查供 systemd / Kubernetes 使用，不需要认证
+		if r.URL.Path == "/healthz" || r.URL.Path == "/readyz" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		who, ok := auth.authenticate(r)
+		if !ok {
+			w.Header().Set("WWW-Authenticate", `Basic realm="SysPulse", charset="UTF-8"`)
+			writeError(w, r, http.StatusUnauthorized, "unauthorized", "未授权")
+			return
+		}
+		setAccessUser(r, who.Name)
+		next.ServeHTTP(w, withPrincipal(r, who))
+	})
+}
+
+// corsMiddleware 只对配置的来源返回跨域响应头
+func (s *Server) corsMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		listed, wildcard := s.matchOrigin(origin)
+		if origin != "" && (listed || wildcard) {
+			if listed {
+				// 只有明确列出的来源可以携带凭据（Cookie、Basic 认证）
+				w.Header().Set("Access-Control-Allow-Origin", origin)
+				w.Header().Set("Access-Control-Allow-Credentials", "true")
+				w.Header().Add("Vary", "Origin")
+			} else {
+				// "*" 按 CORS 规范返回字面量 *，浏览器不会为其发送凭据，任意网站无法借用户身份读取数据
+				w.Header().Set("Access-Control-Allow-Origin", "*")
+			}
+
+			if r.Method == http.MethodOptions {
+				w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
+				w.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type")
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// matchOrigin 来源是否明确列在 cors_origins 中，以及 cors_origins 是否包含 "*"
+func (s *Server) matchOrigin(origin string) (listed, wildcard bool) {
+	for _, allowed := range s.currentConfig().Web.CORSOrigins {
+		if allowed == "*" {
+			wildcard = true
+		} else if strings.EqualFold(strings.TrimSuffix(allowed, "/"), origin) {
+			listed = true
+		}
+	}
+	return listed, wildcard
+}
+
+// checkWebSocketOrigin 只允许同源或配置的来源建立 WebSocket，防止跨站 WebSocket 劫持
+// 非浏览器客户端不发送 Origin，直接放行（仍需通过认证）
+func (s *Server) checkWebSocketOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	if strings.EqualFold(u.Host, r.Host) {
+		return true
+	}
+	// 浏览器建立 WebSocket 时总会携带凭据，因此 "*" 不放行跨站连接
+	listed, _ := s.matchOrigin(origin)
+	return listed
+}
@@ -0,0 +1,108 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"syspulse/internal/config"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestAuthMiddleware(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("s3cret"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, tokenHash, err := GenerateToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := config.Default()
+	cfg.Web.Auth = config.WebAuthConfig{
+		Users:  []config.WebUser{{Username: "alice", PasswordHash: string(hash), Role: config.RoleOperator}},
+		Tokens: []config.WebToken{{Name: "ci", TokenHash: tokenHash, Role: config.RoleAdmin}},
+	}
+	_, ts := newTestServer(t, cfg, nil)
+
+	basic := func(username, password string) func(*http.Request) {
+		return func(r *http.Request) { r.SetBasicAuth(username, password) }
+	}
+	bearer := func(token string) func(*http.Request) {
+		return func(r *http.Request) { r.Header.Set("Authorization", "Bearer "+token) }
+	}
+
+	tests := []struct {
+		name   string
+		path   string
+		auth   func(*http.Request)
+		status int
+		user   string
+		role   string
+	}{
+		{name: "no credentials", path: "/api/v1/whoami", status: 401},
+		{name: "wrong password", path: "/api/v1/whoami", auth: basic("alice", "wrong"), status: 401},
+		{name: "unknown user", path: "/api/v1/whoami", auth: basic("mallory", "s3cret"), status: 401},
+		{name: "bad token", path: "/api/v1/whoami", auth: bearer("0123456789abcdef"), status: 401},
+		{name: "token hash as token", path: "/api/v1/whoami", auth: bearer(tokenHash), status: 401},
+		{name: "bcrypt password", path: "/api/v1/whoami", auth: basic("alice", "s3cret"), status: 200, user: "alice", role: "operator"},
+		{name: "cached password", path: "/api/v1/whoami", auth: basic("alice", "s3cret"), status: 200, user: "alice", role: "operator"},
+		{name: "bearer token", path: "/api/v1/whoami", auth: bearer(token), status: 200, user: "token:ci", role: "admin"},
+		{name: "query token only for streams", path: "/api/v1/whoami?access_token=" + token, status: 401},
+		{name: "healthz without credentials", path: "/healthz", status: 200},
+		{name: "metrics without credentials", path: "/metrics", status: 401},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", ts.URL+tt.path, nil)
+			if tt.auth != nil {
+				tt.auth(req)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.status {
+				t.Fatalf("status = %d, want %d", resp.StatusCode, tt.status)
+			}
+			if tt.status == http.StatusUnauthorized && resp.Header.Get("WWW-Authenticate") == "" {
+				t.Error("401 without WWW-Authenticate")
+			}
+			if tt.user == "" {
+				return
+			}
+			var who whoamiResult
+			if err := json.NewDecoder(resp.Body).Decode(&who); err != nil {
+				t.Fatal(err)
+			}
+			if who.Name != tt.user || who.Role != tt.role {
+				t.Errorf("whoami = %+v, want %s (%s)", who, tt.user, tt.role)
+			}
+		})
+	}
+}
+
+func TestAuthDisabledUsesAnonymousRole(t *testing.T) {
+	cfg := config.Default()
+	cfg.Web.Auth.AnonymousRole = config.RoleOperator
+	_, ts := newTestServer(t, cfg, nil)
+
+	resp, err := http.Get(ts.URL + "/api/v1/whoami")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	var who whoamiResult
+	if err := json.NewDecoder(resp.Body).Decode(&who); err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK || who.Name != "anonymous" || who.Role != "operator" {
+		t.Errorf("status %d, whoami = %+v", resp.StatusCode, who)
+	}
+}
@@ -1,140 +1,621 @@
 package web
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
 	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
 	"strconv"
+	"sync"
 	"time"
 
+	"syspulse/internal/alert"
+	"syspulse/internal/config"
 	"syspulse/internal/monitor"
 
 	"github.com/gorilla/mux"
 	"github.com/gorilla/websocket"
+	"gopkg.in/yaml.v3"
 )
 
+// collect 在子系统的采集超时内执行采集，超时时返回 504（/api/v1 下为 timeout 错误）和 false
+func collect[T any](s *Server, w http.ResponseWriter, r *http.Request, subsystem string, fn func(context.Context) T) (T, bool) {
+	timeout := s.currentConfig().CollectTimeout(subsystem)
+	info, ok := monitor.Collect(r.Context(), timeout, fn)
+	if !ok {
+		writeError(w, r, http.StatusGatewayTimeout, "timeout", fmt.Sprintf("采集 %s 超过 %s 未完成", subsystem, timeout))
+	}
+	return info, ok
+}
+
+// collectContext 返回带子系统采集超时的请求上下文
+func (s *Server) collectContext(r *http.Request, subsystem string) (context.Context, context.CancelFunc) {
+	if timeout := s.currentConfig().CollectTimeout(subsystem); timeout > 0 {
+		return context.WithTimeout(r.Context(), timeout)
+	}
+	return context.WithCancel(r.Context())
+}
+
 // handleSystem 处理系统信息请求
-func handleSystem(w http.ResponseWriter, r *http.Request) {
-	info := monitor.GetSystemInfo()
-	respondJSON(w, info)
+func (s *Server) handleSystem(w http.ResponseWriter, r *http.Request) {
+	info, ok := collect(s, w, r, "system", func(ctx context.Context) monitor.SystemInfo { return monitor.GetSystemInfo(ctx) })
+	if ok {
+		respondJSON(w, info)
+	}
 }
 
 // handleCPU 处理 CPU 信息请求
-func handleCPU(w http.ResponseWriter, r *http.Request) {
-	info := monitor.GetCPUInfo()
-	respondJSON(w, info)
+func (s *Server) handleCPU(w http.ResponseWriter, r *http.Request) {
+	info, ok := collect(s, w, r, "cpu", func(ctx context.Context) monitor.CPUInfo { return monitor.GetCPUInfo(ctx) })
+	if ok {
+		respondJSON(w, info)
+	}
 }
 
 // handleMemory 处理内存信息请求
-func handleMemory(w http.ResponseWriter, r *http.Request) {
-	info := monitor.GetMemoryInfo()
-	respondJSON(w, info)
+func (s *Server) handleMemory(w http.ResponseWriter, r *http.Request) {
+	info, ok := collect(s, w, r, "memory", func(ctx context.Context) monitor.MemoryInfo { return monitor.GetMemoryInfo(ctx) })
+	if ok {
+		respondJSON(w, info)
+	}
+}
+
+// handlePressure 处理资源压力 (PSI) 请求
+func (s *Server) handlePressure(w http.ResponseWriter, r *http.Request) {
+	info, ok := collect(s, w, r, "pressure", func(ctx context.Context) monitor.PressureInfo { return monitor.GetPressureInfo(ctx) })
+	if ok {
+		respondJSON(w, info)
+	}
+}
+
+// handleAlerts 处理告警请求
+func (s *Server) handleAlerts(w http.ResponseWriter, r *http.Request) {
+	respondJSON(w, s.evaluateAlerts(r.Context()))
+}
+
+// evaluateAlerts 采集告警所需的指标并按默认规则求值，超时的子系统不参与求值
+func (s *Server) evaluateAlerts(ctx context.Context) []alert.Alert {
+	cfg := s.currentConfig()
+	metrics := alert.Metrics{}
+	if info, ok := monitor.Collect(ctx, cfg.CollectTimeout("pressure"), monitor.GetPressureInfo); ok {
+		metrics.AddPressure(info)
+	}
+	if info, ok := monitor.Collect(ctx, cfg.CollectTimeout("sensors"), monitor.GetSensorsInfo); ok {
+		metrics.AddSensors(info)
+	}
+	if info, ok := monitor.Collect(ctx, cfg.CollectTimeout("protocols"), monitor.GetProtocolStats); ok {
+		metrics.AddProtocolStats(info)
+	}
+	metrics.AddProbes(s.probes.Info())
+	metrics.AddCertificates(s.certificates(ctx, false, false))
+	return alert.Evaluate(alert.DefaultRules(), metrics)
+}
+
+// handleProbes 处理连通性探测结果请求（返回后台最近一次探测的结果）
+func (s *Server) handleProbes(w http.ResponseWriter, r *http.Request) {
+	respondJSON(w, s.probes.Info())
+}
+
+// certCacheTTL 证书很少变化，扫描结果缓存一段时间，避免每次请求都握手所有端口
+const certCacheTTL = 10 * time.Minute
+
+// certCache 最近一次证书扫描的结果
+// 扫描（最长为 certificates 的超时）在后台进行，不持有锁，扫描期间请求读取上一次的结果
+type certCache struct {
+	mu   sync.Mutex
+	info monitor.CertificatesInfo
+	at   time.Time
+	// scanning 正在进行的扫描，完成时关闭；没有扫描时为 nil
+	scanning chan struct{}
+}
+
+// certificates 返回缓存的证书扫描结果，过期或 refresh 时在后台重新扫描
+// wait 为 true 时，还没有结果或要求 refresh 的请求等待扫描完成（同时到达的请求共享同一次扫描）；
+// 告警求值传入 false，只使用已有的结果，不会因冷缓存而阻塞
+func (s *Server) certificates(ctx context.Context, refresh, wait bool) monitor.CertificatesInfo {
+	s.certs.mu.Lock()
+	cold := s.certs.at.IsZero()
+	if (refresh || cold || time.Since(s.certs.at) > certCacheTTL) && s.certs.scanning == nil {
+		s.certs.scanning = make(chan struct{})
+		go s.scanCertificates(s.certs.scanning)
+	}
+	scanning, info := s.certs.scanning, s.certs.info
+	s.certs.mu.Unlock()
+
+	if !wait || scanning == nil || !(refresh || cold) {
+		return info
+	}
+	select {
+	case <-scanning:
+	case <-ctx.Done():
+		return info
+	}
+	s.certs.mu.Lock()
+	defer s.certs.mu.Unlock()
+	return s.certs.info
+}
+
+// scanCertificates 执行一次证书扫描并更新缓存，完成后关闭 done
+func (s *Server) scanCertificates(done chan struct{}) {
+	cfg := s.currentConfig()
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.CollectTimeout("certificates"))
+	defer cancel()
+	info := monitor.GetCertificateInfo(ctx, cfg.CertScanOptions())
+
+	s.certs.mu.Lock()
+	defer s.certs.mu.Unlock()
+	s.certs.info, s.certs.at = info, time.Now()
+	s.certs.scanning = nil
+	close(done)
+}
+
+// handleCerts 处理 TLS 证书扫描请求（?refresh=1 强制重新扫描）
+func (s *Server) handleCerts(w http.ResponseWriter, r *http.Request) {
+	respondJSON(w, s.certificates(r.Context(), r.URL.Query().Get("refresh") == "1", true))
+}
+
+// handleSensors 处理传感器信息请求
+func (s *Server) handleSensors(w http.ResponseWriter, r *http.Request) {
+	info, ok := collect(s, w, r, "sensors", func(ctx context.Context) monitor.SensorsInfo { return monitor.GetSensorsInfo(ctx) })
+	if ok {
+		respondJSON(w, info)
+	}
 }
 
 // handleDisk 处理磁盘信息请求
-func handleDisk(w http.ResponseWriter, r *http.Request) {
-	info := monitor.GetDiskInfo()
-	respondJSON(w, info)
+func (s *Server) handleDisk(w http.ResponseWriter, r *http.Request) {
+	filter := s.currentConfig().DiskFilter()
+	info, ok := collect(s, w, r, "disk", func(ctx context.Context) monitor.DiskInfo { return monitor.GetDiskInfo(ctx, filter) })
+	if ok {
+		respondJSON(w, info)
+	}
+}
+
+// handleDiskHealth 处理磁盘 SMART 健康状态请求
+func (s *Server) handleDiskHealth(w http.ResponseWriter, r *http.Request) {
+	info, ok := collect(s, w, r, "disk_health", func(ctx context.Context) monitor.DriveHealthInfo { return monitor.GetDriveHealth(ctx) })
+	if ok {
+		respondJSON(w, info)
+	}
+}
+
+// 目录占用扫描会遍历整个目录树并占用 4×CPU 个并发读取，对参数和同时进行的扫描数都有上限
+const (
+	maxDirUsageDepth   = 64
+	maxDirUsageTop     = 1000
+	maxDirUsageTimeout = 3600
+	// maxDirUsageScans 同时进行的扫描数，超出时返回 429
+	maxDirUsageScans = 2
+)
+
+// handleDiskUsage 处理目录占用分析请求
+func (s *Server) handleDiskUsage(w http.ResponseWriter, r *http.Request) {
+	if info, ok := s.dirUsage(w, r); ok {
+		respondJSON(w, info)
+	}
+}
+
+// dirUsage 解析目录占用参数并扫描（旧版和 /api/v1 共用），出错时写入响应并返回 false
+// 扫描结果会列出文件的完整路径，路由要求 operator 角色
+func (s *Server) dirUsage(w http.ResponseWriter, r *http.Request) (monitor.DirUsageInfo, bool) {
+	query := r.URL.Query()
+
+	path := query.Get("path")
+	if path == "" {
+		path = "/"
+	}
+
+	opts := monitor.DefaultDirUsageOptions()
+	var err error
+	if opts.MaxDepth, err = intParam(query.Get("depth"), opts.MaxDepth, 0, maxDirUsageDepth); err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid_parameter", "depth "+err.Error())
+		return monitor.DirUsageInfo{}, false
+	}
+	if opts.TopN, err = intParam(query.Get("top"), opts.TopN, 1, maxDirUsageTop); err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid_parameter", "top "+err.Error())
+		return monitor.DirUsageInfo{}, false
+	}
+	timeout, err := intParam(query.Get("timeout"), int(opts.Timeout/time.Second), 1, maxDirUsageTimeout)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid_parameter", "timeout "+err.Error())
+		return monitor.DirUsageInfo{}, false
+	}
+	opts.Timeout = time.Duration(timeout) * time.Second
+
+	select {
+	case s.dirUsageScans <- struct{}{}:
+		defer func() { <-s.dirUsageScans }()
+	default:
+		writeError(w, r, http.StatusTooManyRequests, "busy", fmt.Sprintf("已有 %d 个目录扫描正在进行，请稍后重试", maxDirUsageScans))
+		return monitor.DirUsageInfo{}, false
+	}
+
+	info, err := monitor.GetDirUsage(r.Context(), path, opts)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		writeError(w, r, http.StatusNotFound, "not_found", err.Error())
+		return info, false
+	case err != nil:
+		writeError(w, r, http.StatusBadRequest, "invalid_parameter", err.Error())
+		return info, false
+	}
+	return info, true
 }
 
 // handleNetwork 处理网络信息请求
-func handleNetwork(w http.ResponseWriter, r *http.Request) {
-	info := monitor.GetNetworkInfo()
-	respondJSON(w, info)
+func (s *Server) handleNetwork(w http.ResponseWriter, r *http.Request) {
+	filter := s.currentConfig().NetworkFilter()
+	info, ok := collect(s, w, r, "network", func(ctx context.Context) monitor.NetworkInfo {
+		return monitor.GetNetworkInfo(ctx, filter)
+	})
+	if ok {
+		respondJSON(w, info)
+	}
+}
+
+// handleProtocolStats 处理协议统计请求
+func (s *Server) handleProtocolStats(w http.ResponseWriter, r *http.Request) {
+	info, ok := collect(s, w, r, "protocols", func(ctx context.Context) monitor.ProtocolStats { return monitor.GetProtocolStats(ctx) })
+	if ok {
+		respondJSON(w, info)
+	}
+}
+
+// handleProcessNetwork 处理进程网络带宽请求
+func (s *Server) handleProcessNetwork(w http.ResponseWriter, r *http.Request) {
+	top := defaultNetTopCount
+	if n, err := strconv.Atoi(r.URL.Query().Get("top")); err == nil {
+		top = n
+	}
+	info, ok := collect(s, w, r, "nettop", func(ctx context.Context) monitor.ProcessNetworkInfo {
+		return monitor.GetProcessNetworkInfo(ctx, top)
+	})
+	if ok {
+		respondJSON(w, info)
+	}
 }
 
 // handleProcess 处理进程信息请求
-func handleProcess(w http.ResponseWriter, r *http.Request) {
+func (s *Server) handleProcess(w http.ResponseWriter, r *http.Request) {
 	topN := 10
 	if topNStr := r.URL.Query().Get("top"); topNStr != "" {
 		if n, err := strconv.Atoi(topNStr); err == nil {
 			topN = n
 		}
 	}
-	info := monitor.GetProcessInfo(topN)
-	respondJSON(w, info)
+	info, ok := collect(s, w, r, "process", func(ctx context.Context) monitor.ProcessInfo {
+		return monitor.GetProcessInfo(ctx, topN)
+	})
+	if ok {
+		respondJSON(w, redactProcessInfo(info, principalFrom(r)))
+	}
 }
 
 // handleDocker 处理 Docker 信息请求
-func handleDocker(w http.ResponseWriter, r *http.Request) {
-	info := monitor.GetDockerInfo()
-	respondJSON(w, info)
+func (s *Server) handleDocker(w http.ResponseWriter, r *http.Request) {
+	info, ok := collect(s, w, r, "docker", func(ctx context.Context) monitor.DockerInfo { return monitor.GetDockerInfo(ctx) })
+	if ok {
+		respondJSON(w, info)
+	}
 }
 
 // handleDockerDetail 处理 Docker 容器详情请求
-func handleDockerDetail(w http.ResponseWriter, r *http.Request) {
+func (s *Server) handleDockerDetail(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	containerID := vars["id"]
-	info := monitor.GetContainerDetail(containerID)
+	ctx, cancel := s.collectContext(r, "docker")
+	defer cancel()
+	info, err := monitor.FindContainer(ctx, containerID)
+	switch {
+	case errors.Is(err, monitor.ErrContainerNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	case errors.Is(err, context.DeadlineExceeded):
+		http.Error(w, err.Error(), http.StatusGatewayTimeout)
+		return
+	}
 	respondJSON(w, info)
 }
 
-// handleAll 处理所有信息请求
-func handleAll(w http.ResponseWriter, r *http.Request) {
-	data := map[string]interface{}{
-		"system":  monitor.GetSystemInfo(),
-		"cpu":     monitor.GetCPUInfo(),
-		"memory":  monitor.GetMemoryInfo(),
-		"disk":    monitor.GetDiskInfo(),
-		"network": monitor.GetNetworkInfo(),
-		"ports":   monitor.GetPortInfo(),
-		"docker":  monitor.GetDockerInfo(),
+// handleDockerRestart 重启容器（需要 operator 角色）
+func handleDockerRestart(w http.ResponseWriter, r *http.Request) {
+	containerID := mux.Vars(r)["id"]
+	if err := monitor.RestartContainer(r.Context(), containerID); err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	respondJSON(w, map[string]string{"restarted": containerID})
+}
+
+// handleServices 处理 systemd 服务信息请求
+func (s *Server) handleServices(w http.ResponseWriter, r *http.Request) {
+	info, ok := collect(s, w, r, "services", func(ctx context.Context) monitor.ServicesInfo { return monitor.GetServicesInfo(ctx) })
+	if ok {
+		respondJSON(w, info)
 	}
-	respondJSON(w, data)
+}
+
+// handleAll 处理所有信息请求（返回后台最近一次采集的数据）
+func (s *Server) handleAll(w http.ResponseWriter, r *http.Request) {
+	respondJSON(w, s.snapshotFor(principalFrom(r)))
+}
+
+// snapshotFor 返回后台采集的最新数据，并按请求者的角色去掉不可见的字段
+func (s *Server) snapshotFor(who principal) map[string]interface{} {
+	data := s.sampler.Snapshot()
+	if info, ok := data["process"].(monitor.ProcessInfo); ok {
+		data["process"] = redactProcessInfo(limitProcesses(info, defaultProcessTop), who)
+	}
+	return data
+}
+
+// handleMetrics 以 Prometheus 文本格式导出后台最近一次采集的指标
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	monitor.WritePrometheusText(w, s.sampler.Metrics())
+}
+
+// pluginResults 返回所有插件最近一次执行的结果，超时或还未执行的插件带有说明
+func (s *Server) pluginResults() []monitor.PluginInfo {
+	data := s.sampler.Snapshot()
+	results := make([]monitor.PluginInfo, 0, len(s.plugins))
+	for _, name := range s.plugins {
+		switch value := data[name].(type) {
+		case monitor.PluginInfo:
+			results = append(results, value)
+		case TimedOut:
+			results = append(results, monitor.PluginInfo{Name: name, Error: fmt.Sprintf("执行超过 %gs 未完成", value.TimeoutSeconds)})
+		default:
+			results = append(results, monitor.PluginInfo{Name: name, Error: "尚未执行"})
+		}
+	}
+	return results
 }
 
 // handlePort 处理端口信息请求
-func handlePort(w http.ResponseWriter, r *http.Request) {
-	info := monitor.GetPortInfo()
-	respondJSON(w, info)
+func (s *Server) handlePort(w http.ResponseWriter, r *http.Request) {
+	info, ok := collect(s, w, r, "ports", func(ctx context.Context) monitor.PortInfo { return monitor.GetPortInfo(ctx) })
+	if ok {
+		respondJSON(w, info)
+	}
 }
 
+// wsWriteTimeout WebSocket 单次写入的超时时间，客户端不读数据时及时断开
+const wsWriteTimeout = 10 * time.Second
+
 // handleWebSocket 处理 WebSocket 连接
-func handleWebSocket(w http.ResponseWriter, r *http.Request) {
-	conn, err := upgrader.Upgrade(w, r, nil)
+// 协商了 syspulse.v1 子协议的客户端使用订阅协议（见 websocket.go），否则按间隔推送完整数据
+func (s *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
+	s.wsWG.Add(1)
+	defer s.wsWG.Done()
+
+	conn, err := s.upgrader.Upgrade(w, r, nil)
 	if err != nil {
 		return
 	}
 	defer conn.Close()
+	defer s.trackWebSocket(conn)()
 
 	// 获取刷新间隔（默认 2 秒）
 	interval := 2 * time.Second
 	if intervalStr := r.URL.Query().Get("interval"); intervalStr != "" {
-		if seconds, err := strconv.Atoi(intervalStr); err == nil {
+		if seconds, err := strconv.Atoi(intervalStr); err == nil && seconds > 0 {
 			interval = time.Duration(seconds) * time.Second
 		}
 	}
 
+	who := principalFrom(r)
+	if conn.Subprotocol() == wsSubprotocol {
+		s.serveSubscriptions(conn, who, interval)
+		return
+	}
+
 	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
 	// 立即发送第一次数据
-	sendAllData(conn)
+	if err := s.sendAllData(conn, who); err != nil {
+		return
+	}
 
-	// 定期发送更新
-	for range ticker.C {
-		if err := sendAllData(conn); err != nil {
-			break
+	// 定期发送更新，服务器关闭时退出
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.sendAllData(conn, who); err != nil {
+				return
+			}
+		case <-s.done:
+			return
 		}
 	}
 }
 
-func sendAllData(conn *websocket.Conn) error {
-	data := map[string]interface{}{
-		"system":  monitor.GetSystemInfo(),
-		"cpu":     monitor.GetCPUInfo(),
-		"memory":  monitor.GetMemoryInfo(),
-		"disk":    monitor.GetDiskInfo(),
-		"network": monitor.GetNetworkInfo(),
-		"ports":   monitor.GetPortInfo(),
-		"docker":  monitor.GetDockerInfo(),
-		"process": monitor.GetProcessInfo(10),
+func (s *Server) sendAllData(conn *websocket.Conn, who principal) error {
+	conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+	return conn.WriteJSON(s.snapshotFor(who))
+}
+
+// handleHealthz 存活检查：进程能处理请求即返回 200
+func handleHealthz(w http.ResponseWriter, r *http.Request) {
+	respondJSON(w, map[string]string{"status": "ok"})
+}
+
+// handleReadyz 就绪检查：所有后台采集器在 staleAfter 内完成过采集时返回 200，否则返回 503
+func (s *Server) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	collectors, ready := s.sampler.Health()
+
+	status := "ready"
+	switch {
+	case s.draining.Load():
+		status = "draining"
+	case !ready:
+		status = "not ready"
+	}
+	if status != "ready" {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	respondJSON(w, map[string]interface{}{
+		"status":     status,
+		"collectors": collectors,
+	})
+}
+
+// handleWhoami 返回当前请求者的身份和角色，前端据此显示可用的操作
+func handleWhoami(w http.ResponseWriter, r *http.Request) {
+	who := principalFrom(r)
+	respondJSON(w, map[string]string{
+		"name": who.Name,
+		"role": who.Role.String(),
+	})
+}
+
+// maxConfigSize 通过 API 上传的配置文件大小上限
+const maxConfigSize = 1 << 20
+
+// handleGetConfig 返回当前配置文件内容（需要 admin 角色）
+func (s *Server) handleGetConfig(w http.ResponseWriter, r *http.Request) {
+	cfg := s.currentConfig()
+
+	var data []byte
+	var err error
+	if cfg.Path() != "" {
+		data, err = os.ReadFile(cfg.Path())
+	} else {
+		data, err = yaml.Marshal(cfg)
 	}
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, "internal", err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/yaml; charset=utf-8")
+	w.Write(data)
+}
 
-	return conn.WriteJSON(data)
+// handlePutConfig 校验并保存新的 YAML 配置（需要 admin 角色）
+// 磁盘、网络、证书和跨域设置立即生效，认证、TLS 和探测设置需要重启 web 服务
+func (s *Server) handlePutConfig(w http.ResponseWriter, r *http.Request) {
+	path := s.currentConfig().Path()
+	if path == "" {
+		writeError(w, r, http.StatusConflict, "conflict", "未加载配置文件，请使用 --config 指定配置文件后再修改")
+		return
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r.Body, maxConfigSize+1))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid_body", err.Error())
+		return
+	}
+	if len(data) > maxConfigSize {
+		writeError(w, r, http.StatusRequestEntityTooLarge, "too_large", "配置文件过大")
+		return
+	}
+
+	cfg, err := config.Parse(data)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid_config", fmt.Sprintf("配置无效: %v", err))
+		return
+	}
+	if field := lockedConfigChange(s.currentConfig(), cfg); field != "" {
+		writeError(w, r, http.StatusForbidden, "forbidden", fmt.Sprintf("%s 不能通过 API 修改，请直接编辑配置文件 %s", field, path))
+		return
+	}
+	if err := writeFileAtomic(path, data); err != nil {
+		writeError(w, r, http.StatusInternalServerError, "internal", fmt.Sprintf("保存配置失败: %v", err))
+		return
+	}
+	cfg.SetPath(path)
+
+	s.mu.Lock()
+	s.config = cfg
+	s.mu.Unlock()
+
+	restartRequired := []string{"web.auth", "web.tls", "probes", "scrape"}
+	if isAPIV1(r) {
+		respondAPI(w, configSaveResult{Saved: path, RestartRequired: restartRequired})
+		return
+	}
+	respondJSON(w, map[string]interface{}{
+		"saved":           path,
+		"restartRequired": restartRequired,
+	})
+}
+
+// lockedConfigChange 返回修改了的、不允许通过 API 修改的配置项（没有时返回空字符串）
+// 插件会以服务进程的身份执行命令，抓取、探测和证书扫描目标会让服务端请求任意地址，
+// 审计日志和访问日志是服务端写入的文件路径，因此即使是 admin 也只能通过编辑配置文件修改
+func lockedConfigChange(current, next *config.Config) string {
+	scrapeURLs := func(c *config.Config) interface{} {
+		var urls []string
+		for _, target := range c.Scrape.Targets {
+			urls = append(urls, target.URL)
+		}
+		return urls
+	}
+	locked := []struct {
+		field string
+		value func(c *config.Config) interface{}
+	}{
+		{"plugins", func(c *config.Config) interface{} { return c.Plugins }},
+		{"scrape.targets[].url", scrapeURLs},
+		{"probes.targets", func(c *config.Config) interface{} { return c.Probes.Targets }},
+		{"certificates.targets", func(c *config.Config) interface{} { return c.Certificates.Targets }},
+		{"web.audit_log", func(c *config.Config) interface{} { return c.Web.AuditLog }},
+		{"web.access_log", func(c *config.Config) interface{} { return c.Web.AccessLog }},
+	}
+	for _, item := range locked {
+		if !sameSetting(item.value(current), item.value(next)) {
+			return item.field
+		}
+	}
+	return ""
+}
+
+// sameSetting 比较两个配置值，空列表与省略等价
+func sameSetting(a, b interface{}) bool {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if va.Kind() == reflect.Slice && va.Len() == 0 && vb.Len() == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// writeFileAtomic 先写临时文件再重命名，避免写入中途失败留下损坏的配置，并保留原文件权限
+func writeFileAtomic(path string, data []byte) error {
+	mode := os.FileMode(0600)
+	if stat, err := os.Stat(path); err == nil {
+		mode = stat.Mode().Perm()
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".syspulse-config-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(mode); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
 }
 
 func respondJSON(w http.ResponseWriter, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
-	w.Header().Set("Access-Control-Allow-Origin", "*")
 	json.NewEncoder(w).Encode(data)
 }
@@ -0,0 +1,162 @@
+package web
+
+import (
+	"strings"
+	"testing"
+
+	"syspulse/internal/config"
+)
+
+func TestLockedConfigChange(t *testing.T) {
+	const current = `
+plugins:
+  - name: backup
+    command: /usr/local/bin/check-backup
+scrape:
+  targets:
+    - name: app
+      url: http://127.0.0.1:8080/metrics
+      pins:
+        - series: http_requests_total
+probes:
+  targets:
+    - name: api
+      type: http
+      target: http://127.0.0.1:8080/health
+certificates:
+  targets:
+    - example.com:443
+web:
+  audit_log: /var/log/syspulse/audit.log
+  access_log: stdout
+`
+
+	tests := []struct {
+		name string
+		next string
+		want string
+	}{
+		{name: "unchanged", next: current, want: ""},
+		{
+			name: "other settings and pins",
+			next: `
+web:
+  cors_origins: ["https://ops.example.com"]
+  audit_log: /var/log/syspulse/audit.log
+  access_log: stdout
+plugins:
+  - name: backup
+    command: /usr/local/bin/check-backup
+scrape:
+  interval: 30s
+  targets:
+    - name: app
+      url: http://127.0.0.1:8080/metrics
+      pins:
+        - series: process_resident_memory_bytes
+probes:
+  interval: 1m
+  targets:
+    - name: api
+      type: http
+      target: http://127.0.0.1:8080/health
+certificates:
+  warn_days: 14
+  targets:
+    - example.com:443
+`,
+			want: "",
+		},
+		{
+			name: "plugin command",
+			next: `
+plugins:
+  - name: backup
+    command: /bin/sh
+    args: ["-c", "id"]
+scrape:
+  targets:
+    - name: app
+      url: http://127.0.0.1:8080/metrics
+`,
+			want: "plugins",
+		},
+		{
+			name: "plugin removed",
+			next: `
+scrape:
+  targets:
+    - name: app
+      url: http://127.0.0.1:8080/metrics
+`,
+			want: "plugins",
+		},
+		{
+			name: "scrape url",
+			next: `
+plugins:
+  - name: backup
+    command: /usr/local/bin/check-backup
+scrape:
+  targets:
+    - name: app
+      url: http://169.254.169.254/latest/meta-data
+`,
+			want: "scrape.targets[].url",
+		},
+		{
+			name: "scrape target added",
+			next: strings.Replace(current, "        - series: http_requests_total\n", "        - series: http_requests_total\n    - name: other\n      url: http://10.0.0.5:9100/metrics\n", 1),
+			want: "scrape.targets[].url",
+		},
+		{
+			name: "probe target",
+			next: strings.Replace(current, "http://127.0.0.1:8080/health", "http://169.254.169.254/latest/meta-data", 1),
+			want: "probes.targets",
+		},
+		{
+			name: "certificate target",
+			next: strings.Replace(current, "example.com:443", "10.0.0.5:6379", 1),
+			want: "certificates.targets",
+		},
+		{
+			name: "audit log",
+			next: strings.Replace(current, "/var/log/syspulse/audit.log", "/etc/cron.d/syspulse", 1),
+			want: "web.audit_log",
+		},
+		{
+			name: "access log",
+			next: strings.Replace(current, "access_log: stdout", "access_log: /root/.ssh/authorized_keys", 1),
+			want: "web.access_log",
+		},
+	}
+
+	currentConfig, err := config.Parse([]byte(current))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next, err := config.Parse([]byte(tt.next))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := lockedConfigChange(currentConfig, next); got != tt.want {
+				t.Errorf("lockedConfigChange = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	// 没有插件时，plugins: [] 与省略等价
+	empty, err := config.Parse([]byte("web:\n  port: 3000\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	emptyList, err := config.Parse([]byte("plugins: []\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := lockedConfigChange(empty, emptyList); got != "" {
+		t.Errorf("empty plugin list reported as change: %q", got)
+	}
+}
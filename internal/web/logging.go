@@ -0,0 +1,152 @@
+package web
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// responseRecorder 记录响应状态码和字节数，同时保留 WebSocket 需要的 Hijack 和流式响应需要的 Flush
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+func newResponseRecorder(w http.ResponseWriter) *responseRecorder {
+	return &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	n, err := r.ResponseWriter.Write(data)
+	r.bytes += int64(n)
+	return n, err
+}
+
+func (r *responseRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (r *responseRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := r.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("响应不支持 Hijack")
+	}
+	r.status = http.StatusSwitchingProtocols
+	return hijacker.Hijack()
+}
+
+// Unwrap 供 http.ResponseController 访问底层连接
+func (r *responseRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// accessEntry 访问日志的一条记录（JSON Lines 格式）
+type accessEntry struct {
+	Time       time.Time `json:"time"`
+	Remote     string    `json:"remote"`
+	User       string    `json:"user,omitempty"`
+	Method     string    `json:"method"`
+	Path       string    `json:"path"`
+	Status     int       `json:"status"`
+	Bytes      int64     `json:"bytes"`
+	DurationMs float64   `json:"duration_ms"`
+	UserAgent  string    `json:"user_agent,omitempty"`
+}
+
+type accessEntryKey struct{}
+
+// setAccessUser 把认证后的用户名记录到访问日志（认证在日志中间件内层执行）
+func setAccessUser(r *http.Request, user string) {
+	if entry, ok := r.Context().Value(accessEntryKey{}).(*accessEntry); ok {
+		entry.User = user
+	}
+}
+
+// accessLogger 把每个请求写成一行 JSON
+type accessLogger struct {
+	mu   sync.Mutex
+	out  io.Writer
+	file *os.File
+}
+
+// openAccessLog 打开访问日志：stdout（默认）、stderr、off（返回 nil）或文件路径
+func openAccessLog(target string) (*accessLogger, error) {
+	switch target {
+	case "", "stdout":
+		return &accessLogger{out: os.Stdout}, nil
+	case "stderr":
+		return &accessLogger{out: os.Stderr}, nil
+	case "off":
+		return nil, nil
+	}
+
+	file, err := os.OpenFile(target, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0640)
+	if err != nil {
+		return nil, fmt.Errorf("打开访问日志失败: %w", err)
+	}
+	return &accessLogger{out: file, file: file}, nil
+}
+
+// Close 关闭访问日志文件
+func (l *accessLogger) Close() error {
+	if l == nil || l.file == nil {
+		return nil
+	}
+	return l.file.Close()
+}
+
+// middleware 记录访问日志（健康检查请求过于频繁，不记录）
+func (l *accessLogger) middleware(next http.Handler) http.Handler {
+	if l == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/healthz" || r.URL.Path == "/readyz" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		entry := &accessEntry{
+			Time:      time.Now(),
+			Remote:    r.RemoteAddr,
+			Method:    r.Method,
+			Path:      r.URL.Path,
+			UserAgent: r.UserAgent(),
+		}
+		rec := newResponseRecorder(w)
+		next.ServeHTTP(rec, r.WithContext(context.WithValue(r.Context(), accessEntryKey{}, entry)))
+
+		entry.Status = rec.status
+		entry.Bytes = rec.bytes
+		entry.DurationMs = float64(time.Since(entry.Time)) / float64(time.Millisecond)
+		l.write(entry)
+	})
+}
+
+func (l *accessLogger) write(entry *accessEntry) {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	line = append(line, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.out.Write(line)
+}
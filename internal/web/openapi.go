@@ -0,0 +1,173 @@
+package web
+
+import (
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"syspulse/internal/monitor"
+)
+
+// openAPIVersion /api/v1 文档的版本号，接口有不兼容变化时应改用新的路径前缀
+const openAPIVersion = "1.0.0"
+
+var (
+	openAPIOnce sync.Once
+	openAPIDoc  map[string]interface{}
+)
+
+// handleOpenAPI 返回根据路由表生成的 OpenAPI 3.0 文档
+func (s *Server) handleOpenAPI(w http.ResponseWriter, r *http.Request) {
+	// 路由表和类型在运行期间不会变化，只生成一次
+	openAPIOnce.Do(func() {
+		openAPIDoc = buildOpenAPI(s.apiRoutes())
+	})
+	respondJSON(w, openAPIDoc)
+}
+
+// buildOpenAPI 生成 OpenAPI 文档
+func buildOpenAPI(routes []apiRoute) map[string]interface{} {
+	schemas := newSchemaBuilder()
+	schemas.components["Error"] = map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"error": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"code":    map[string]interface{}{"type": "string"},
+					"message": map[string]interface{}{"type": "string"},
+				},
+			},
+		},
+	}
+
+	paths := make(map[string]interface{})
+	for _, route := range routes {
+		item, ok := paths[route.path].(map[string]interface{})
+		if !ok {
+			item = make(map[string]interface{})
+			paths[route.path] = item
+		}
+		item[strings.ToLower(route.method)] = operation(route, schemas)
+	}
+	paths["/openapi.json"] = map[string]interface{}{
+		"get": map[string]interface{}{
+			"summary":   "本文档",
+			"security":  []interface{}{},
+			"responses": map[string]interface{}{"200": map[string]interface{}{"description": "OpenAPI 文档"}},
+		},
+	}
+
+	return map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":       "syspulse API",
+			"version":     openAPIVersion,
+			"description": "字段名为 snake_case，时间为 RFC 3339，时长单位为秒。列表接口支持 limit 和 offset 分页。",
+		},
+		"servers":  []interface{}{map[string]interface{}{"url": apiV1Prefix}},
+		"paths":    paths,
+		"security": []interface{}{map[string]interface{}{"basic": []string{}}, map[string]interface{}{"bearer": []string{}}},
+		"components": map[string]interface{}{
+			"schemas": schemas.components,
+			"securitySchemes": map[string]interface{}{
+				"basic":  map[string]interface{}{"type": "http", "scheme": "basic"},
+				"bearer": map[string]interface{}{"type": "http", "scheme": "bearer"},
+			},
+		},
+	}
+}
+
+// operation 生成一个路由的 operation 对象
+func operation(route apiRoute, schemas *schemaBuilder) map[string]interface{} {
+	params := make([]interface{}, 0, len(route.params)+2)
+	for _, p := range route.params {
+		params = append(params, map[string]interface{}{
+			"name":        p.name,
+			"in":          p.in,
+			"required":    p.in == "path",
+			"description": p.description,
+			"schema":      map[string]interface{}{"type": p.kind},
+		})
+	}
+
+	var content map[string]interface{}
+	switch {
+	case route.response == nil:
+		content = map[string]interface{}{"application/yaml": map[string]interface{}{"schema": map[string]interface{}{"type": "string"}}}
+	case route.list:
+		params = append(params,
+			map[string]interface{}{
+				"name": "limit", "in": "query", "description": "每页条数",
+				"schema": map[string]interface{}{"type": "integer", "minimum": 1, "maximum": maxPageLimit, "default": defaultPageLimit},
+			},
+			map[string]interface{}{
+				"name": "offset", "in": "query", "description": "跳过的条数",
+				"schema": map[string]interface{}{"type": "integer", "minimum": 0, "default": 0},
+			},
+		)
+		content = jsonContent(map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"items":  map[string]interface{}{"type": "array", "items": schemas.schema(reflect.TypeOf(route.response))},
+				"total":  map[string]interface{}{"type": "integer"},
+				"offset": map[string]interface{}{"type": "integer"},
+				"limit":  map[string]interface{}{"type": "integer"},
+				"errors": schemas.schema(reflect.TypeOf(monitor.FieldErrors{})),
+			},
+		})
+	default:
+		content = jsonContent(schemas.schema(reflect.TypeOf(route.response)))
+	}
+
+	responses := map[string]interface{}{
+		"200": map[string]interface{}{"description": "成功", "content": content},
+		"400": errorResponse("参数错误"),
+		"401": errorResponse("未认证"),
+	}
+	if route.role > roleViewer {
+		responses["403"] = errorResponse("权限不足，需要 " + route.role.String() + " 角色")
+	}
+	for _, status := range route.errors {
+		responses[strconv.Itoa(status)] = errorResponse(http.StatusText(status))
+	}
+
+	op := map[string]interface{}{
+		"summary":     route.summary,
+		"operationId": operationID(route),
+		"parameters":  params,
+		"responses":   responses,
+	}
+	if route.method == "PUT" {
+		op["requestBody"] = map[string]interface{}{
+			"required": true,
+			"content":  map[string]interface{}{"application/yaml": map[string]interface{}{"schema": map[string]interface{}{"type": "string"}}},
+		}
+	}
+	return op
+}
+
+// operationID 由方法和路径生成，如 GET /containers/{id} -> get_containers_id
+func operationID(route apiRoute) string {
+	id := strings.ToLower(route.method)
+	for _, part := range strings.Split(route.path, "/") {
+		part = strings.Trim(part, "{}")
+		if part != "" {
+			id += "_" + part
+		}
+	}
+	return id
+}
+
+func jsonContent(schema map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{"application/json": map[string]interface{}{"schema": schema}}
+}
+
+func errorResponse(description string) map[string]interface{} {
+	return map[string]interface{}{
+		"description": description,
+		"content":     jsonContent(map[string]interface{}{"$ref": "#/components/schemas/Error"}),
+	}
+}
@@ -0,0 +1,39 @@
+package web
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"syspulse/internal/config"
+)
+
+var updateGolden = flag.Bool("update", false, "重新生成 testdata 中的 golden 文件")
+
+// /api/v1 的字段名由 Go 字段名推导，重命名结构体字段会改变公开的接口。
+// 文档与 testdata/openapi.json 不一致时测试失败；确认是有意的变化后用 -update 更新。
+func TestOpenAPIGolden(t *testing.T) {
+	s := NewServer("127.0.0.1", 0, config.Default())
+	got, err := json.MarshalIndent(buildOpenAPI(s.apiRoutes()), "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got = append(got, '\n')
+
+	golden := filepath.Join("testdata", "openapi.json")
+	if *updateGolden {
+		if err := os.WriteFile(golden, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("OpenAPI document differs from %s; run go test ./internal/web -run TestOpenAPIGolden -update if the API change is intended", golden)
+	}
+}
@@ -0,0 +1,156 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"syspulse/internal/config"
+	"syspulse/internal/monitor"
+)
+
+// role 角色，数值越大权限越高，高权限角色拥有低权限角色的所有权限
+type role int
+
+const (
+	roleViewer role = iota
+	roleOperator
+	roleAdmin
+)
+
+// parseRole 解析配置中的角色名（配置加载时已校验，未知或为空时按 viewer 处理）
+func parseRole(name string) role {
+	switch name {
+	case config.RoleOperator:
+		return roleOperator
+	case config.RoleAdmin:
+		return roleAdmin
+	}
+	return roleViewer
+}
+
+func (r role) String() string {
+	switch r {
+	case roleOperator:
+		return config.RoleOperator
+	case roleAdmin:
+		return config.RoleAdmin
+	}
+	return config.RoleViewer
+}
+
+// principal 请求的身份
+type principal struct {
+	Name string
+	Role role
+}
+
+type principalKey struct{}
+
+// withPrincipal 把身份放入请求上下文
+func withPrincipal(r *http.Request, who principal) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), principalKey{}, who))
+}
+
+// principalFrom 取出请求的身份（没有时视为匿名 viewer）
+func principalFrom(r *http.Request) principal {
+	if who, ok := r.Context().Value(principalKey{}).(principal); ok {
+		return who
+	}
+	return principal{Name: "anonymous", Role: roleViewer}
+}
+
+// require 要求请求者至少拥有 min 角色，高于 viewer 的路由视为特权操作并记录审计日志
+func (s *Server) require(min role, handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		who := principalFrom(r)
+		if who.Role < min {
+			if min > roleViewer {
+				s.audit.log(r, who, http.StatusForbidden)
+			}
+			writeError(w, r, http.StatusForbidden, "forbidden", fmt.Sprintf("权限不足，需要 %s 角色", min))
+			return
+		}
+		if min == roleViewer {
+			handler(w, r)
+			return
+		}
+
+		rec := newResponseRecorder(w)
+		handler(rec, r)
+		s.audit.log(r, who, rec.status)
+	}
+}
+
+// auditEntry 审计日志的一条记录（JSON Lines 格式）
+type auditEntry struct {
+	Time   time.Time `json:"time"`
+	User   string    `json:"user"`
+	Role   string    `json:"role"`
+	Method string    `json:"method"`
+	Path   string    `json:"path"`
+	Remote string    `json:"remote"`
+	Status int       `json:"status"`
+}
+
+// auditLogger 把特权操作追加到审计日志文件（未配置文件时输出到标准错误）
+type auditLogger struct {
+	mu   sync.Mutex
+	path string
+}
+
+func (a *auditLogger) log(r *http.Request, who principal, status int) {
+	line, err := json.Marshal(auditEntry{
+		Time:   time.Now(),
+		User:   who.Name,
+		Role:   who.Role.String(),
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Remote: r.RemoteAddr,
+		Status: status,
+	})
+	if err != nil {
+		return
+	}
+	line = append(line, '\n')
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.path == "" {
+		os.Stderr.Write(line)
+		return
+	}
+	// 特权操作很少，每次打开文件追加，便于外部轮转日志
+	file, err := os.OpenFile(a.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "写入审计日志失败: %v\n", err)
+		os.Stderr.Write(line)
+		return
+	}
+	defer file.Close()
+	file.Write(line)
+}
+
+// redactProcessInfo 去掉 operator 以下角色不可见的进程命令行（可能包含密码等敏感参数）
+func redactProcessInfo(info monitor.ProcessInfo, who principal) monitor.ProcessInfo {
+	if who.Role >= roleOperator {
+		return info
+	}
+	info.TopCPU = redactProcesses(info.TopCPU)
+	info.TopMemory = redactProcesses(info.TopMemory)
+	return info
+}
+
+func redactProcesses(processes []monitor.ProcessDetail) []monitor.ProcessDetail {
+	redacted := make([]monitor.ProcessDetail, len(processes))
+	for i, p := range processes {
+		p.Command = ""
+		redacted[i] = p
+	}
+	return redacted
+}
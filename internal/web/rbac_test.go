@@ -0,0 +1,262 @@
+package web
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"syspulse/internal/config"
+	"syspulse/internal/monitor"
+
+	"github.com/gorilla/websocket"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// secretCommand 测试快照中带敏感参数的进程命令行
+const secretCommand = "postgres --password=hunter2"
+
+// newRBACTestServer 创建带 viewer、operator 两个用户（密码与用户名相同）的服务器，快照中有一个带敏感命令行的进程
+func newRBACTestServer(t *testing.T, auditLog string) (*Server, *httptest.Server) {
+	t.Helper()
+	cfg := config.Default()
+	cfg.Web.AuditLog = auditLog
+	for _, role := range []string{config.RoleViewer, config.RoleOperator} {
+		hash, err := bcrypt.GenerateFromPassword([]byte(role), bcrypt.MinCost)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cfg.Web.Auth.Users = append(cfg.Web.Auth.Users, config.WebUser{Username: role, PasswordHash: string(hash), Role: role})
+	}
+
+	process := monitor.ProcessInfo{
+		TotalProcesses: 1,
+		TopCPU:         []monitor.ProcessDetail{{PID: 42, Name: "postgres", Command: secretCommand}},
+		TopMemory:      []monitor.ProcessDetail{{PID: 42, Name: "postgres", Command: secretCommand}},
+	}
+	return newTestServer(t, cfg, map[string]interface{}{"process": process})
+}
+
+func TestRequireRole(t *testing.T) {
+	auditLog := filepath.Join(t.TempDir(), "audit.log")
+	_, ts := newRBACTestServer(t, auditLog)
+
+	tests := []struct {
+		user   string
+		method string
+		path   string
+		denied bool
+	}{
+		{user: "viewer", method: "POST", path: "/api/v1/containers/abc/restart", denied: true},
+		{user: "viewer", method: "POST", path: "/api/docker/abc/restart", denied: true},
+		{user: "viewer", method: "GET", path: "/api/v1/disks/usage", denied: true},
+		{user: "viewer", method: "GET", path: "/api/disk/usage", denied: true},
+		{user: "viewer", method: "GET", path: "/api/v1/config", denied: true},
+		{user: "viewer", method: "PUT", path: "/api/config", denied: true},
+		{user: "operator", method: "GET", path: "/api/v1/config", denied: true},
+		{user: "operator", method: "PUT", path: "/api/v1/config", denied: true},
+		{user: "operator", method: "GET", path: "/api/v1/disks/usage?path=" + t.TempDir(), denied: false},
+		{user: "viewer", method: "GET", path: "/api/v1/whoami", denied: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.user+" "+tt.method+" "+tt.path, func(t *testing.T) {
+			req, _ := http.NewRequest(tt.method, ts.URL+tt.path, strings.NewReader(""))
+			req.SetBasicAuth(tt.user, tt.user)
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+
+			if denied := resp.StatusCode == http.StatusForbidden; denied != tt.denied {
+				t.Fatalf("status = %d, want denied = %v", resp.StatusCode, tt.denied)
+			}
+			if tt.denied && strings.HasPrefix(tt.path, apiV1Prefix) {
+				var body apiErrorBody
+				if err := json.NewDecoder(resp.Body).Decode(&body); err != nil || body.Error.Code != "forbidden" {
+					t.Errorf("error body = %+v (%v)", body, err)
+				}
+			}
+		})
+	}
+
+	// 被拒绝的特权操作也写入审计日志
+	data, err := os.ReadFile(auditLog)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var denied int
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		var entry auditEntry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("audit line %q: %v", line, err)
+		}
+		if entry.Status == http.StatusForbidden {
+			denied++
+		}
+	}
+	if denied != 8 {
+		t.Errorf("audit log has %d denied entries, want 8:\n%s", denied, data)
+	}
+}
+
+func TestProcessCommandRedaction(t *testing.T) {
+	s, ts := newRBACTestServer(t, filepath.Join(t.TempDir(), "audit.log"))
+	s.events.append(s.sampler.Snapshot())
+
+	self := filepath.Base(os.Args[0])
+	tests := []struct {
+		name string
+		// commands 返回接口中所有进程的命令行
+		commands func(t *testing.T, user string) []string
+	}{
+		{name: "api process", commands: func(t *testing.T, user string) []string {
+			var info monitor.ProcessInfo
+			getJSON(t, ts.URL+"/api/process?top=1000", user, &info)
+			return processCommands(info)
+		}},
+		{name: "api v1 processes", commands: func(t *testing.T, user string) []string {
+			var list struct {
+				Items []map[string]interface{} `json:"items"`
+			}
+			getJSON(t, ts.URL+"/api/v1/processes?limit=1000&name="+self, user, &list)
+			var commands []string
+			for _, item := range list.Items {
+				commands = append(commands, item["command"].(string))
+			}
+			return commands
+		}},
+		{name: "websocket", commands: func(t *testing.T, user string) []string {
+			conn := dialWebSocket(t, ts, user, nil)
+			var data map[string]json.RawMessage
+			if err := conn.ReadJSON(&data); err != nil {
+				t.Fatal(err)
+			}
+			var info monitor.ProcessInfo
+			if err := json.Unmarshal(data["process"], &info); err != nil {
+				t.Fatal(err)
+			}
+			return processCommands(info)
+		}},
+		{name: "websocket subscription", commands: func(t *testing.T, user string) []string {
+			conn := dialWebSocket(t, ts, user, []string{wsSubprotocol})
+			if err := conn.WriteJSON(clientMessage{Type: "subscribe", Topics: []string{"process"}}); err != nil {
+				t.Fatal(err)
+			}
+			msg := readUntil(t, conn, "snapshot")
+			var info monitor.ProcessInfo
+			data, _ := json.Marshal(msg.Data["process"])
+			if err := json.Unmarshal(data, &info); err != nil {
+				t.Fatal(err)
+			}
+			return processCommands(info)
+		}},
+		{name: "sse", commands: func(t *testing.T, user string) []string {
+			req, _ := http.NewRequest("GET", ts.URL+"/api/stream?topics=process", nil)
+			req.SetBasicAuth(user, user)
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+
+			scanner := bufio.NewScanner(resp.Body)
+			for scanner.Scan() {
+				if data, ok := strings.CutPrefix(scanner.Text(), "data: "); ok {
+					var info monitor.ProcessInfo
+					if err := json.Unmarshal([]byte(data), &info); err != nil {
+						t.Fatal(err)
+					}
+					return processCommands(info)
+				}
+			}
+			t.Fatalf("stream ended without data: %v", scanner.Err())
+			return nil
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viewer := tt.commands(t, "viewer")
+			if len(viewer) == 0 {
+				t.Fatal("viewer got no processes")
+			}
+			for _, command := range viewer {
+				if command != "" {
+					t.Errorf("viewer sees command %q", command)
+				}
+			}
+
+			var visible bool
+			for _, command := range tt.commands(t, "operator") {
+				visible = visible || command != ""
+			}
+			if !visible {
+				t.Error("operator sees no command lines")
+			}
+		})
+	}
+}
+
+// getJSON 以 user 的身份请求 url 并解码 JSON 响应
+func getJSON(t *testing.T, url, user string, v interface{}) {
+	t.Helper()
+	req, _ := http.NewRequest("GET", url, nil)
+	req.SetBasicAuth(user, user)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("GET %s: status %d", url, resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// dialWebSocket 以 user 的身份（为空时匿名）连接 /ws，测试结束时关闭
+func dialWebSocket(t *testing.T, ts *httptest.Server, user string, subprotocols []string) *websocket.Conn {
+	t.Helper()
+	req, _ := http.NewRequest("GET", ts.URL, nil)
+	if user != "" {
+		req.SetBasicAuth(user, user)
+	}
+	dialer := websocket.Dialer{Subprotocols: subprotocols, HandshakeTimeout: 5 * time.Second}
+	conn, _, err := dialer.Dial(wsURL(ts, "/ws"), req.Header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	return conn
+}
+
+// readUntil 读取服务器消息，直到收到指定类型的消息
+func readUntil(t *testing.T, conn *websocket.Conn, typ string) serverMessage {
+	t.Helper()
+	for {
+		var msg serverMessage
+		if err := conn.ReadJSON(&msg); err != nil {
+			t.Fatalf("waiting for %s: %v", typ, err)
+		}
+		if msg.Type == typ {
+			return msg
+		}
+	}
+}
+
+func processCommands(info monitor.ProcessInfo) []string {
+	var commands []string
+	for _, p := range append(info.TopCPU, info.TopMemory...) {
+		commands = append(commands, p.Command)
+	}
+	return commands
+}
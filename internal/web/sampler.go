@@ -0,0 +1,225 @@
+package web
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"syspulse/internal/monitor"
+)
+
+// sampleInterval 后台采集间隔，与前端默认的 WebSocket 刷新间隔一致
+const sampleInterval = 2 * time.Second
+
+// staleAfter 采集器超过该时间（采集间隔更长的采集器为两个间隔）没有完成一次采集即视为不新鲜（/readyz 返回 503）
+const staleAfter = 30 * time.Second
+
+// collectorStatus 采集器最近一次执行的状态
+type collectorStatus struct {
+	lastStart  time.Time
+	lastUpdate time.Time
+	duration   time.Duration
+	running    bool
+	timedOut   bool
+}
+
+// TimedOut 采集超时时代替数据放入快照，前端据此显示"超时"
+type TimedOut struct {
+	TimedOut       bool
+	TimeoutSeconds float64
+}
+
+// CollectorHealth /readyz 中单个采集器的新鲜度
+type CollectorHealth struct {
+	Name       string
+	LastUpdate time.Time
+	AgeSeconds float64
+	DurationMs float64
+	Fresh      bool
+	// 最近一次采集超时
+	TimedOut bool
+}
+
+// sampler 在后台按间隔并发执行所有采集器，缓存最新数据
+// 所有 WebSocket 客户端、/api/all 和 /metrics 共享同一份数据，不再为每个连接单独采集
+type sampler struct {
+	interval   time.Duration
+	collectors []monitor.Collector
+	// timeout 返回采集器的超时（0 表示不限时），每轮采集时读取，修改配置后立即生效
+	timeout func(name string) time.Duration
+
+	mu      sync.RWMutex
+	data    map[string]interface{}
+	metrics map[string][]monitor.Metric
+	status  map[string]*collectorStatus
+
+	stop chan struct{}
+	once sync.Once
+}
+
+func newSampler(interval time.Duration, timeout func(name string) time.Duration, collectors []monitor.Collector) *sampler {
+	status := make(map[string]*collectorStatus, len(collectors))
+	for _, c := range collectors {
+		status[c.Name()] = &collectorStatus{}
+	}
+	return &sampler{
+		interval:   interval,
+		collectors: collectors,
+		timeout:    timeout,
+		data:       make(map[string]interface{}, len(collectors)),
+		metrics:    make(map[string][]monitor.Metric, len(collectors)),
+		status:     status,
+		stop:       make(chan struct{}),
+	}
+}
+
+// Start 立即采集一次，之后按间隔采集，直到调用 Stop
+func (s *sampler) Start() {
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		s.sample()
+		for {
+			select {
+			case <-ticker.C:
+				s.sample()
+			case <-s.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop 停止后台采集
+func (s *sampler) Stop() {
+	s.once.Do(func() { close(s.stop) })
+}
+
+// sample 并发执行所有到期的采集器（采集间隔长于 sampler 间隔的采集器，如插件，按自己的间隔执行），
+// 上一轮还没完成的采集器跳过，避免慢采集器（如 Docker）堆积
+func (s *sampler) sample() {
+	now := time.Now()
+	for _, c := range s.collectors {
+		s.mu.Lock()
+		status := s.status[c.Name()]
+		if status.running || now.Sub(status.lastStart) < c.Interval() {
+			s.mu.Unlock()
+			continue
+		}
+		status.running = true
+		status.lastStart = now
+		s.mu.Unlock()
+
+		go s.run(c, s.timeout(c.Name()))
+	}
+}
+
+// run 执行一次采集。超时后快照中放入 TimedOut 标记（采集函数响应取消及时返回的部分结果则照常放入），
+// 采集函数卡在无法中断的调用上时仍保持 running，直到它真正返回，其结果被丢弃
+func (s *sampler) run(c monitor.Collector, timeout time.Duration) {
+	ctx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	result := make(chan monitor.Sample, 1)
+	go func() {
+		result <- c.Collect(ctx)
+	}()
+
+	var sample monitor.Sample
+	pending := false
+	select {
+	case sample = <-result:
+	case <-ctx.Done():
+		sample.Info = TimedOut{TimedOut: true, TimeoutSeconds: timeout.Seconds()}
+		pending = true
+	}
+
+	s.mu.Lock()
+	s.data[c.Name()] = sample.Info
+	s.metrics[c.Name()] = sample.Metrics
+	status := s.status[c.Name()]
+	status.lastUpdate = time.Now()
+	status.duration = time.Since(start)
+	status.timedOut = ctx.Err() != nil
+	s.mu.Unlock()
+
+	if pending {
+		<-result
+	}
+	s.mu.Lock()
+	s.status[c.Name()].running = false
+	s.mu.Unlock()
+}
+
+// Names 返回所有采集器的名称
+func (s *sampler) Names() []string {
+	names := make([]string, 0, len(s.collectors))
+	for _, c := range s.collectors {
+		names = append(names, c.Name())
+	}
+	return names
+}
+
+// Has 是否存在该名称的采集器
+func (s *sampler) Has(name string) bool {
+	_, ok := s.status[name]
+	return ok
+}
+
+// Snapshot 返回最新数据的副本（还未采集到的项不包含在内）
+func (s *sampler) Snapshot() map[string]interface{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	data := make(map[string]interface{}, len(s.data))
+	for name, value := range s.data {
+		data[name] = value
+	}
+	return data
+}
+
+// Metrics 按采集器的顺序返回最新一次采集的指标（超时的采集器没有指标）
+func (s *sampler) Metrics() []monitor.Metric {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var metrics []monitor.Metric
+	for _, c := range s.collectors {
+		metrics = append(metrics, s.metrics[c.Name()]...)
+	}
+	return metrics
+}
+
+// Health 返回各采集器的新鲜度，所有采集器都新鲜时 ready 为 true
+func (s *sampler) Health() (health []CollectorHealth, ready bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := time.Now()
+	ready = true
+	for _, c := range s.collectors {
+		status := s.status[c.Name()]
+		h := CollectorHealth{
+			Name:       c.Name(),
+			LastUpdate: status.lastUpdate,
+			DurationMs: float64(status.duration) / float64(time.Millisecond),
+			TimedOut:   status.timedOut,
+		}
+		if !status.lastUpdate.IsZero() {
+			age := now.Sub(status.lastUpdate)
+			h.AgeSeconds = age.Seconds()
+			h.Fresh = age <= max(staleAfter, 2*c.Interval()) && !status.timedOut
+		}
+		if !h.Fresh {
+			ready = false
+		}
+		health = append(health, h)
+	}
+	return health, ready
+}
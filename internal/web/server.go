@@ -1,12 +1,23 @@
 package web
 
 import (
+	"context"
+	"crypto/tls"
 	"embed"
+	"errors"
 	"fmt"
 	"io/fs"
+	"net"
 	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"syspulse/internal/config"
+	"syspulse/internal/monitor"
+	"syspulse/internal/probe"
+
 	"github.com/gorilla/mux"
 	"github.com/gorilla/websocket"
 )
@@ -14,64 +25,260 @@ import (
 //go:embed static/*
 var staticFiles embed.FS
 
-var upgrader = websocket.Upgrader{
-	CheckOrigin: func(r *http.Request) bool {
-		return true
-	},
-}
-
 // Server Web 服务器
 type Server struct {
 	host   string
 	port   int
 	router *mux.Router
+	probes *probe.Runner
+	// collectors 后台采集的所有采集器（内置子系统、探测和插件）
+	collectors *monitor.Registry
+	plugins    []string
+	certs      certCache
+	// dirUsageScans 正在进行的目录扫描（信号量）
+	dirUsageScans chan struct{}
+	audit         *auditLogger
+	sampler       *sampler
+	events        *eventBuffer
+	upgrader      websocket.Upgrader
+
+	// 正在连接的 WebSocket 客户端，关闭服务器时逐个发送关闭帧并等待处理函数退出
+	wsMu     sync.Mutex
+	wsConns  map[*websocket.Conn]struct{}
+	wsWG     sync.WaitGroup
+	done     chan struct{}
+	draining atomic.Bool
+
+	// config 可由 admin 通过 API 替换，请求处理中使用 currentConfig() 读取
+	mu     sync.RWMutex
+	config *config.Config
 }
 
 // NewServer 创建新的 Web 服务器
-func NewServer(host string, port int) *Server {
+func NewServer(host string, port int, cfg *config.Config) *Server {
 	s := &Server{
-		host:   host,
-		port:   port,
-		router: mux.NewRouter(),
+		host:    host,
+		port:    port,
+		config:  cfg,
+		router:  mux.NewRouter(),
+		probes:  probe.NewRunner(cfg.ProbeTargets(), cfg.Probes.Interval),
+		audit:   &auditLogger{path: cfg.Web.AuditLog},
+		wsConns: make(map[*websocket.Conn]struct{}),
+		done:    make(chan struct{}),
+		events:  newEventBuffer(),
+
+		dirUsageScans: make(chan struct{}, maxDirUsageScans),
 	}
 
+	s.upgrader = websocket.Upgrader{
+		CheckOrigin:  s.checkWebSocketOrigin,
+		Subprotocols: []string{wsSubprotocol},
+	}
+	// 内置子系统、连通性探测、配置的插件和抓取目标都注册为采集器，由后台统一采集
+	s.collectors = monitor.NewRegistry(monitor.BuiltinCollectors(monitor.BuiltinOptions{
+		DiskFilter:    func() monitor.DiskFilter { return s.currentConfig().DiskFilter() },
+		NetworkFilter: func() monitor.NetworkFilter { return s.currentConfig().NetworkFilter() },
+		ProcessTop:    maxProcessTop,
+		NetTopCount:   defaultNetTopCount,
+		// 与 /api/certificates 共用缓存，不会额外扫描
+		Certificates: func(ctx context.Context) monitor.CertificatesInfo { return s.certificates(ctx, false, true) },
+	})...)
+	s.collectors.Register(monitor.NewCollector("probes", 0, func(ctx context.Context) probe.Info { return s.probes.Info() }, nil))
+	for _, plugin := range cfg.PluginCollectors() {
+		s.collectors.Register(plugin)
+		s.plugins = append(s.plugins, plugin.Name())
+	}
+	if scrape := cfg.ScrapeCollector(); scrape != nil {
+		s.collectors.Register(scrape)
+	}
+
+	timeout := func(name string) time.Duration { return s.currentConfig().CollectTimeout(name) }
+	s.sampler = newSampler(sampleInterval, timeout, s.collectors.Collectors())
+
 	s.setupRoutes()
 	return s
 }
 
 func (s *Server) setupRoutes() {
-	// API 路由
+	s.router.Use(s.corsMiddleware, s.authMiddleware)
+
+	// 版本化 API（见 apiv1.go），需在 /api 之前注册
+	s.setupAPIV1()
+
+	// 旧版 API 路由，已弃用，响应中带有指向 /api/v1 的 Deprecation 和 Link 头
+	// 未用 require 包装的路由是只读的，所有角色都可访问
 	api := s.router.PathPrefix("/api").Subrouter()
-	api.HandleFunc("/system", handleSystem).Methods("GET")
-	api.HandleFunc("/cpu", handleCPU).Methods("GET")
-	api.HandleFunc("/memory", handleMemory).Methods("GET")
-	api.HandleFunc("/disk", handleDisk).Methods("GET")
-	api.HandleFunc("/network", handleNetwork).Methods("GET")
-	api.HandleFunc("/port", handlePort).Methods("GET")
-	api.HandleFunc("/process", handleProcess).Methods("GET")
-	api.HandleFunc("/docker", handleDocker).Methods("GET")
-	api.HandleFunc("/docker/{id}", handleDockerDetail).Methods("GET")
-	api.HandleFunc("/all", handleAll).Methods("GET")
+	api.Use(deprecationMiddleware)
+	api.Methods("OPTIONS").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	api.HandleFunc("/system", s.handleSystem).Methods("GET")
+	api.HandleFunc("/cpu", s.handleCPU).Methods("GET")
+	api.HandleFunc("/memory", s.handleMemory).Methods("GET")
+	api.HandleFunc("/pressure", s.handlePressure).Methods("GET")
+	api.HandleFunc("/alerts", s.handleAlerts).Methods("GET")
+	api.HandleFunc("/sensors", s.handleSensors).Methods("GET")
+	api.HandleFunc("/disk", s.handleDisk).Methods("GET")
+	api.HandleFunc("/disk/usage", s.require(roleOperator, s.handleDiskUsage)).Methods("GET")
+	api.HandleFunc("/disk/health", s.handleDiskHealth).Methods("GET")
+	api.HandleFunc("/network", s.handleNetwork).Methods("GET")
+	api.HandleFunc("/network/protocols", s.handleProtocolStats).Methods("GET")
+	api.HandleFunc("/network/processes", s.handleProcessNetwork).Methods("GET")
+	api.HandleFunc("/port", s.handlePort).Methods("GET")
+	api.HandleFunc("/process", s.handleProcess).Methods("GET")
+	api.HandleFunc("/docker", s.handleDocker).Methods("GET")
+	api.HandleFunc("/docker/{id}", s.handleDockerDetail).Methods("GET")
+	api.HandleFunc("/docker/{id}/restart", s.require(roleOperator, handleDockerRestart)).Methods("POST")
+	api.HandleFunc("/services", s.handleServices).Methods("GET")
+	api.HandleFunc("/probes", s.handleProbes).Methods("GET")
+	api.HandleFunc("/certs", s.handleCerts).Methods("GET")
+	api.HandleFunc("/all", s.handleAll).Methods("GET")
+	api.HandleFunc("/stream", s.handleStream).Methods("GET")
+	api.HandleFunc("/whoami", handleWhoami).Methods("GET")
+	api.HandleFunc("/config", s.require(roleAdmin, s.handleGetConfig)).Methods("GET")
+	api.HandleFunc("/config", s.require(roleAdmin, s.handlePutConfig)).Methods("PUT")
 
 	// WebSocket 路由
-	s.router.HandleFunc("/ws", handleWebSocket)
+	s.router.HandleFunc("/ws", s.handleWebSocket)
+
+	// Prometheus 指标导出（内置子系统和插件的指标）
+	s.router.HandleFunc("/metrics", s.handleMetrics).Methods("GET")
+
+	// 健康检查（不需要认证）
+	s.router.HandleFunc("/healthz", handleHealthz).Methods("GET", "HEAD")
+	s.router.HandleFunc("/readyz", s.handleReadyz).Methods("GET", "HEAD")
 
 	// 静态文件服务
 	staticFS, _ := fs.Sub(staticFiles, "static")
 	s.router.PathPrefix("/").Handler(http.FileServer(http.FS(staticFS)))
 }
 
-// Start 启动服务器
-func (s *Server) Start() error {
-	addr := fmt.Sprintf("%s:%d", s.host, s.port)
+// shutdownTimeout 收到退出信号后等待进行中的请求完成的最长时间
+const shutdownTimeout = 10 * time.Second
+
+// Start 启动服务器，ctx 取消时（如收到 SIGINT / SIGTERM）优雅关闭
+func (s *Server) Start(ctx context.Context) error {
+	accessLog, err := openAccessLog(s.config.Web.AccessLog)
+	if err != nil {
+		return err
+	}
+	defer accessLog.Close()
+
+	// 后台按间隔执行连通性探测和数据采集
+	s.probes.Start()
+	defer s.probes.Stop()
+	s.sampler.Start()
+	defer s.sampler.Stop()
+	go s.recordEvents()
 
+	// 不设置 WriteTimeout：它会切断目录扫描等耗时请求，WebSocket 和 SSE 等长连接在每次写入时设置自己的超时
 	srv := &http.Server{
-		Addr:         addr,
-		Handler:      s.router,
-		ReadTimeout:  15 * time.Second,
-		WriteTimeout: 15 * time.Second,
-		IdleTimeout:  60 * time.Second,
+		Addr:              fmt.Sprintf("%s:%d", s.host, s.port),
+		Handler:           accessLog.middleware(s.router),
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       15 * time.Second,
+		IdleTimeout:       60 * time.Second,
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- s.serve(srv)
+	}()
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	fmt.Println("🛑 正在关闭 Web 服务器...")
+	return s.shutdown(srv)
+}
+
+// serve 按 TLS 配置监听
+func (s *Server) serve(srv *http.Server) error {
+	tlsConfig := s.config.Web.TLS
+	switch {
+	case tlsConfig.CertFile != "" && tlsConfig.KeyFile != "":
+		return srv.ListenAndServeTLS(tlsConfig.CertFile, tlsConfig.KeyFile)
+	case tlsConfig.SelfSigned:
+		cert, fingerprint, err := generateSelfSignedCert(s.host)
+		if err != nil {
+			return fmt.Errorf("生成自签名证书失败: %w", err)
+		}
+		fmt.Printf("🔐 使用自签名证书，SHA-256 指纹: %s\n", fingerprint)
+		srv.TLSConfig = &tls.Config{Certificates: []tls.Certificate{cert}}
+		return srv.ListenAndServeTLS("", "")
 	}
 
 	return srv.ListenAndServe()
 }
+
+// shutdown 停止接受新连接，等待进行中的 HTTP 请求完成，并通知 WebSocket 客户端断开
+func (s *Server) shutdown(srv *http.Server) error {
+	s.draining.Store(true)
+	close(s.done)
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	// WebSocket 连接已被接管，http.Server.Shutdown 不会处理它们
+	s.closeWebSockets()
+
+	err := srv.Shutdown(ctx)
+	if errors.Is(err, context.DeadlineExceeded) {
+		srv.Close()
+		return fmt.Errorf("等待请求完成超时（%s），已强制关闭", shutdownTimeout)
+	}
+
+	// Shutdown 返回后不会再有新的 WebSocket 处理函数，等待已有的退出
+	wsDone := make(chan struct{})
+	go func() {
+		s.wsWG.Wait()
+		close(wsDone)
+	}()
+	select {
+	case <-wsDone:
+	case <-ctx.Done():
+	}
+	return err
+}
+
+// trackWebSocket 登记 WebSocket 连接，返回的函数在连接结束时调用
+func (s *Server) trackWebSocket(conn *websocket.Conn) (untrack func()) {
+	s.wsMu.Lock()
+	s.wsConns[conn] = struct{}{}
+	s.wsMu.Unlock()
+
+	return func() {
+		s.wsMu.Lock()
+		delete(s.wsConns, conn)
+		s.wsMu.Unlock()
+	}
+}
+
+// closeWebSockets 向所有 WebSocket 客户端发送 going away 关闭帧，客户端会自动重连到新实例
+func (s *Server) closeWebSockets() {
+	s.wsMu.Lock()
+	defer s.wsMu.Unlock()
+
+	message := websocket.FormatCloseMessage(websocket.CloseGoingAway, "服务器关闭")
+	for conn := range s.wsConns {
+		conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(time.Second))
+		conn.Close()
+	}
+}
+
+// currentConfig 返回当前生效的配置
+func (s *Server) currentConfig() *config.Config {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.config
+}
+
+// URL 返回服务器的访问地址
+func (s *Server) URL() string {
+	scheme := "http"
+	if s.config.Web.TLS.Enabled() {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://%s", scheme, net.JoinHostPort(s.host, strconv.Itoa(s.port)))
+}
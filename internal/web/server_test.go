@@ -0,0 +1,123 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"syspulse/internal/config"
+
+	"github.com/gorilla/websocket"
+)
+
+// newTestServer 创建不启动后台采集的服务器，data 代替采集结果放入快照，测试结束时关闭
+func newTestServer(t *testing.T, cfg *config.Config, data map[string]interface{}) (*Server, *httptest.Server) {
+	t.Helper()
+	s := NewServer("127.0.0.1", 0, cfg)
+	s.sampler.mu.Lock()
+	for name, value := range data {
+		s.sampler.data[name] = value
+	}
+	s.sampler.mu.Unlock()
+
+	ts := httptest.NewServer(s.router)
+	t.Cleanup(ts.Close)
+	// 先通知 WebSocket 和 SSE 处理函数退出，再关闭 httptest 服务
+	t.Cleanup(func() { close(s.done) })
+	return s, ts
+}
+
+// wsURL 把 httptest 服务的地址转换为 WebSocket 地址
+func wsURL(ts *httptest.Server, path string) string {
+	return "ws" + strings.TrimPrefix(ts.URL, "http") + path
+}
+
+func TestCORSMiddleware(t *testing.T) {
+	tests := []struct {
+		name        string
+		origins     []string
+		origin      string
+		method      string
+		allowOrigin string
+		credentials bool
+		status      int
+	}{
+		{name: "listed origin", origins: []string{"https://ops.example.com"}, origin: "https://ops.example.com", method: "GET", allowOrigin: "https://ops.example.com", credentials: true, status: 200},
+		{name: "listed with trailing slash", origins: []string{"https://ops.example.com/"}, origin: "https://ops.example.com", method: "GET", allowOrigin: "https://ops.example.com", credentials: true, status: 200},
+		{name: "wildcard without credentials", origins: []string{"*"}, origin: "https://evil.example.com", method: "GET", allowOrigin: "*", status: 200},
+		{name: "listed beats wildcard", origins: []string{"*", "https://ops.example.com"}, origin: "https://ops.example.com", method: "GET", allowOrigin: "https://ops.example.com", credentials: true, status: 200},
+		{name: "unlisted origin", origins: []string{"https://ops.example.com"}, origin: "https://evil.example.com", method: "GET", status: 200},
+		{name: "no cors configured", origin: "https://ops.example.com", method: "GET", status: 200},
+		{name: "preflight", origins: []string{"https://ops.example.com"}, origin: "https://ops.example.com", method: "OPTIONS", allowOrigin: "https://ops.example.com", credentials: true, status: 204},
+		{name: "wildcard preflight", origins: []string{"*"}, origin: "https://evil.example.com", method: "OPTIONS", allowOrigin: "*", status: 204},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.Default()
+			cfg.Web.CORSOrigins = tt.origins
+			_, ts := newTestServer(t, cfg, nil)
+
+			req, _ := http.NewRequest(tt.method, ts.URL+"/api/v1/whoami", nil)
+			req.Header.Set("Origin", tt.origin)
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != tt.status {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.status)
+			}
+			if got := resp.Header.Get("Access-Control-Allow-Origin"); got != tt.allowOrigin {
+				t.Errorf("Access-Control-Allow-Origin = %q, want %q", got, tt.allowOrigin)
+			}
+			if got := resp.Header.Get("Access-Control-Allow-Credentials") == "true"; got != tt.credentials {
+				t.Errorf("credentials allowed = %v, want %v", got, tt.credentials)
+			}
+		})
+	}
+}
+
+func TestWebSocketOrigin(t *testing.T) {
+	cfg := config.Default()
+	cfg.Web.CORSOrigins = []string{"*", "https://ops.example.com"}
+	_, ts := newTestServer(t, cfg, nil)
+
+	tests := []struct {
+		name   string
+		origin string
+		ok     bool
+	}{
+		{name: "same origin", origin: ts.URL, ok: true},
+		{name: "no origin", origin: "", ok: true},
+		{name: "listed origin", origin: "https://ops.example.com", ok: true},
+		{name: "cross origin despite wildcard", origin: "https://evil.example.com", ok: false},
+		{name: "malformed origin", origin: "://", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.origin != "" {
+				header.Set("Origin", tt.origin)
+			}
+			conn, resp, err := websocket.DefaultDialer.Dial(wsURL(ts, "/ws"), header)
+			if tt.ok {
+				if err != nil {
+					t.Fatalf("upgrade rejected: %v", err)
+				}
+				conn.Close()
+				return
+			}
+			if err == nil {
+				conn.Close()
+				t.Fatal("cross-origin upgrade accepted")
+			}
+			if resp == nil || resp.StatusCode != http.StatusForbidden {
+				t.Errorf("response = %v, want 403", resp)
+			}
+		})
+	}
+}
@@ -6,16 +6,27 @@ let currentData = {}; // 存储当前数据，用于搜索过滤
 const MAX_RECONNECT_ATTEMPTS = 10;
 const RECONNECT_INTERVAL = 3000;
 
+// 订阅协议：订阅页面用到的主题，服务器首次推送完整数据，之后只推送变化的部分
+const WS_SUBPROTOCOL = 'syspulse.v1';
+const WS_TOPICS = ['system', 'cpu', 'memory', 'pressure', 'disk', 'network', 'ports', 'docker', 'process', 'probes'];
+// 页面不订阅的内置主题（磁盘健康通过 REST 接口加载），不能当作插件显示
+const UNSUBSCRIBED_TOPICS = ['sensors', 'protocols', 'cgroup', 'services', 'nettop', 'disk_health', 'certificates'];
+let topicState = {}; // 各主题的最新完整数据
+let pluginTopics = []; // 服务器配置的插件主题（welcome 中内置主题和 scrape 以外的主题）
+let scrapeEnabled = false; // 服务器是否配置了抓取目标
+
 // 连接 WebSocket
 function connectWebSocket() {
     const protocol = window.location.protocol === 'https:' ? 'wss:' : 'ws:';
     const wsUrl = `${protocol}//${window.location.host}/ws?interval=2`;
     
     try {
-        ws = new WebSocket(wsUrl);
+        ws = new WebSocket(wsUrl, WS_SUBPROTOCOL);
         
         ws.onopen = () => {
             console.log('WebSocket 连接成功');
+            topicState = {};
+            ws.send(JSON.stringify({ type: 'subscribe', topics: WS_TOPICS }));
             reconnectAttempts = 0; // 重置重连次数
             updateStatus(true);
             hideReconnectNotice();
@@ -23,8 +34,7 @@ function connectWebSocket() {
         };
         
         ws.onmessage = (event) => {
-            const data = JSON.parse(event.data);
-            updateUI(data);
+            handleServerMessage(JSON.parse(event.data));
         };
         
         ws.onerror = (error) => {
@@ -44,6 +54,52 @@ function connectWebSocket() {
     }
 }
 
+// 处理订阅协议的服务器消息
+function handleServerMessage(msg) {
+    switch (msg.type) {
+        case 'welcome': {
+            const extra = (msg.topics || []).filter(t => !WS_TOPICS.includes(t) && !UNSUBSCRIBED_TOPICS.includes(t));
+            scrapeEnabled = extra.includes('scrape');
+            pluginTopics = extra.filter(t => t !== 'scrape');
+            if (extra.length > 0) {
+                ws.send(JSON.stringify({ type: 'subscribe', topics: extra }));
+            }
+            updatePluginList(topicState);
+            updateScrapeList(topicState.scrape);
+            break;
+        }
+        case 'snapshot':
+            Object.assign(topicState, msg.data);
+            updateUI(topicState);
+            break;
+        case 'delta':
+            for (const [topic, patch] of Object.entries(msg.data)) {
+                topicState[topic] = applyMergePatch(topicState[topic], patch);
+            }
+            updateUI(topicState);
+            break;
+        case 'error':
+            console.warn(`WebSocket 错误 (${msg.code}): ${msg.message}`);
+            break;
+    }
+}
+
+// 应用 JSON Merge Patch (RFC 7386)：对象按字段合并，null 表示删除，其他值整体替换
+function applyMergePatch(target, patch) {
+    if (patch === null || typeof patch !== 'object' || Array.isArray(patch)) {
+        return patch;
+    }
+    const result = (target && typeof target === 'object' && !Array.isArray(target)) ? { ...target } : {};
+    for (const [key, value] of Object.entries(patch)) {
+        if (value === null) {
+            delete result[key];
+        } else {
+            result[key] = applyMergePatch(result[key], value);
+        }
+    }
+    return result;
+}
+
 // 尝试重连
 function attemptReconnect() {
     if (reconnectTimer) {
@@ -97,8 +153,45 @@ function updateStatus(connected) {
     }
 }
 
+// 采集超时的主题显示"超时"的位置
+const TIMED_OUT_TARGETS = {
+    system: 'hostname',
+    cpu: 'cpu-percent',
+    memory: 'mem-usage',
+    pressure: 'cpu-pressure',
+    disk: 'disk-list',
+    network: 'network-list',
+    ports: 'port-list',
+    docker: 'docker-status',
+    process: 'process-tbody',
+    probes: 'probe-list',
+    scrape: 'scrape-list'
+};
+
+// 标记采集超时的主题（服务器用 {TimedOut: true} 代替数据），返回有数据的主题
+function markTimedOut(data) {
+    const fresh = {};
+    for (const [topic, value] of Object.entries(data)) {
+        if (!value || !value.TimedOut) {
+            fresh[topic] = value;
+            continue;
+        }
+        const el = document.getElementById(TIMED_OUT_TARGETS[topic]);
+        if (!el) continue;
+        const text = `⏱ 超时（超过 ${value.TimeoutSeconds} 秒未完成）`;
+        if (el.tagName === 'TBODY') {
+            el.innerHTML = `<tr><td colspan="5" class="timed-out">${text}</td></tr>`;
+        } else {
+            el.innerHTML = `<span class="timed-out">${text}</span>`;
+        }
+    }
+    return fresh;
+}
+
 // 更新界面
 function updateUI(data) {
+    updatePluginList(data);
+    data = markTimedOut(data);
     currentData = data; // 保存当前数据
     document.getElementById('last-update').textContent = `最后更新: ${new Date().toLocaleTimeString()}`;
     
@@ -118,6 +211,17 @@ function updateUI(data) {
         document.getElementById('cpu-cores').textContent = data.cpu.CoreCount;
         document.getElementById('cpu-load').textContent = 
             `${data.cpu.LoadAvg1.toFixed(2)} / ${data.cpu.LoadAvg5.toFixed(2)} / ${data.cpu.LoadAvg15.toFixed(2)}`;
+        if (data.cpu.Modes) {
+            const m = data.cpu.Modes;
+            document.getElementById('cpu-user-system').textContent = `${m.User.toFixed(1)}% / ${m.System.toFixed(1)}%`;
+            document.getElementById('cpu-iowait-steal').textContent = `${m.IOWait.toFixed(1)}% / ${m.Steal.toFixed(1)}%`;
+            document.getElementById('cpu-irq').textContent = `${m.IRQ.toFixed(1)}% / ${m.SoftIRQ.toFixed(1)}%`;
+        }
+        document.getElementById('cpu-ctxt').textContent =
+            `${Math.round(data.cpu.ContextSwitchesPerSec).toLocaleString()}/s / ${Math.round(data.cpu.InterruptsPerSec).toLocaleString()}/s`;
+        document.getElementById('cpu-freq').textContent = formatFrequency(data.cpu.Frequencies);
+        document.getElementById('cpu-temp').textContent =
+            data.cpu.Temperature > 0 ? `${data.cpu.Temperature.toFixed(1)}°C` : '不可用';
     }
     
     // 内存
@@ -135,6 +239,13 @@ function updateUI(data) {
         updateProgressBar('swap-bar', swapPercent);
     }
     
+    // 资源压力 (PSI)
+    if (data.pressure) {
+        document.getElementById('cpu-pressure').textContent = formatPressure(data.pressure.CPU);
+        document.getElementById('mem-pressure').textContent = formatPressure(data.pressure.Memory);
+        document.getElementById('io-pressure').textContent = formatPressure(data.pressure.IO);
+    }
+    
     // 磁盘
     if (data.disk && data.disk.Partitions) {
         updateDiskList(data.disk.Partitions);
@@ -159,6 +270,129 @@ function updateUI(data) {
     if (data.process && data.process.TopCPU) {
         updateProcessTable(data.process.TopCPU);
     }
+    
+    // 应用指标
+    if (data.scrape) {
+        updateScrapeList(data.scrape);
+    }
+    
+    // 连通性探测
+    if (data.probes) {
+        updateProbeList(data.probes);
+    }
+}
+
+// 更新插件指标列表，超时的插件显示超时提示
+function updatePluginList(data) {
+    const container = document.getElementById('plugin-list');
+    
+    if (pluginTopics.length === 0) {
+        container.innerHTML = '<div style="text-align: center; color: var(--text-muted); padding: 20px;">未配置插件</div>';
+        return;
+    }
+    
+    const rows = pluginTopics.map(topic => {
+        const info = data[topic];
+        const name = `<td><strong>${escapeHTML(topic)}</strong></td>`;
+        if (!info) {
+            return `<tr>${name}<td colspan="2" style="color: var(--text-muted);">尚未完成首次执行</td></tr>`;
+        }
+        if (info.TimedOut) {
+            return `<tr>${name}<td colspan="2" class="timed-out">⏱ 超时（超过 ${info.TimeoutSeconds} 秒未完成）</td></tr>`;
+        }
+        if (info.Error) {
+            return `<tr>${name}<td colspan="2" style="color: var(--danger);">${escapeHTML(info.Error)}</td></tr>`;
+        }
+        const metrics = info.Metrics || [];
+        if (metrics.length === 0) {
+            return `<tr>${name}<td colspan="2" style="color: var(--text-muted);">插件没有输出指标</td></tr>`;
+        }
+        return metrics.map((m, i) => `
+            <tr>
+                ${i === 0 ? `<td rowspan="${metrics.length}"><strong>${escapeHTML(topic)}</strong></td>` : ''}
+                <td class="breakable"><code>${escapeHTML(formatSeries(m))}</code></td>
+                <td class="nowrap">${formatMetricValue(m.Value)}</td>
+            </tr>
+        `).join('');
+    }).join('');
+    
+    container.innerHTML = `
+        <table>
+            <thead><tr><th>插件</th><th>指标</th><th>值</th></tr></thead>
+            <tbody>${rows}</tbody>
+        </table>
+    `;
+}
+
+// 更新应用指标列表：每个抓取目标的状态和固定序列的当前值
+function updateScrapeList(info) {
+    const container = document.getElementById('scrape-list');
+    
+    if (!scrapeEnabled) {
+        container.innerHTML = '<div style="text-align: center; color: var(--text-muted); padding: 20px;">未配置抓取目标</div>';
+        return;
+    }
+    if (!info) {
+        container.innerHTML = '<div style="text-align: center; color: var(--text-muted); padding: 20px;">首次抓取尚未完成</div>';
+        return;
+    }
+    
+    const rows = info.Targets.map(t => {
+        const status = t.Up
+            ? '<span class="status-badge status-listen">正常</span>'
+            : '<span class="status-badge status-failed">失败</span>';
+        const pins = t.Up ? (t.Pins || []) : [];
+        const span = Math.max(pins.length, 1);
+        const target = `
+            <td rowspan="${span}"><strong>${escapeHTML(t.Name)}</strong><br><code class="breakable">${escapeHTML(t.URL)}</code></td>
+            <td rowspan="${span}">${status}</td>
+        `;
+        if (!t.Up) {
+            return `<tr>${target}<td colspan="2" style="color: var(--danger);">${escapeHTML(t.Error)}</td></tr>`;
+        }
+        if (pins.length === 0) {
+            return `<tr>${target}<td colspan="2" style="color: var(--text-muted);">${t.SampleCount} 个序列，未设置固定序列</td></tr>`;
+        }
+        return pins.map((p, i) => `
+            <tr>
+                ${i === 0 ? target : ''}
+                <td class="breakable" title="${escapeHTML(p.Selector)}">${escapeHTML(p.Title)}</td>
+                <td class="nowrap">${formatPinnedValue(p)}</td>
+            </tr>
+        `).join('');
+    }).join('');
+    
+    container.innerHTML = `
+        <table>
+            <thead><tr><th>目标</th><th>状态</th><th>序列</th><th>值</th></tr></thead>
+            <tbody>${rows}</tbody>
+        </table>
+    `;
+}
+
+// 固定序列的值：速率带 /s，分位数和平均值标明取值方式，无法计算时显示原因
+function formatPinnedValue(p) {
+    if (p.Error) {
+        return `<span style="color: var(--text-muted);">${escapeHTML(p.Error)}</span>`;
+    }
+    const value = formatMetricValue(p.Value);
+    switch (p.Kind) {
+        case 'rate': return `${value}/s`;
+        case 'average': return `${value} <span style="color: var(--text-muted);">(平均)</span>`;
+        case 'quantile': return `${value} <span style="color: var(--text-muted);">(p${+(p.Quantile * 100).toFixed(1)})</span>`;
+    }
+    return value;
+}
+
+// 指标的序列名，如 http_requests_total{code="200"}
+function formatSeries(m) {
+    const labels = Object.keys(m.Labels || {}).sort().map(k => `${k}="${m.Labels[k]}"`);
+    return labels.length > 0 ? `${m.Name}{${labels.join(',')}}` : m.Name;
+}
+
+// 指标值：整数原样显示，其余保留 6 位有效数字
+function formatMetricValue(v) {
+    return Number.isInteger(v) ? v.toLocaleString() : Number(v.toPrecision(6)).toString();
 }
 
 // 更新进度条
@@ -193,6 +427,8 @@ function updateDiskList(partitions) {
                 <th>已用</th>
                 <th>可用</th>
                 <th>已用% ▼</th>
+                <th>inode%</th>
+                <th>预计写满</th>
                 <th>挂载点</th>
             </tr>
         </thead>
@@ -203,6 +439,10 @@ function updateDiskList(partitions) {
                 const used = formatBytes(partition.Used);
                 const total = formatBytes(partition.Total);
                 const free = formatBytes(partition.Free);
+                const inode = partition.InodesTotal > 0 ? partition.InodesUsedPercent.toFixed(0) + '%' : '-';
+                const inodeClass = getPercentClass(partition.InodesUsedPercent);
+                const flags = [partition.ReadOnly ? 'ro' : '', partition.NoExec ? 'noexec' : ''].filter(f => f).join(',');
+                const remount = partition.ReadOnlyRemount ? ' <span class="percent-badge danger">只读重挂载</span>' : '';
                 
                 return `
                     <tr>
@@ -216,7 +456,9 @@ function updateDiskList(partitions) {
                                 <div class="progress-fill ${percentClass}" style="width: ${percent}%"></div>
                             </div>
                         </td>
-                        <td class="breakable"><strong>${partition.Mountpoint}</strong></td>
+                        <td class="nowrap"><span class="percent-badge ${inodeClass}">${inode}</span></td>
+                        <td class="nowrap">${formatETA(partition.FillETASeconds)}</td>
+                        <td class="breakable"><strong>${partition.Mountpoint}</strong>${flags ? ` (${flags})` : ''}${remount}</td>
                     </tr>
                 `;
             }).join('')}
@@ -226,6 +468,142 @@ function updateDiskList(partitions) {
     container.appendChild(table);
 }
 
+// 磁盘健康 (SMART)
+const DISK_HEALTH_INTERVAL = 5 * 60 * 1000;
+
+async function loadDriveHealth() {
+    const container = document.getElementById('disk-health');
+    try {
+        const resp = await fetch('/api/disk/health');
+        if (!resp.ok) {
+            throw new Error(await resp.text());
+        }
+        renderDriveHealth(await resp.json());
+    } catch (error) {
+        container.innerHTML = `<div style="text-align: center; color: var(--danger); padding: 20px;">获取失败: ${escapeHTML(error.message)}</div>`;
+    }
+}
+
+function renderDriveHealth(info) {
+    const container = document.getElementById('disk-health');
+    if (!info.Available) {
+        container.innerHTML = `<div style="text-align: center; color: var(--text-muted); padding: 20px;">${escapeHTML(info.Error)}</div>`;
+        return;
+    }
+    
+    const rows = (info.Drives || []).map(d => {
+        if (!d.Supported) {
+            return `
+                <tr>
+                    <td><strong>${escapeHTML(d.Device)}</strong></td>
+                    <td colspan="7" style="color: var(--text-muted);">${escapeHTML(d.Error || '不支持 SMART')}</td>
+                </tr>
+            `;
+        }
+        const issues = (d.Issues || []).length > 0
+            ? `<div style="color: var(--danger); font-size: 0.9em;">⚠️ ${d.Issues.map(escapeHTML).join('，')}</div>`
+            : '';
+        const wear = d.WearKnown
+            ? `<span class="percent-badge ${getPercentClass(d.PercentUsed)}">${d.PercentUsed.toFixed(0)}%</span>`
+            : '-';
+        return `
+            <tr>
+                <td><strong>${escapeHTML(d.Device)}</strong><div style="color: var(--text-muted); font-size: 0.9em;">${escapeHTML(d.Model)}</div>${issues}</td>
+                <td><span class="status-badge status-${d.Passed ? 'listen' : 'failed'}">${d.Passed ? 'PASSED' : 'FAILED'}</span></td>
+                <td class="nowrap">${d.Temperature > 0 ? d.Temperature.toFixed(0) + '°C' : '-'}</td>
+                <td class="nowrap">${wear}</td>
+                <td>${d.ReallocatedSectors}</td>
+                <td>${d.PendingSectors}</td>
+                <td>${d.MediaErrors}</td>
+                <td class="nowrap">${d.PowerOnHours.toLocaleString()}h</td>
+            </tr>
+        `;
+    }).join('');
+    
+    container.innerHTML = `
+        <table>
+            <thead><tr><th>设备</th><th>状态</th><th>温度</th><th>寿命已用</th><th>重映射</th><th>待映射</th><th>介质错误</th><th>通电时间</th></tr></thead>
+            <tbody>${rows || '<tr><td colspan="8" style="text-align: center; color: var(--text-muted);">未检测到物理磁盘</td></tr>'}</tbody>
+        </table>
+    `;
+}
+
+// 目录占用分析（下钻视图）
+async function loadDiskUsage(path) {
+    const input = document.getElementById('du-path');
+    if (path) {
+        input.value = path;
+    }
+    const target = input.value || '/';
+    const container = document.getElementById('du-result');
+    if (!canOperate()) {
+        container.innerHTML = '<div style="text-align: center; color: var(--text-muted); padding: 20px;">目录占用分析需要 operator 角色</div>';
+        return;
+    }
+    container.innerHTML = '<div style="text-align: center; color: var(--text-muted); padding: 20px;">正在扫描...</div>';
+    
+    try {
+        const resp = await fetch(`/api/disk/usage?path=${encodeURIComponent(target)}&depth=1&top=20`);
+        if (!resp.ok) {
+            throw new Error(await resp.text());
+        }
+        renderDiskUsage(await resp.json());
+    } catch (error) {
+        container.innerHTML = `<div style="text-align: center; color: var(--danger); padding: 20px;">扫描失败: ${escapeHTML(error.message)}</div>`;
+    }
+}
+
+function renderDiskUsage(usage) {
+    const container = document.getElementById('du-result');
+    const total = usage.TotalBytes || 1;
+    const parent = usage.Root === '/' ? null : usage.Root.replace(/\/[^\/]+$/, '') || '/';
+    
+    let notice = '';
+    if (usage.TimedOut) {
+        notice += '<div style="color: var(--warning);">⚠️ 扫描超时，以下为部分结果</div>';
+    }
+    if (usage.ErrorCount > 0) {
+        notice += `<div style="color: var(--warning);">⚠️ ${usage.ErrorCount} 个目录或文件无法读取</div>`;
+    }
+    
+    const rows = (usage.Tree.Children || []).map(dir => {
+        const percent = (dir.Bytes / total * 100).toFixed(1);
+        return `
+            <tr>
+                <td class="nowrap">${formatBytes(dir.Bytes)}</td>
+                <td class="nowrap">
+                    <span class="percent-badge ${getPercentClass(percent)}">${percent}%</span>
+                    <div class="progress-bar-mini">
+                        <div class="progress-fill ${getPercentClass(percent)}" style="width: ${percent}%"></div>
+                    </div>
+                </td>
+                <td class="breakable"><a href="#" onclick="loadDiskUsage(${escapeHTML(JSON.stringify(dir.Path))}); return false;">📁 ${escapeHTML(dir.Name)}</a></td>
+            </tr>
+        `;
+    }).join('');
+    
+    const files = (usage.LargestFiles || []).map(f => `
+        <tr>
+            <td class="nowrap">${formatBytes(f.Bytes)}</td>
+            <td class="breakable"><code>${escapeHTML(f.Path)}</code></td>
+        </tr>
+    `).join('');
+    
+    container.innerHTML = `
+        <div style="margin: 10px 0;">
+            <strong>${escapeHTML(usage.Root)}</strong> 共 ${formatBytes(usage.TotalBytes)}
+            (${usage.FileCount.toLocaleString()} 个文件, 耗时 ${usage.Elapsed.toFixed(1)}s)
+            ${parent ? `<a href="#" style="margin-left: 15px;" onclick="loadDiskUsage(${escapeHTML(JSON.stringify(parent))}); return false;">⬆️ 上一级</a>` : ''}
+        </div>
+        ${notice}
+        <table>
+            <thead><tr><th>大小 ▼</th><th>占比</th><th>目录</th></tr></thead>
+            <tbody>${rows || '<tr><td colspan="3" style="text-align: center; color: var(--text-muted);">无子目录</td></tr>'}</tbody>
+        </table>
+        ${files ? `<table style="margin-top: 15px;"><thead><tr><th>大小 ▼</th><th>最大的文件</th></tr></thead><tbody>${files}</tbody></table>` : ''}
+    `;
+}
+
 // 更新网络列表
 function updateNetworkList(interfaces) {
     const container = document.getElementById('network-list');
@@ -241,7 +619,11 @@ function updateNetworkList(interfaces) {
                         <strong style="color: var(--primary); font-size: 1.1em;">${iface.Name}</strong>
                         <span style="margin-left: 15px; color: var(--text);">${iface.Addrs[0]}</span>
                     </div>
+                    <div style="color: var(--text-muted); font-size: 0.9em;">
+                        ${iface.Kind} · ${iface.OperState || '-'} · MTU ${iface.MTU}${iface.SpeedMbps > 0 ? ` · ${formatLinkSpeed(iface.SpeedMbps)}` : ''}${iface.Duplex && iface.Duplex !== 'unknown' ? ` ${iface.Duplex}` : ''}
+                    </div>
                 </div>
+                ${iface.MAC ? `<div style="color: var(--text-muted); font-size: 0.85em; margin-bottom: 8px;">MAC ${iface.MAC}</div>` : ''}
                 <div class="network-stats">
                     <span><strong>📤 发送:</strong> ${formatBytes(iface.BytesSent)}</span>
                     <span><strong>📥 接收:</strong> ${formatBytes(iface.BytesRecv)}</span>
@@ -254,6 +636,14 @@ function updateNetworkList(interfaces) {
     });
 }
 
+// 格式化链路速率（Mbps）
+function formatLinkSpeed(mbps) {
+    if (mbps >= 1000 && mbps % 1000 === 0) {
+        return `${mbps / 1000} Gbps`;
+    }
+    return `${mbps} Mbps`;
+}
+
 // 更新端口列表
 function updatePortList(ports) {
     const container = document.getElementById('port-list');
@@ -314,6 +704,90 @@ function updatePortList(ports) {
 }
 
 // 更新 Docker 列表
+// 更新连通性探测结果
+function updateProbeList(info) {
+    const container = document.getElementById('probe-list');
+    const results = info.Results || [];
+    
+    if (results.length === 0) {
+        container.innerHTML = '<div style="text-align: center; color: var(--text-muted); padding: 20px;">未配置探测目标，或首次探测尚未完成</div>';
+        return;
+    }
+    
+    document.getElementById('probe-hint').textContent =
+        `每 ${info.Interval} 秒探测一次，${info.TotalCount} 个目标中 ${info.FailedCount} 个失败`;
+    
+    const rows = results.map(r => {
+        let status = '<span class="status-badge status-listen">正常</span>';
+        if (!r.Success) {
+            status = '<span class="status-badge status-failed">失败</span>';
+        } else if (r.Slow) {
+            status = '<span class="status-badge status-time_wait">慢</span>';
+        }
+        
+        const details = [];
+        if (r.StatusCode) details.push(`HTTP ${r.StatusCode}`);
+        if (r.Addresses) details.push(r.Addresses.map(escapeHTML).join(', '));
+        if (r.Type === 'icmp' && r.Success) details.push(`丢包 ${r.PacketLoss.toFixed(0)}%`);
+        if (r.CertNotAfter && !r.CertNotAfter.startsWith('0001')) {
+            const days = r.CertDaysLeft.toFixed(0);
+            const color = r.CertDaysLeft < 7 ? 'var(--danger)' : r.CertDaysLeft < 14 ? 'var(--warning)' : 'inherit';
+            details.push(`<span style="color: ${color};">证书剩余 ${days} 天</span>`);
+        }
+        if (r.Error) details.push(`<span style="color: var(--danger);">${escapeHTML(r.Error)}</span>`);
+        
+        return `
+            <tr>
+                <td><strong>${escapeHTML(r.Name)}</strong></td>
+                <td>${r.Type}</td>
+                <td class="breakable"><code>${escapeHTML(r.Target)}</code></td>
+                <td>${status}</td>
+                <td class="nowrap">${r.LatencyMs.toFixed(2)} ms</td>
+                <td class="breakable">${details.join(' · ') || '-'}</td>
+            </tr>
+        `;
+    }).join('');
+    
+    container.innerHTML = `
+        <table>
+            <thead><tr><th>名称</th><th>类型</th><th>目标</th><th>状态</th><th>延迟</th><th>详情</th></tr></thead>
+            <tbody>${rows}</tbody>
+        </table>
+    `;
+}
+
+// 当前用户的角色（viewer / operator / admin），决定是否显示容器操作按钮
+let currentRole = 'viewer';
+
+async function loadWhoami() {
+    try {
+        const resp = await fetch('/api/whoami');
+        if (resp.ok) {
+            currentRole = (await resp.json()).role;
+        }
+    } catch (error) {
+        console.error('获取用户角色失败:', error);
+    }
+}
+
+function canOperate() {
+    return currentRole === 'operator' || currentRole === 'admin';
+}
+
+async function restartContainer(id, name) {
+    if (!confirm(`确定重启容器 ${name} 吗？`)) {
+        return;
+    }
+    try {
+        const resp = await fetch(`/api/docker/${encodeURIComponent(id)}/restart`, { method: 'POST' });
+        if (!resp.ok) {
+            throw new Error(await resp.text());
+        }
+    } catch (error) {
+        alert(`重启失败: ${error.message}`);
+    }
+}
+
 function updateDockerList(docker) {
     const statusEl = document.getElementById('docker-status');
     const container = document.getElementById('docker-list');
@@ -342,6 +816,7 @@ function updateDockerList(docker) {
                 <th>状态</th>
                 <th>CPU</th>
                 <th>内存</th>
+                ${canOperate() ? '<th>操作</th>' : ''}
             </tr>
         </thead>
         <tbody>
@@ -371,6 +846,7 @@ function updateDockerList(docker) {
                         <td class="nowrap"><span class="${statusClass}">${c.Status}</span></td>
                         <td>${cpu}</td>
                         <td>${mem}</td>
+                        ${canOperate() ? `<td class="nowrap"><button class="control-btn" onclick="restartContainer('${c.ID}', '${escapeHTML(c.Name)}')">🔄 重启</button></td>` : ''}
                     </tr>
                 `;
             }).join('')}
@@ -437,12 +913,42 @@ function formatUptime(seconds) {
     }
 }
 
+function formatFrequency(freqs) {
+    if (!freqs || freqs.length === 0) return '-';
+    const current = freqs.map(f => f.CurrentMHz).filter(mhz => mhz > 0);
+    if (current.length === 0) return '-';
+    const avg = current.reduce((a, b) => a + b, 0) / current.length;
+    const max = Math.max(...freqs.map(f => f.MaxMHz));
+    return max > 0 ? `${(avg / 1000).toFixed(2)} / ${(max / 1000).toFixed(2)} GHz` : `${(avg / 1000).toFixed(2)} GHz`;
+}
+
+function formatPressure(res) {
+    if (!res || !res.Available) return '不支持';
+    return `${res.Some.Avg10.toFixed(2)}% / ${res.Full.Avg10.toFixed(2)}%`;
+}
+
+function formatETA(seconds) {
+    if (!seconds || seconds <= 0) return '-';
+    if (seconds < 3600) return `${Math.floor(seconds / 60)} 分钟`;
+    if (seconds < 172800) return `${(seconds / 3600).toFixed(1)} 小时`;
+    return `${Math.round(seconds / 86400)} 天`;
+}
+
 function getPercentClass(percent) {
     if (percent > 80) return 'danger';
     if (percent > 50) return 'warning';
     return '';
 }
 
+function escapeHTML(str) {
+    return String(str)
+        .replace(/&/g, '&amp;')
+        .replace(/</g, '&lt;')
+        .replace(/>/g, '&gt;')
+        .replace(/"/g, '&quot;')
+        .replace(/'/g, '&#39;');
+}
+
 function truncate(str, len) {
     if (str.length <= len) return str;
     return str.substring(0, len - 3) + '...';
@@ -521,7 +1027,10 @@ function restoreTheme() {
 
 // 页面加载时连接
 window.addEventListener('load', () => {
+    loadWhoami();
     connectWebSocket();
+    loadDriveHealth();
+    setInterval(loadDriveHealth, DISK_HEALTH_INTERVAL);
     restoreCardStates();
     restoreTheme();
 });
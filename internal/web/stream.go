@@ -0,0 +1,200 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// streamBufferSize 保留的最近快照数，断线重连时可以从中补发（按 2 秒间隔约 2 分钟）
+	streamBufferSize = 60
+	// streamHeartbeat SSE 注释心跳的间隔，防止代理因空闲断开连接
+	streamHeartbeat = 15 * time.Second
+	// streamRetry 建议客户端的重连等待时间（毫秒）
+	streamRetry = 3000
+)
+
+// streamEvent 一次采集快照，id 为采集时间的 Unix 毫秒数（严格递增，重启后也不会变小）
+type streamEvent struct {
+	id   int64
+	data map[string]interface{}
+}
+
+// eventBuffer 最近快照的环形缓冲区
+type eventBuffer struct {
+	mu     sync.Mutex
+	events []streamEvent
+	notify chan struct{} // 追加新快照时关闭并替换，用于唤醒等待的客户端
+}
+
+func newEventBuffer() *eventBuffer {
+	return &eventBuffer{notify: make(chan struct{})}
+}
+
+// append 追加一个快照，超出容量时丢弃最旧的
+func (b *eventBuffer) append(data map[string]interface{}) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	id := time.Now().UnixMilli()
+	if n := len(b.events); n > 0 && id <= b.events[n-1].id {
+		id = b.events[n-1].id + 1
+	}
+	b.events = append(b.events, streamEvent{id: id, data: data})
+	if len(b.events) > streamBufferSize {
+		b.events = b.events[len(b.events)-streamBufferSize:]
+	}
+
+	close(b.notify)
+	b.notify = make(chan struct{})
+}
+
+// since 返回 id 之后的快照（lastID 为 0 时只返回最新的一个），以及下一个快照到达时关闭的通道
+func (b *eventBuffer) since(lastID int64) ([]streamEvent, <-chan struct{}) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if lastID == 0 {
+		if n := len(b.events); n > 0 {
+			return b.events[n-1:], b.notify
+		}
+		return nil, b.notify
+	}
+
+	// 缓冲区中的快照按 id 递增，找到第一个比 lastID 新的
+	start := len(b.events)
+	for i, event := range b.events {
+		if event.id > lastID {
+			start = i
+			break
+		}
+	}
+	return b.events[start:], b.notify
+}
+
+// recordEvents 按采集间隔把最新数据写入缓冲区，直到服务器关闭
+func (s *Server) recordEvents() {
+	ticker := time.NewTicker(sampleInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.events.append(s.sampler.Snapshot())
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// handleStream 以 Server-Sent Events 推送与 WebSocket 相同的主题
+// 参数: topics=cpu,memory,process?top=25（默认全部），interval=秒（最小为采集间隔）
+// 断线重连时根据 Last-Event-ID 请求头（或 lastEventId 参数）补发缓冲区中错过的快照
+func (s *Server) handleStream(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	specs := s.sampler.Names()
+	if raw := query.Get("topics"); raw != "" {
+		specs = strings.Split(raw, ",")
+	}
+	var topics []topic
+	for _, spec := range specs {
+		t, err := s.parseTopic(strings.TrimSpace(spec))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		topics = append(topics, t)
+	}
+
+	interval := sampleInterval
+	if raw := query.Get("interval"); raw != "" {
+		seconds, err := strconv.ParseFloat(raw, 64)
+		if err != nil || seconds <= 0 {
+			http.Error(w, "interval 必须是正数（秒）", http.StatusBadRequest)
+			return
+		}
+		interval = time.Duration(seconds * float64(time.Second))
+	}
+
+	lastID := int64(0)
+	if raw := r.Header.Get("Last-Event-ID"); raw != "" {
+		lastID, _ = strconv.ParseInt(raw, 10, 64)
+	} else if raw := query.Get("lastEventId"); raw != "" {
+		lastID, _ = strconv.ParseInt(raw, 10, 64)
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "不支持流式响应", http.StatusInternalServerError)
+		return
+	}
+	controller := http.NewResponseController(w)
+
+	w.Header().Set("Content-Type", "text/event-stream; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-cache")
+	// 关闭 nginx 等反向代理的响应缓冲
+	w.Header().Set("X-Accel-Buffering", "no")
+	fmt.Fprintf(w, "retry: %d\n\n", streamRetry)
+	flusher.Flush()
+
+	who := principalFrom(r)
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+
+	var lastSent time.Time
+	for {
+		events, next := s.events.since(lastID)
+		controller.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+		for _, event := range events {
+			lastID = event.id
+			at := time.UnixMilli(event.id)
+			// 按客户端的间隔跳过快照，留出少量余量以免因时间抖动多跳过一个
+			if !lastSent.IsZero() && at.Sub(lastSent) < interval-sampleInterval/4 {
+				continue
+			}
+			lastSent = at
+			if err := writeStreamEvent(w, event, topics, who); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+
+		select {
+		case <-next:
+		case <-heartbeat.C:
+			controller.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// writeStreamEvent 把一个快照写成多个 SSE 事件，每个主题一个（event 为主题名，id 相同）
+func writeStreamEvent(w http.ResponseWriter, event streamEvent, topics []topic, who principal) error {
+	for _, t := range topics {
+		value, ok := topicValue(t, event.data, who)
+		if !ok {
+			continue
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.id, t.key, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package web
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"syspulse/internal/config"
+)
+
+func TestEventBufferSince(t *testing.T) {
+	b := newEventBuffer()
+	if events, _ := b.since(0); len(events) != 0 {
+		t.Fatalf("empty buffer returned %d events", len(events))
+	}
+
+	for i := 0; i < streamBufferSize+5; i++ {
+		b.append(map[string]interface{}{"cpu": i})
+	}
+	if len(b.events) != streamBufferSize {
+		t.Fatalf("buffer holds %d events, want %d", len(b.events), streamBufferSize)
+	}
+	for i := 1; i < len(b.events); i++ {
+		if b.events[i].id <= b.events[i-1].id {
+			t.Fatalf("ids not increasing: %d after %d", b.events[i].id, b.events[i-1].id)
+		}
+	}
+	first, last := b.events[0].id, b.events[len(b.events)-1].id
+
+	tests := []struct {
+		name   string
+		lastID int64
+		want   int
+	}{
+		{name: "new client gets latest", lastID: 0, want: 1},
+		{name: "older than buffer", lastID: first - 1000, want: streamBufferSize},
+		{name: "first buffered", lastID: first, want: streamBufferSize - 1},
+		{name: "up to date", lastID: last, want: 0},
+		{name: "from the future", lastID: last + 1000, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, next := b.since(tt.lastID)
+			if len(events) != tt.want {
+				t.Fatalf("got %d events, want %d", len(events), tt.want)
+			}
+			if tt.want > 0 && events[len(events)-1].id != last {
+				t.Errorf("last event id = %d, want %d", events[len(events)-1].id, last)
+			}
+			select {
+			case <-next:
+				t.Error("notify channel closed before the next append")
+			default:
+			}
+		})
+	}
+
+	_, next := b.since(last)
+	b.append(map[string]interface{}{"cpu": -1})
+	select {
+	case <-next:
+	default:
+		t.Error("append did not wake waiting clients")
+	}
+}
+
+// readStreamIDs 读取 SSE 事件的 id，收到 n 个或超时后返回
+func readStreamIDs(t *testing.T, ts *httptest.Server, query string, header http.Header, n int, timeout time.Duration) []int64 {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	req, _ := http.NewRequestWithContext(ctx, "GET", ts.URL+"/api/stream?"+query, nil)
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	var ids []int64
+	scanner := bufio.NewScanner(resp.Body)
+	for len(ids) < n && scanner.Scan() {
+		if raw, ok := strings.CutPrefix(scanner.Text(), "id: "); ok {
+			id, err := strconv.ParseInt(raw, 10, 64)
+			if err != nil {
+				t.Fatalf("bad event id %q", raw)
+			}
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func TestStreamResume(t *testing.T) {
+	s, ts := newTestServer(t, config.Default(), nil)
+	for i := 0; i < streamBufferSize+5; i++ {
+		s.events.append(map[string]interface{}{"cpu": map[string]interface{}{"UsagePercent": i}})
+	}
+	var buffered []int64
+	for _, event := range s.events.events {
+		buffered = append(buffered, event.id)
+	}
+	first, last := buffered[0], buffered[len(buffered)-1]
+
+	// interval 很小，不按间隔跳过快照
+	const query = "topics=cpu&interval=0.001"
+	tests := []struct {
+		name   string
+		query  string
+		header string
+		want   []int64
+	}{
+		{name: "new connection", query: query, want: buffered[len(buffered)-1:]},
+		{name: "missed events", query: query, header: strconv.FormatInt(buffered[len(buffered)-4], 10), want: buffered[len(buffered)-3:]},
+		{name: "query parameter", query: query + "&lastEventId=" + strconv.FormatInt(buffered[len(buffered)-2], 10), want: buffered[len(buffered)-1:]},
+		{name: "header wins over query", query: query + "&lastEventId=" + strconv.FormatInt(first, 10), header: strconv.FormatInt(buffered[len(buffered)-3], 10), want: buffered[len(buffered)-2:]},
+		{name: "older than buffer", query: query, header: strconv.FormatInt(first-5000, 10), want: buffered},
+		{name: "non-numeric id", query: query, header: "abc", want: buffered[len(buffered)-1:]},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.header != "" {
+				header.Set("Last-Event-ID", tt.header)
+			}
+			got := readStreamIDs(t, ts, tt.query, header, len(tt.want)+1, 300*time.Millisecond)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("event ids = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// 已经是最新的客户端等待下一个快照
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		s.events.append(map[string]interface{}{"cpu": map[string]interface{}{"UsagePercent": -1}})
+	}()
+	header := http.Header{"Last-Event-Id": []string{strconv.FormatInt(last, 10)}}
+	got := readStreamIDs(t, ts, query, header, 1, 3*time.Second)
+	if len(got) != 1 || got[0] <= last {
+		t.Errorf("up-to-date client got %v, want one event after %d", got, last)
+	}
+}
@@ -0,0 +1,393 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"syspulse/internal/monitor"
+
+	"github.com/gorilla/websocket"
+)
+
+// wsSubprotocol 订阅协议的 WebSocket 子协议名
+// 未协商该子协议的客户端（旧版前端、脚本）仍然按间隔收到完整数据
+const wsSubprotocol = "syspulse.v1"
+
+const (
+	// wsHeartbeatInterval 服务器发送心跳消息和 ping 帧的间隔
+	wsHeartbeatInterval = 15 * time.Second
+	// wsReadTimeout 超过该时间没有收到客户端的任何消息或 pong 即断开
+	wsReadTimeout = 2*wsHeartbeatInterval + 10*time.Second
+	// 客户端可设置的推送间隔范围
+	wsMinInterval = time.Second
+	wsMaxInterval = time.Hour
+	// wsMaxMessageSize 客户端消息的大小上限
+	wsMaxMessageSize = 64 * 1024
+)
+
+const (
+	// defaultProcessTop 进程列表默认返回的条数
+	defaultProcessTop = 10
+	// maxProcessTop 后台采集的进程条数，也是 process?top= 的上限
+	maxProcessTop = 100
+	// defaultNetTopCount 后台采集的进程带宽条数，也是 /network/processes 默认的条数
+	defaultNetTopCount = 20
+)
+
+// clientMessage 客户端发送的消息
+type clientMessage struct {
+	// subscribe、unsubscribe、interval、ping
+	Type string `json:"type"`
+	// 可选，服务器的应答中原样带回
+	ID string `json:"id,omitempty"`
+	// 订阅的主题，如 "cpu"、"process?top=25"
+	Topics []string `json:"topics,omitempty"`
+	// 推送间隔（秒），可随 subscribe 一起设置
+	Interval float64 `json:"interval,omitempty"`
+}
+
+// serverMessage 服务器发送的消息
+type serverMessage struct {
+	// welcome、subscribed、unsubscribed、interval、snapshot、delta、heartbeat、pong、error
+	Type string `json:"type"`
+	ID   string `json:"id,omitempty"`
+	// snapshot 和 delta 的序号，每个连接从 1 开始递增
+	Seq uint64 `json:"seq,omitempty"`
+	// snapshot 为主题的完整数据，delta 为相对上一次推送的 JSON Merge Patch (RFC 7386)
+	Data map[string]interface{} `json:"data,omitempty"`
+	// 当前订阅的主题（welcome 中为可订阅的主题）
+	Topics   []string `json:"topics,omitempty"`
+	Interval float64  `json:"interval,omitempty"`
+	Time     string   `json:"time,omitempty"`
+	// error 的错误码和说明
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// topic 解析后的订阅主题
+type topic struct {
+	key  string // 原始字符串，作为订阅的唯一标识
+	name string // 采集器名称
+	top  int    // process 的条数
+}
+
+// parseTopic 解析 "name?param=value" 格式的主题
+func (s *Server) parseTopic(spec string) (topic, error) {
+	name, rawQuery, _ := strings.Cut(spec, "?")
+	if !s.sampler.Has(name) {
+		return topic{}, fmt.Errorf("未知主题 %q", name)
+	}
+
+	params, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return topic{}, fmt.Errorf("主题 %q 参数格式错误: %v", spec, err)
+	}
+
+	t := topic{key: spec, name: name}
+	for key, values := range params {
+		if name != "process" || key != "top" {
+			return topic{}, fmt.Errorf("主题 %q 不支持参数 %s", name, key)
+		}
+		n, err := strconv.Atoi(values[0])
+		if err != nil || n <= 0 || n > maxProcessTop {
+			return topic{}, fmt.Errorf("top 必须是 1-%d 之间的整数", maxProcessTop)
+		}
+		t.top = n
+	}
+	if name == "process" && t.top == 0 {
+		t.top = defaultProcessTop
+	}
+	return t, nil
+}
+
+// topicValue 从采集数据中取出主题的值，并按请求者的角色去掉不可见的字段
+func topicValue(t topic, snapshot map[string]interface{}, who principal) (interface{}, bool) {
+	value, ok := snapshot[t.name]
+	if !ok {
+		return nil, false
+	}
+	if info, ok := value.(monitor.ProcessInfo); ok {
+		value = redactProcessInfo(limitProcesses(info, t.top), who)
+	}
+	return value, true
+}
+
+// limitProcesses 截取前 n 个进程
+func limitProcesses(info monitor.ProcessInfo, n int) monitor.ProcessInfo {
+	if len(info.TopCPU) > n {
+		info.TopCPU = info.TopCPU[:n]
+	}
+	if len(info.TopMemory) > n {
+		info.TopMemory = info.TopMemory[:n]
+	}
+	return info
+}
+
+// subscription 一个已订阅的主题及最近一次推送给客户端的值
+type subscription struct {
+	topic topic
+	last  interface{} // 通用 JSON 表示，nil 表示还没推送过
+}
+
+// wsSession 一个使用订阅协议的 WebSocket 连接（只由处理函数的 goroutine 写入）
+type wsSession struct {
+	server   *Server
+	conn     *websocket.Conn
+	who      principal
+	interval time.Duration
+	subs     map[string]*subscription
+	seq      uint64
+}
+
+// serveSubscriptions 运行订阅协议，直到连接断开或服务器关闭
+func (s *Server) serveSubscriptions(conn *websocket.Conn, who principal, interval time.Duration) {
+	session := &wsSession{
+		server:   s,
+		conn:     conn,
+		who:      who,
+		interval: interval,
+		subs:     make(map[string]*subscription),
+	}
+
+	// 读取客户端消息，收到 pong 或任何消息都延长读超时
+	conn.SetReadLimit(wsMaxMessageSize)
+	conn.SetReadDeadline(time.Now().Add(wsReadTimeout))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(wsReadTimeout))
+	})
+
+	inbox := make(chan clientMessage)
+	readDone := make(chan struct{})
+	quit := make(chan struct{})
+	defer close(quit)
+	go func() {
+		defer close(readDone)
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			conn.SetReadDeadline(time.Now().Add(wsReadTimeout))
+
+			var msg clientMessage
+			if err := json.Unmarshal(data, &msg); err != nil {
+				msg = clientMessage{Type: "invalid"}
+			}
+			select {
+			case inbox <- msg:
+			case <-quit:
+				return
+			}
+		}
+	}()
+
+	if err := session.send(serverMessage{
+		Type:     "welcome",
+		Topics:   s.sampler.Names(),
+		Interval: interval.Seconds(),
+	}); err != nil {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	heartbeat := time.NewTicker(wsHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		var err error
+		select {
+		case msg := <-inbox:
+			err = session.handle(msg, ticker)
+		case <-ticker.C:
+			err = session.push()
+		case <-heartbeat.C:
+			err = session.send(serverMessage{Type: "heartbeat", Time: time.Now().Format(time.RFC3339)})
+			if err == nil {
+				err = conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteTimeout))
+			}
+		case <-readDone:
+			return
+		case <-s.done:
+			return
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// handle 处理一条客户端消息
+func (ws *wsSession) handle(msg clientMessage, ticker *time.Ticker) error {
+	switch msg.Type {
+	case "subscribe":
+		if msg.Interval != 0 {
+			if err := ws.setInterval(msg, ticker); err != nil {
+				return err
+			}
+		}
+		for _, spec := range msg.Topics {
+			if _, ok := ws.subs[spec]; ok {
+				continue
+			}
+			t, err := ws.server.parseTopic(spec)
+			if err != nil {
+				if err := ws.sendError(msg.ID, "invalid_topic", err.Error()); err != nil {
+					return err
+				}
+				continue
+			}
+			ws.subs[spec] = &subscription{topic: t}
+		}
+		if err := ws.send(serverMessage{Type: "subscribed", ID: msg.ID, Topics: ws.topics()}); err != nil {
+			return err
+		}
+		// 新订阅的主题立即推送完整数据，不等下一个间隔
+		return ws.push()
+
+	case "unsubscribe":
+		for _, spec := range msg.Topics {
+			delete(ws.subs, spec)
+		}
+		return ws.send(serverMessage{Type: "unsubscribed", ID: msg.ID, Topics: ws.topics()})
+
+	case "interval":
+		return ws.setInterval(msg, ticker)
+
+	case "ping":
+		return ws.send(serverMessage{Type: "pong", ID: msg.ID, Time: time.Now().Format(time.RFC3339)})
+
+	case "invalid":
+		return ws.sendError("", "invalid_message", "消息不是有效的 JSON")
+	}
+
+	return ws.sendError(msg.ID, "unknown_type", fmt.Sprintf("未知消息类型 %q", msg.Type))
+}
+
+// setInterval 修改推送间隔
+func (ws *wsSession) setInterval(msg clientMessage, ticker *time.Ticker) error {
+	interval := time.Duration(msg.Interval * float64(time.Second))
+	if interval < wsMinInterval || interval > wsMaxInterval {
+		return ws.sendError(msg.ID, "invalid_interval",
+			fmt.Sprintf("间隔必须在 %s 到 %s 之间", wsMinInterval, wsMaxInterval))
+	}
+	ws.interval = interval
+	ticker.Reset(interval)
+	return ws.send(serverMessage{Type: "interval", ID: msg.ID, Interval: interval.Seconds()})
+}
+
+// push 推送订阅主题的变化：第一次推送完整数据 (snapshot)，之后只推送变化的部分 (delta)
+func (ws *wsSession) push() error {
+	snapshot := ws.server.sampler.Snapshot()
+	full := make(map[string]interface{})
+	delta := make(map[string]interface{})
+
+	for key, sub := range ws.subs {
+		value, ok := topicValue(sub.topic, snapshot, ws.who)
+		if !ok {
+			continue
+		}
+		current, err := toGenericJSON(value)
+		if err != nil {
+			continue
+		}
+		if sub.last == nil {
+			full[key] = current
+		} else if patch, changed, ok := mergePatch(sub.last, current); !ok {
+			// 有值变为 null，Merge Patch 无法表示，改为推送该主题的完整数据
+			full[key] = current
+		} else if changed {
+			delta[key] = patch
+		}
+		sub.last = current
+	}
+
+	if len(full) > 0 {
+		ws.seq++
+		if err := ws.send(serverMessage{Type: "snapshot", Seq: ws.seq, Data: full}); err != nil {
+			return err
+		}
+	}
+	if len(delta) > 0 {
+		ws.seq++
+		if err := ws.send(serverMessage{Type: "delta", Seq: ws.seq, Data: delta}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// topics 当前订阅的主题（排序后）
+func (ws *wsSession) topics() []string {
+	topics := make([]string, 0, len(ws.subs))
+	for key := range ws.subs {
+		topics = append(topics, key)
+	}
+	sort.Strings(topics)
+	return topics
+}
+
+func (ws *wsSession) send(msg serverMessage) error {
+	ws.conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+	return ws.conn.WriteJSON(msg)
+}
+
+func (ws *wsSession) sendError(id, code, message string) error {
+	return ws.send(serverMessage{Type: "error", ID: id, Code: code, Message: message})
+}
+
+// toGenericJSON 把值转换为 encoding/json 的通用表示（map、slice、float64 等），便于比较
+func toGenericJSON(value interface{}) (interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var generic interface{}
+	err = json.Unmarshal(data, &generic)
+	return generic, err
+}
+
+// mergePatch 计算从 old 到 current 的 JSON Merge Patch (RFC 7386)
+// 对象按字段递归比较，被删除的字段为 null，数组和其他值整体替换
+// Merge Patch 中的 null 表示删除字段，无法表示值变为 null，此时 ok 为 false
+func mergePatch(old, current interface{}) (patch interface{}, changed, ok bool) {
+	oldMap, oldIsMap := old.(map[string]interface{})
+	currentMap, currentIsMap := current.(map[string]interface{})
+	if !oldIsMap || !currentIsMap {
+		if reflect.DeepEqual(old, current) {
+			return nil, false, true
+		}
+		return current, true, current != nil
+	}
+
+	fields := make(map[string]interface{})
+	for key, value := range currentMap {
+		previous, existed := oldMap[key]
+		if !existed {
+			if value == nil {
+				return nil, true, false
+			}
+			fields[key] = value
+			continue
+		}
+		diff, changed, ok := mergePatch(previous, value)
+		if !ok {
+			return nil, true, false
+		}
+		if changed {
+			fields[key] = diff
+		}
+	}
+	for key := range oldMap {
+		if _, exists := currentMap[key]; !exists {
+			fields[key] = nil
+		}
+	}
+	return fields, len(fields) > 0, true
+}
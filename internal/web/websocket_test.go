@@ -0,0 +1,219 @@
+package web
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"syspulse/internal/config"
+
+	"github.com/gorilla/websocket"
+)
+
+// applyPatch 按 RFC 7386 把 patch 应用到 target（与前端的 applyMergePatch 相同）
+func applyPatch(target, patch interface{}) interface{} {
+	fields, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	result := make(map[string]interface{})
+	if object, ok := target.(map[string]interface{}); ok {
+		for key, value := range object {
+			result[key] = value
+		}
+	}
+	for key, value := range fields {
+		if value == nil {
+			delete(result, key)
+		} else {
+			result[key] = applyPatch(result[key], value)
+		}
+	}
+	return result
+}
+
+func TestMergePatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		old     string
+		current string
+		patch   string
+		changed bool
+		ok      bool
+	}{
+		{name: "unchanged", old: `{"a":1,"b":{"c":[1,2]}}`, current: `{"a":1,"b":{"c":[1,2]}}`, changed: false, ok: true},
+		{name: "scalar", old: `{"a":1,"b":2}`, current: `{"a":1,"b":3}`, patch: `{"b":3}`, changed: true, ok: true},
+		{name: "nested", old: `{"a":{"b":1,"c":2}}`, current: `{"a":{"b":1,"c":5}}`, patch: `{"a":{"c":5}}`, changed: true, ok: true},
+		{name: "added", old: `{"a":1}`, current: `{"a":1,"b":{"c":1}}`, patch: `{"b":{"c":1}}`, changed: true, ok: true},
+		{name: "removed", old: `{"a":1,"b":2}`, current: `{"a":1}`, patch: `{"b":null}`, changed: true, ok: true},
+		{name: "array replaced", old: `{"a":[1,2,3]}`, current: `{"a":[1,3]}`, patch: `{"a":[1,3]}`, changed: true, ok: true},
+		{name: "object to scalar", old: `{"a":{"b":1}}`, current: `{"a":"x"}`, patch: `{"a":"x"}`, changed: true, ok: true},
+		{name: "null unchanged", old: `{"a":null}`, current: `{"a":null}`, changed: false, ok: true},
+		{name: "null to value", old: `{"a":null}`, current: `{"a":[1]}`, patch: `{"a":[1]}`, changed: true, ok: true},
+		{name: "value to null", old: `{"a":1,"b":2}`, current: `{"a":1,"b":null}`, changed: true, ok: false},
+		{name: "nested value to null", old: `{"a":{"b":"2026-01-01T00:00:00Z"}}`, current: `{"a":{"b":null}}`, changed: true, ok: false},
+		{name: "added null", old: `{"a":1}`, current: `{"a":1,"b":null}`, changed: true, ok: false},
+		{name: "topic to null", old: `{"a":1}`, current: `null`, changed: true, ok: false},
+	}
+
+	decode := func(s string) interface{} {
+		var v interface{}
+		if err := json.Unmarshal([]byte(s), &v); err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			old, current := decode(tt.old), decode(tt.current)
+			patch, changed, ok := mergePatch(old, current)
+			if changed != tt.changed || ok != tt.ok {
+				t.Fatalf("changed, ok = %v, %v, want %v, %v", changed, ok, tt.changed, tt.ok)
+			}
+			if !ok || !changed {
+				return
+			}
+			if !reflect.DeepEqual(patch, decode(tt.patch)) {
+				got, _ := json.Marshal(patch)
+				t.Errorf("patch = %s, want %s", got, tt.patch)
+			}
+			// 客户端应用补丁后必须得到新值
+			if got := applyPatch(old, patch); !reflect.DeepEqual(got, current) {
+				t.Errorf("applied patch = %v, want %v", got, current)
+			}
+		})
+	}
+}
+
+func TestParseTopic(t *testing.T) {
+	s, _ := newTestServer(t, config.Default(), nil)
+
+	tests := []struct {
+		spec    string
+		want    topic
+		wantErr string
+	}{
+		{spec: "cpu", want: topic{key: "cpu", name: "cpu"}},
+		{spec: "process", want: topic{key: "process", name: "process", top: defaultProcessTop}},
+		{spec: "process?top=25", want: topic{key: "process?top=25", name: "process", top: 25}},
+		{spec: "process?top=100", want: topic{key: "process?top=100", name: "process", top: 100}},
+		{spec: "process?top=0", wantErr: "top 必须是 1-100"},
+		{spec: "process?top=101", wantErr: "top 必须是 1-100"},
+		{spec: "process?top=ten", wantErr: "top 必须是 1-100"},
+		{spec: "process?sort=cpu", wantErr: "不支持参数 sort"},
+		{spec: "cpu?top=5", wantErr: "不支持参数 top"},
+		{spec: "process?top=%zz", wantErr: "参数格式错误"},
+		{spec: "gpu", wantErr: "未知主题"},
+		{spec: "", wantErr: "未知主题"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := s.parseTopic(tt.spec)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("parseTopic = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// setSnapshot 修改测试服务器快照中的一项
+func setSnapshot(s *Server, name string, value interface{}) {
+	s.sampler.mu.Lock()
+	s.sampler.data[name] = value
+	s.sampler.mu.Unlock()
+}
+
+func TestWebSocketSession(t *testing.T) {
+	s, ts := newTestServer(t, config.Default(), map[string]interface{}{
+		"cpu": map[string]interface{}{"UsagePercent": 10, "Model": "test", "Since": "2026-01-01T00:00:00Z"},
+	})
+	conn := dialWebSocket(t, ts, "", []string{wsSubprotocol})
+
+	send := func(msg interface{}) {
+		t.Helper()
+		if err := conn.WriteJSON(msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+	read := func() serverMessage {
+		t.Helper()
+		var msg serverMessage
+		if err := conn.ReadJSON(&msg); err != nil {
+			t.Fatal(err)
+		}
+		return msg
+	}
+
+	welcome := read()
+	if welcome.Type != "welcome" || welcome.Interval != 2 || !reflect.DeepEqual(welcome.Topics, s.sampler.Names()) {
+		t.Fatalf("welcome = %+v", welcome)
+	}
+
+	// 未知主题返回错误，其余主题照常订阅并立即推送完整数据
+	send(clientMessage{Type: "subscribe", ID: "1", Topics: []string{"cpu", "gpu"}})
+	if msg := read(); msg.Type != "error" || msg.ID != "1" || msg.Code != "invalid_topic" {
+		t.Errorf("invalid topic reply = %+v", msg)
+	}
+	if msg := read(); msg.Type != "subscribed" || msg.ID != "1" || !reflect.DeepEqual(msg.Topics, []string{"cpu"}) {
+		t.Errorf("subscribed = %+v", msg)
+	}
+	snapshot := read()
+	if snapshot.Type != "snapshot" || snapshot.Seq != 1 || snapshot.Data["cpu"].(map[string]interface{})["Model"] != "test" {
+		t.Fatalf("snapshot = %+v", snapshot)
+	}
+
+	send(clientMessage{Type: "interval", ID: "2", Interval: 0.5})
+	if msg := read(); msg.Type != "error" || msg.Code != "invalid_interval" {
+		t.Errorf("invalid interval reply = %+v", msg)
+	}
+	send(clientMessage{Type: "interval", ID: "3", Interval: 1})
+	if msg := read(); msg.Type != "interval" || msg.ID != "3" || msg.Interval != 1 {
+		t.Errorf("interval reply = %+v", msg)
+	}
+
+	// 只推送变化的字段
+	setSnapshot(s, "cpu", map[string]interface{}{"UsagePercent": 20, "Model": "test", "Since": "2026-01-01T00:00:00Z"})
+	delta := readUntil(t, conn, "delta")
+	if delta.Seq != 2 || !reflect.DeepEqual(delta.Data, map[string]interface{}{"cpu": map[string]interface{}{"UsagePercent": float64(20)}}) {
+		t.Errorf("delta = %+v", delta)
+	}
+
+	// 值变为 null 时推送完整数据，而不是表示删除的 null
+	setSnapshot(s, "cpu", map[string]interface{}{"UsagePercent": 20, "Model": "test", "Since": nil})
+	snapshot = readUntil(t, conn, "snapshot")
+	cpu := snapshot.Data["cpu"].(map[string]interface{})
+	if since, ok := cpu["Since"]; snapshot.Seq != 3 || !ok || since != nil {
+		t.Errorf("snapshot after null = %+v", snapshot)
+	}
+
+	send(clientMessage{Type: "ping", ID: "4"})
+	if msg := readUntil(t, conn, "pong"); msg.ID != "4" || msg.Time == "" {
+		t.Errorf("pong = %+v", msg)
+	}
+	send(clientMessage{Type: "unsubscribe", ID: "5", Topics: []string{"cpu"}})
+	if msg := readUntil(t, conn, "unsubscribed"); msg.ID != "5" || len(msg.Topics) != 0 {
+		t.Errorf("unsubscribed = %+v", msg)
+	}
+	send(clientMessage{Type: "reboot", ID: "6"})
+	if msg := read(); msg.Type != "error" || msg.ID != "6" || msg.Code != "unknown_type" {
+		t.Errorf("unknown type reply = %+v", msg)
+	}
+	if err := conn.WriteMessage(websocket.TextMessage, []byte("not json")); err != nil {
+		t.Fatal(err)
+	}
+	if msg := read(); msg.Type != "error" || msg.Code != "invalid_message" {
+		t.Errorf("invalid message reply = %+v", msg)
+	}
+}